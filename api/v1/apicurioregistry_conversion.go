@@ -0,0 +1,210 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// AnnotationV1beta2Spec preserves the v1beta2 spec when it contains values that cannot be represented in v1,
+// such as a Secret reference for the SQL password or additional hostnames, so they survive a round-trip through v1.
+const AnnotationV1beta2Spec = "registry.apicur.io/v1beta2-spec"
+
+var _ conversion.Convertible = &ApicurioRegistry{}
+
+// ConvertTo converts this ApicurioRegistry to the hub version (v1beta2).
+func (this *ApicurioRegistry) ConvertTo(hubRaw conversion.Hub) error {
+	hub := hubRaw.(*v1beta2.ApicurioRegistry)
+	src := this.DeepCopy()
+
+	hub.ObjectMeta = src.ObjectMeta
+	hub.Spec = v1beta2.ApicurioRegistrySpec{}
+	if value, exists := src.Annotations[AnnotationV1beta2Spec]; exists {
+		if err := json.Unmarshal([]byte(value), &hub.Spec); err != nil {
+			return err
+		}
+		delete(hub.Annotations, AnnotationV1beta2Spec)
+		if len(hub.Annotations) == 0 {
+			hub.Annotations = nil
+		}
+	}
+
+	// Values present in v1 take precedence over the preserved ones
+	srcConfig := src.Spec.Configuration
+	config := &hub.Spec.Configuration
+	config.Persistence = v1beta2.ApicurioRegistryPersistence(srcConfig.Persistence)
+	config.Sql.DataSource.Url = srcConfig.Sql.DataSource.Url
+	config.Sql.DataSource.UserName = srcConfig.Sql.DataSource.UserName
+	config.Sql.DataSource.Password.Value = srcConfig.Sql.DataSource.Password
+	config.Kafkasql.BootstrapServers = srcConfig.Kafkasql.BootstrapServers
+	config.Kafkasql.Security.Tls.TruststoreSecretName = srcConfig.Kafkasql.Security.Tls.TruststoreSecretName
+	config.Kafkasql.Security.Tls.KeystoreSecretName = srcConfig.Kafkasql.Security.Tls.KeystoreSecretName
+	config.Kafkasql.Security.Scram.TruststoreSecretName = srcConfig.Kafkasql.Security.Scram.TruststoreSecretName
+	config.Kafkasql.Security.Scram.User = srcConfig.Kafkasql.Security.Scram.User
+	config.Kafkasql.Security.Scram.PasswordSecretName = srcConfig.Kafkasql.Security.Scram.PasswordSecretName
+	config.Kafkasql.Security.Scram.Mechanism = v1beta2.ApicurioRegistryScramMechanism(srcConfig.Kafkasql.Security.Scram.Mechanism)
+	config.UI.ReadOnly = srcConfig.UI.ReadOnly
+	config.LogLevel = srcConfig.LogLevel
+	config.RegistryLogLevel = srcConfig.RegistryLogLevel
+	config.Security.Keycloak.Url = srcConfig.Security.Keycloak.Url
+	config.Security.Keycloak.Realm = srcConfig.Security.Keycloak.Realm
+	config.Security.Keycloak.ApiClientId = srcConfig.Security.Keycloak.ApiClientId
+	config.Security.Keycloak.UiClientId = srcConfig.Security.Keycloak.UiClientId
+	config.Security.Https.DisableHttp = srcConfig.Security.Https.DisableHttp
+	config.Security.Https.SecretName = srcConfig.Security.Https.SecretName
+	config.Env = srcConfig.Env
+
+	srcDeployment := src.Spec.Deployment
+	deployment := &hub.Spec.Deployment
+	deployment.Replicas = srcDeployment.Replicas
+	deployment.Hosts.Primary = srcDeployment.Host
+	deployment.Affinity = srcDeployment.Affinity
+	deployment.Tolerations = srcDeployment.Tolerations
+	deployment.Metadata.Annotations = srcDeployment.Metadata.Annotations
+	deployment.Metadata.Labels = srcDeployment.Metadata.Labels
+	deployment.Image = srcDeployment.Image
+	deployment.ImagePullSecrets = srcDeployment.ImagePullSecrets
+	deployment.ManagedResources.DisableIngress = srcDeployment.ManagedResources.DisableIngress
+	deployment.ManagedResources.DisableNetworkPolicy = srcDeployment.ManagedResources.DisableNetworkPolicy
+	deployment.ManagedResources.DisablePodDisruptionBudget = srcDeployment.ManagedResources.DisablePodDisruptionBudget
+	deployment.PodTemplateSpec = v1beta2.ApicurioRegistryPodTemplateSpec{
+		Metadata: v1beta2.ApicurioRegistryObjectMeta(srcDeployment.PodTemplateSpecPreview.Metadata),
+		Spec:     v1beta2.ApicurioRegistryPodSpec(srcDeployment.PodTemplateSpecPreview.Spec),
+	}
+
+	hub.Status = v1beta2.ApicurioRegistryStatus{
+		Info:       v1beta2.ApicurioRegistryStatusInfo(src.Status.Info),
+		Conditions: src.Status.Conditions,
+	}
+	for _, r := range src.Status.ManagedResources {
+		hub.Status.ManagedResources = append(hub.Status.ManagedResources, v1beta2.ApicurioRegistryStatusManagedResource(r))
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1beta2) to this ApicurioRegistry.
+func (this *ApicurioRegistry) ConvertFrom(hubRaw conversion.Hub) error {
+	src := hubRaw.(*v1beta2.ApicurioRegistry).DeepCopy()
+
+	this.ObjectMeta = src.ObjectMeta
+	delete(this.Annotations, AnnotationV1beta2Spec)
+
+	srcConfig := src.Spec.Configuration
+	this.Spec.Configuration = ApicurioRegistrySpecConfiguration{
+		Persistence: string(srcConfig.Persistence),
+		Sql: ApicurioRegistrySpecConfigurationSql{
+			DataSource: ApicurioRegistrySpecConfigurationDataSource{
+				Url:      srcConfig.Sql.DataSource.Url,
+				UserName: srcConfig.Sql.DataSource.UserName,
+				Password: srcConfig.Sql.DataSource.Password.Value,
+			},
+		},
+		Kafkasql: ApicurioRegistrySpecConfigurationKafkasql{
+			BootstrapServers: srcConfig.Kafkasql.BootstrapServers,
+			Security: ApicurioRegistrySpecConfigurationKafkaSecurity{
+				Tls: ApicurioRegistrySpecConfigurationKafkaSecurityTls{
+					TruststoreSecretName: srcConfig.Kafkasql.Security.Tls.TruststoreSecretName,
+					KeystoreSecretName:   srcConfig.Kafkasql.Security.Tls.KeystoreSecretName,
+				},
+				Scram: ApicurioRegistrySpecConfigurationKafkaSecurityScram{
+					TruststoreSecretName: srcConfig.Kafkasql.Security.Scram.TruststoreSecretName,
+					User:                 srcConfig.Kafkasql.Security.Scram.User,
+					PasswordSecretName:   srcConfig.Kafkasql.Security.Scram.PasswordSecretName,
+					Mechanism:            string(srcConfig.Kafkasql.Security.Scram.Mechanism),
+				},
+			},
+		},
+		UI: ApicurioRegistrySpecConfigurationUI{
+			ReadOnly: srcConfig.UI.ReadOnly,
+		},
+		LogLevel:         srcConfig.LogLevel,
+		RegistryLogLevel: srcConfig.RegistryLogLevel,
+		Security: ApicurioRegistrySpecConfigurationSecurity{
+			Keycloak: ApicurioRegistrySpecConfigurationSecurityKeycloak{
+				Url:         srcConfig.Security.Keycloak.Url,
+				Realm:       srcConfig.Security.Keycloak.Realm,
+				ApiClientId: srcConfig.Security.Keycloak.ApiClientId,
+				UiClientId:  srcConfig.Security.Keycloak.UiClientId,
+			},
+			Https: ApicurioRegistrySpecConfigurationSecurityHttps{
+				DisableHttp: srcConfig.Security.Https.DisableHttp,
+				SecretName:  srcConfig.Security.Https.SecretName,
+			},
+		},
+		Env: srcConfig.Env,
+	}
+
+	srcDeployment := src.Spec.Deployment
+	this.Spec.Deployment = ApicurioRegistrySpecDeployment{
+		Replicas:    srcDeployment.Replicas,
+		Host:        srcDeployment.Hosts.Primary,
+		Affinity:    srcDeployment.Affinity,
+		Tolerations: srcDeployment.Tolerations,
+		Metadata: ApicurioRegistrySpecDeploymentMetadata{
+			Annotations: srcDeployment.Metadata.Annotations,
+			Labels:      srcDeployment.Metadata.Labels,
+		},
+		Image:            srcDeployment.Image,
+		ImagePullSecrets: srcDeployment.ImagePullSecrets,
+		ManagedResources: ApicurioRegistrySpecDeploymentManagedResources{
+			DisableIngress:             srcDeployment.ManagedResources.DisableIngress,
+			DisableNetworkPolicy:       srcDeployment.ManagedResources.DisableNetworkPolicy,
+			DisablePodDisruptionBudget: srcDeployment.ManagedResources.DisablePodDisruptionBudget,
+		},
+		PodTemplateSpecPreview: ApicurioRegistryPodTemplateSpec{
+			Metadata: ApicurioRegistryObjectMeta(srcDeployment.PodTemplateSpec.Metadata),
+			Spec:     ApicurioRegistryPodSpec(srcDeployment.PodTemplateSpec.Spec),
+		},
+	}
+
+	this.Status = ApicurioRegistryStatus{
+		Info: ApicurioRegistryStatusInfo{
+			Host: src.Status.Info.Host,
+		},
+		Conditions: src.Status.Conditions,
+	}
+	for _, r := range src.Status.ManagedResources {
+		this.Status.ManagedResources = append(this.Status.ManagedResources, ApicurioRegistryStatusManagedResource{
+			Kind:      r.Kind,
+			Name:      r.Name,
+			Namespace: r.Namespace,
+		})
+	}
+
+	// Preserve the v1beta2 spec if it cannot be fully represented in v1
+	check := &v1beta2.ApicurioRegistry{}
+	if err := this.ConvertTo(check); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(check.Spec, src.Spec) {
+		value, err := json.Marshal(src.Spec)
+		if err != nil {
+			return err
+		}
+		if this.Annotations == nil {
+			this.Annotations = make(map[string]string)
+		}
+		this.Annotations[AnnotationV1beta2Spec] = string(value)
+	}
+
+	return nil
+}
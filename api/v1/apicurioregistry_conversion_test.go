@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConversionRoundTripFromV1(t *testing.T) {
+	original := &ApicurioRegistry{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "registry",
+			Namespace: "test",
+		},
+		Spec: ApicurioRegistrySpec{
+			Configuration: ApicurioRegistrySpecConfiguration{
+				Persistence: "sql",
+				Sql: ApicurioRegistrySpecConfigurationSql{
+					DataSource: ApicurioRegistrySpecConfigurationDataSource{
+						Url:      "jdbc:postgresql://postgresql.test.svc:5432/registry",
+						UserName: "postgres",
+						Password: "password",
+					},
+				},
+			},
+			Deployment: ApicurioRegistrySpecDeployment{
+				Host: "registry.example.com",
+				PodTemplateSpecPreview: ApicurioRegistryPodTemplateSpec{
+					Spec: ApicurioRegistryPodSpec{
+						Containers: []core.Container{{Name: "registry"}},
+					},
+				},
+			},
+		},
+	}
+
+	hub := &v1beta2.ApicurioRegistry{}
+	if err := original.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	c.AssertEquals(t, v1beta2.PersistenceSql, hub.Spec.Configuration.Persistence)
+	c.AssertEquals(t, "password", hub.Spec.Configuration.Sql.DataSource.Password.Value)
+	c.AssertEquals(t, "registry.example.com", hub.Spec.Deployment.Hosts.Primary)
+	c.AssertEquals(t, "registry", hub.Spec.Deployment.PodTemplateSpec.Spec.Containers[0].Name)
+
+	converted := &ApicurioRegistry{}
+	if err := converted.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	c.AssertEquals(t, original, converted)
+}
+
+func TestConversionRoundTripFromV1beta2(t *testing.T) {
+	original := &v1beta2.ApicurioRegistry{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "registry",
+			Namespace: "test",
+		},
+		Spec: v1beta2.ApicurioRegistrySpec{
+			Configuration: v1beta2.ApicurioRegistrySpecConfiguration{
+				Persistence: v1beta2.PersistenceSql,
+				Sql: v1beta2.ApicurioRegistrySpecConfigurationSql{
+					DataSource: v1beta2.ApicurioRegistrySpecConfigurationDataSource{
+						Password: v1beta2.ApicurioRegistrySecretValue{
+							SecretKeyRef: &core.SecretKeySelector{
+								LocalObjectReference: core.LocalObjectReference{Name: "db-secret"},
+								Key:                  "password",
+							},
+						},
+					},
+				},
+			},
+			Deployment: v1beta2.ApicurioRegistrySpecDeployment{
+				Hosts: v1beta2.ApicurioRegistrySpecDeploymentHosts{
+					Primary:    "registry.example.com",
+					Additional: []string{"registry.internal"},
+				},
+			},
+		},
+	}
+
+	spoke := &ApicurioRegistry{}
+	if err := spoke.ConvertFrom(original); err != nil {
+		t.Fatal(err)
+	}
+	c.AssertEquals(t, "registry.example.com", spoke.Spec.Deployment.Host)
+	if _, exists := spoke.Annotations[AnnotationV1beta2Spec]; !exists {
+		t.Errorf("Expected the %s annotation to be present.", AnnotationV1beta2Spec)
+	}
+
+	// A change made in v1 takes precedence
+	spoke.Spec.Deployment.Host = "registry2.example.com"
+
+	converted := &v1beta2.ApicurioRegistry{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	original.Spec.Deployment.Hosts.Primary = "registry2.example.com"
+	c.AssertEquals(t, original, converted)
+}
@@ -0,0 +1,1224 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ### Spec
+
+// ApicurioRegistrySpec defines the desired state of ApicurioRegistry
+type ApicurioRegistrySpec struct {
+	// Apicurio Registry application configuration
+	Configuration ApicurioRegistrySpecConfiguration `json:"configuration,omitempty"`
+	// Apicurio Registry deployment configuration
+	Deployment ApicurioRegistrySpecDeployment `json:"deployment,omitempty"`
+}
+
+// ApicurioRegistryPersistence is the type of storage used by Apicurio Registry
+// +kubebuilder:validation:Enum=mem;sql;kafkasql
+type ApicurioRegistryPersistence string
+
+const (
+	PersistenceMem      ApicurioRegistryPersistence = "mem"
+	PersistenceSql      ApicurioRegistryPersistence = "sql"
+	PersistenceKafkasql ApicurioRegistryPersistence = "kafkasql"
+)
+
+type ApicurioRegistrySpecConfiguration struct {
+	// Storage:
+	//
+	// Type of storage used by Apicurio Registry, one of: mem, sql, kafkasql.
+	// Default value is `mem`.
+	Persistence ApicurioRegistryPersistence `json:"persistence,omitempty"`
+	// Configuration of Apicurio Registry SQL storage
+	Sql ApicurioRegistrySpecConfigurationSql `json:"sql,omitempty"`
+	// Configuration of Apicurio Registry KafkaSQL storage
+	Kafkasql ApicurioRegistrySpecConfigurationKafkasql `json:"kafkasql,omitempty"`
+	// Configuration of Apicurio Registry web console
+	UI ApicurioRegistrySpecConfigurationUI `json:"ui,omitempty"`
+	// Third-party (non-Apicurio) library log level
+	LogLevel string `json:"logLevel,omitempty"`
+	// Apicurio Registry application log level
+	RegistryLogLevel string `json:"registryLogLevel,omitempty"`
+	// Security configuration
+	Security ApicurioRegistrySpecConfigurationSecurity `json:"security,omitempty"`
+	// Apicurio Registry application configuration:
+	//
+	// Typed configuration of the Apicurio Registry application, which the Operator maps to the corresponding environment variables.
+	// A variable with the same name in `env` takes precedence over the value configured here.
+	Registry ApicurioRegistrySpecConfigurationRegistry `json:"registry,omitempty"`
+	// Environment variables:
+	//
+	// List of additional environment variables that will be
+	// provided to the Apicurio Registry application.
+	Env []core.EnvVar `json:"env,omitempty"`
+	// Environment variables from ConfigMaps and Secrets:
+	//
+	// List of sources of additional environment variables that will be
+	// provided to the Apicurio Registry application.
+	// Variables in `env`, and the variables set by the Operator, take precedence over the variables from these sources.
+	// The Apicurio Registry pods are restarted when the data of the referenced ConfigMaps or Secrets changes.
+	EnvFrom []core.EnvFromSource `json:"envFrom,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationDataSource struct {
+	// Data source URL:
+	//
+	// URL of the PostgreSQL database, for example:
+	// `jdbc:postgresql://<service name>.<namespace>.svc:5432/<database name>`.
+	Url string `json:"url,omitempty"`
+	// Data source username
+	UserName string `json:"userName,omitempty"`
+	// Data source password:
+	//
+	// Password of the data source user, preferably provided as a reference to a Secret key.
+	Password ApicurioRegistrySecretValue `json:"password,omitempty"`
+}
+
+type ApicurioRegistrySecretValue struct {
+	// Secret key reference:
+	//
+	// Reference to a key of a Secret in the same namespace, which contains the value.
+	SecretKeyRef *core.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// Value:
+	//
+	// Plain-text value, used only if `secretKeyRef` is not set.
+	// WARNING: The value is visible to everyone who can read the ApicurioRegistry resource.
+	Value string `json:"value,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationSql struct {
+	// SQL data source
+	DataSource ApicurioRegistrySpecConfigurationDataSource `json:"dataSource,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationKafkasql struct {
+	// Kafka bootstrap servers URL:
+	//
+	// URL of one of the Kafka brokers, which provide initial metadata about the Kafka cluster,
+	// for example: `<service name>.<namespace>.svc:9092`.
+	BootstrapServers string `json:"bootstrapServers,omitempty"`
+	// Kafka security configuration:
+	//
+	// Provide the following configuration options if your Kafka cluster
+	// is secured using TLS, SCRAM, OAuth or PLAIN. Only one of them can be used at the same time.
+	Security ApicurioRegistrySpecConfigurationKafkaSecurity `json:"security,omitempty"`
+	// Strimzi:
+	//
+	// Use a Kafka cluster managed by Strimzi in the same namespace.
+	// The Operator creates a KafkaUser and the journal KafkaTopic for this Apicurio Registry,
+	// and configures the bootstrap servers and security options automatically.
+	// Requires the Strimzi CRDs to be installed in the cluster.
+	// The KafkaUser is created without ACLs. If the Kafka cluster uses authorization,
+	// grant access to the journal topic and the consumer group of Apicurio Registry separately.
+	Strimzi ApicurioRegistrySpecConfigurationKafkasqlStrimzi `json:"strimzi,omitempty"`
+	// Topic:
+	//
+	// Configuration of the Kafka topics used by Apicurio Registry.
+	Topic ApicurioRegistrySpecConfigurationKafkasqlTopic `json:"topic,omitempty"`
+	// Kafka client properties:
+	//
+	// Additional Kafka client properties, in the `<scope>.<property>` format,
+	// where scope is one of `common`, `producer` or `consumer`,
+	// for example `producer.linger.ms` or `consumer.group.id`.
+	// Only selected properties are supported, security properties are configured by the Operator.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationKafkasqlTopic struct {
+	// Journal topic name:
+	//
+	// Name of the topic where Apicurio Registry stores its data, default value is `kafkasql-journal`.
+	// When Strimzi is used, the default value is `<ApicurioRegistry name>-kafkasql-journal`.
+	Name string `json:"name,omitempty"`
+	// Snapshots topic name:
+	//
+	// Name of the topic where Apicurio Registry stores snapshots of its data.
+	SnapshotsName string `json:"snapshotsName,omitempty"`
+	// Automatically create topics:
+	//
+	// Apicurio Registry creates the topics if they do not exist, default value is `true`.
+	AutoCreate *bool `json:"autoCreate,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationKafkasqlStrimzi struct {
+	// Kafka cluster name:
+	//
+	// Name of the Strimzi Kafka resource in the same namespace.
+	ClusterName string `json:"clusterName,omitempty"`
+	// Listener name:
+	//
+	// Name of the Kafka listener that Apicurio Registry connects to.
+	// The listener must have TLS enabled, default value is `tls`.
+	ListenerName string `json:"listenerName,omitempty"`
+	// Authentication:
+	//
+	// Authentication type of the KafkaUser created by the Operator,
+	// default value is `scram-sha-512`.
+	Authentication ApicurioRegistryStrimziAuthentication `json:"authentication,omitempty"`
+}
+
+// ApicurioRegistryStrimziAuthentication is the authentication type of a Strimzi KafkaUser
+// +kubebuilder:validation:Enum=scram-sha-512;tls
+type ApicurioRegistryStrimziAuthentication string
+
+const (
+	StrimziAuthenticationScramSha512 ApicurioRegistryStrimziAuthentication = "scram-sha-512"
+	StrimziAuthenticationTls         ApicurioRegistryStrimziAuthentication = "tls"
+)
+
+type ApicurioRegistrySpecConfigurationKafkaSecurity struct {
+	// TLS:
+	//
+	// Kafka is secured using TLS.
+	Tls ApicurioRegistrySpecConfigurationKafkaSecurityTls `json:"tls,omitempty"`
+	// SCRAM:
+	//
+	// Kafka is secured using SCRAM.
+	Scram ApicurioRegistrySpecConfigurationKafkaSecurityScram `json:"scram,omitempty"`
+	// OAuth:
+	//
+	// Kafka is secured using SASL/OAUTHBEARER.
+	OAuth ApicurioRegistrySpecConfigurationKafkaSecurityOAuth `json:"oauth,omitempty"`
+	// PLAIN:
+	//
+	// Kafka is secured using SASL/PLAIN.
+	Plain ApicurioRegistrySpecConfigurationKafkaSecurityPlain `json:"plain,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationKafkaSecurityTls struct {
+	// Truststore Secret name:
+	//
+	// Name of a Secret that contains TLS truststore (in PKCS12 format)
+	// under the `ca.p12` key, and truststore password under the `ca.password` key.
+	// If the format is PEM, the Secret must contain the CA certificate under the `ca.crt` key.
+	TruststoreSecretName string `json:"truststoreSecretName,omitempty"`
+	// Keystore Secret name:
+	//
+	// Name of a Secret that contains TLS keystore (in PKCS12 format)
+	// under the `user.p12` key, and keystore password under the `user.password` key.
+	// If the format is PEM, the Secret must contain the certificate under the `tls.crt` key,
+	// and the private key under the `tls.key` key, e.g. a cert-manager Certificate Secret.
+	KeystoreSecretName string `json:"keystoreSecretName,omitempty"`
+	// Format:
+	//
+	// Format of the keystore and truststore Secrets, default value is PKCS12.
+	// PEM Secrets are converted by the Operator into an Operator-owned Secret,
+	// which is updated when the source Secrets change.
+	Format ApicurioRegistryKafkaTlsFormat `json:"format,omitempty"`
+}
+
+// ApicurioRegistryKafkaTlsFormat is the format of the Kafka TLS keystore and truststore Secrets
+// +kubebuilder:validation:Enum=PKCS12;PEM
+type ApicurioRegistryKafkaTlsFormat string
+
+const (
+	KafkaTlsFormatPkcs12 ApicurioRegistryKafkaTlsFormat = "PKCS12"
+	KafkaTlsFormatPem    ApicurioRegistryKafkaTlsFormat = "PEM"
+)
+
+type ApicurioRegistrySpecConfigurationKafkaSecurityScram struct {
+	// Truststore Secret name:
+	//
+	// Name of a Secret that contains TLS truststore (in PKCS12 format)
+	// under the `ca.p12` key, and truststore password under the `ca.password` key.
+	TruststoreSecretName string `json:"truststoreSecretName,omitempty"`
+	// User name
+	User string `json:"user,omitempty"`
+	// User password Secret name:
+	//
+	// Name of a Secret that contains password of the SCRAM user
+	// under the `password` key.
+	PasswordSecretName string `json:"passwordSecretName,omitempty"`
+	// Mechanism:
+	//
+	// Name of the SCRAM mechanism, default value is SCRAM-SHA-512.
+	Mechanism ApicurioRegistryScramMechanism `json:"mechanism,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationKafkaSecurityOAuth struct {
+	// Token endpoint URL:
+	//
+	// URL of the OAuth 2.0 token endpoint of the authorization server.
+	TokenEndpointUrl string `json:"tokenEndpointUrl,omitempty"`
+	// Client ID
+	ClientId string `json:"clientId,omitempty"`
+	// Client secret Secret name:
+	//
+	// Name of a Secret that contains the OAuth 2.0 client secret
+	// under the `client-secret` key.
+	ClientSecretSecretName string `json:"clientSecretSecretName,omitempty"`
+	// Scope:
+	//
+	// OAuth 2.0 scope requested from the authorization server, optional.
+	Scope string `json:"scope,omitempty"`
+	// Truststore Secret name:
+	//
+	// Name of a Secret that contains TLS truststore (in PKCS12 format)
+	// under the `ca.p12` key, and truststore password under the `ca.password` key.
+	// Optional, the default JVM truststore is used if not set.
+	TruststoreSecretName string `json:"truststoreSecretName,omitempty"`
+	// Security protocol:
+	//
+	// Kafka security protocol, default value is SASL_SSL.
+	SecurityProtocol ApicurioRegistryKafkaSecurityProtocol `json:"securityProtocol,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationKafkaSecurityPlain struct {
+	// User name
+	User string `json:"user,omitempty"`
+	// User password Secret name:
+	//
+	// Name of a Secret that contains password of the user
+	// under the `password` key.
+	PasswordSecretName string `json:"passwordSecretName,omitempty"`
+	// Truststore Secret name:
+	//
+	// Name of a Secret that contains TLS truststore (in PKCS12 format)
+	// under the `ca.p12` key, and truststore password under the `ca.password` key.
+	// Optional, the default JVM truststore is used if not set.
+	TruststoreSecretName string `json:"truststoreSecretName,omitempty"`
+	// Security protocol:
+	//
+	// Kafka security protocol, default value is SASL_SSL.
+	SecurityProtocol ApicurioRegistryKafkaSecurityProtocol `json:"securityProtocol,omitempty"`
+}
+
+// ApicurioRegistryKafkaSecurityProtocol is the name of a Kafka SASL security protocol
+// +kubebuilder:validation:Enum=SASL_SSL;SASL_PLAINTEXT
+type ApicurioRegistryKafkaSecurityProtocol string
+
+const (
+	KafkaSecurityProtocolSaslSsl       ApicurioRegistryKafkaSecurityProtocol = "SASL_SSL"
+	KafkaSecurityProtocolSaslPlaintext ApicurioRegistryKafkaSecurityProtocol = "SASL_PLAINTEXT"
+)
+
+// ApicurioRegistryScramMechanism is the name of a Kafka SCRAM mechanism
+// +kubebuilder:validation:Enum=SCRAM-SHA-256;SCRAM-SHA-512
+type ApicurioRegistryScramMechanism string
+
+const (
+	ScramMechanismSha256 ApicurioRegistryScramMechanism = "SCRAM-SHA-256"
+	ScramMechanismSha512 ApicurioRegistryScramMechanism = "SCRAM-SHA-512"
+)
+
+type ApicurioRegistrySpecConfigurationUI struct {
+	// Read-only:
+	//
+	// Set the web console to read-only mode.
+	// WARNING: This does not affect access to the Apicurio REST API.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationRegistry struct {
+	// Global artifact rules
+	Rules ApicurioRegistrySpecConfigurationRegistryRules `json:"rules,omitempty"`
+	// Resource limits
+	Limits ApicurioRegistrySpecConfigurationRegistryLimits `json:"limits,omitempty"`
+	// Authorization:
+	//
+	// Authorization configuration, requires authentication to be enabled, for example using `security.keycloak`.
+	Authorization ApicurioRegistrySpecConfigurationRegistryAuthorization `json:"authorization,omitempty"`
+	// Web console configuration
+	UI ApicurioRegistrySpecConfigurationRegistryUI `json:"ui,omitempty"`
+}
+
+// ApicurioRegistryValidityRule is the configuration of the global validity rule
+// +kubebuilder:validation:Enum=NONE;SYNTAX_ONLY;FULL
+type ApicurioRegistryValidityRule string
+
+const (
+	ValidityRuleNone       ApicurioRegistryValidityRule = "NONE"
+	ValidityRuleSyntaxOnly ApicurioRegistryValidityRule = "SYNTAX_ONLY"
+	ValidityRuleFull       ApicurioRegistryValidityRule = "FULL"
+)
+
+// ApicurioRegistryCompatibilityRule is the configuration of the global compatibility rule
+// +kubebuilder:validation:Enum=NONE;BACKWARD;BACKWARD_TRANSITIVE;FORWARD;FORWARD_TRANSITIVE;FULL;FULL_TRANSITIVE
+type ApicurioRegistryCompatibilityRule string
+
+const (
+	CompatibilityRuleNone               ApicurioRegistryCompatibilityRule = "NONE"
+	CompatibilityRuleBackward           ApicurioRegistryCompatibilityRule = "BACKWARD"
+	CompatibilityRuleBackwardTransitive ApicurioRegistryCompatibilityRule = "BACKWARD_TRANSITIVE"
+	CompatibilityRuleForward            ApicurioRegistryCompatibilityRule = "FORWARD"
+	CompatibilityRuleForwardTransitive  ApicurioRegistryCompatibilityRule = "FORWARD_TRANSITIVE"
+	CompatibilityRuleFull               ApicurioRegistryCompatibilityRule = "FULL"
+	CompatibilityRuleFullTransitive     ApicurioRegistryCompatibilityRule = "FULL_TRANSITIVE"
+)
+
+// ApicurioRegistryIntegrityRule is the configuration of the global integrity rule
+// +kubebuilder:validation:Enum=NONE;REFS_EXIST;ALL_REFS_MAPPED;NO_DUPLICATES;FULL
+type ApicurioRegistryIntegrityRule string
+
+const (
+	IntegrityRuleNone          ApicurioRegistryIntegrityRule = "NONE"
+	IntegrityRuleRefsExist     ApicurioRegistryIntegrityRule = "REFS_EXIST"
+	IntegrityRuleAllRefsMapped ApicurioRegistryIntegrityRule = "ALL_REFS_MAPPED"
+	IntegrityRuleNoDuplicates  ApicurioRegistryIntegrityRule = "NO_DUPLICATES"
+	IntegrityRuleFull          ApicurioRegistryIntegrityRule = "FULL"
+)
+
+type ApicurioRegistrySpecConfigurationRegistryRules struct {
+	// Validity rule:
+	//
+	// Global validity rule applied to artifacts that do not have their own, one of: NONE, SYNTAX_ONLY, FULL.
+	Validity ApicurioRegistryValidityRule `json:"validity,omitempty"`
+	// Compatibility rule:
+	//
+	// Global compatibility rule applied to artifacts that do not have their own, one of:
+	// NONE, BACKWARD, BACKWARD_TRANSITIVE, FORWARD, FORWARD_TRANSITIVE, FULL, FULL_TRANSITIVE.
+	Compatibility ApicurioRegistryCompatibilityRule `json:"compatibility,omitempty"`
+	// Integrity rule:
+	//
+	// Global integrity rule applied to artifacts that do not have their own, one of:
+	// NONE, REFS_EXIST, ALL_REFS_MAPPED, NO_DUPLICATES, FULL.
+	Integrity ApicurioRegistryIntegrityRule `json:"integrity,omitempty"`
+}
+
+// Limits are not applied if the value is not set (or is zero).
+type ApicurioRegistrySpecConfigurationRegistryLimits struct {
+	// Maximum number of artifact versions (schemas) in total
+	// +kubebuilder:validation:Minimum=0
+	MaxTotalSchemas int64 `json:"maxTotalSchemas,omitempty"`
+	// Maximum size of an artifact version (schema) in bytes
+	// +kubebuilder:validation:Minimum=0
+	MaxSchemaSizeBytes int64 `json:"maxSchemaSizeBytes,omitempty"`
+	// Maximum number of artifacts
+	// +kubebuilder:validation:Minimum=0
+	MaxArtifacts int64 `json:"maxArtifacts,omitempty"`
+	// Maximum number of versions of a single artifact
+	// +kubebuilder:validation:Minimum=0
+	MaxVersionsPerArtifact int64 `json:"maxVersionsPerArtifact,omitempty"`
+	// Maximum number of properties of an artifact
+	// +kubebuilder:validation:Minimum=0
+	MaxArtifactProperties int64 `json:"maxArtifactProperties,omitempty"`
+	// Maximum number of labels of an artifact
+	// +kubebuilder:validation:Minimum=0
+	MaxArtifactLabels int64 `json:"maxArtifactLabels,omitempty"`
+	// Maximum number of requests per second, per client
+	// +kubebuilder:validation:Minimum=0
+	MaxRequestsPerSecond int64 `json:"maxRequestsPerSecond,omitempty"`
+}
+
+// ApicurioRegistryRoleSource is the source of the user roles for role-based authorization
+// +kubebuilder:validation:Enum=token;application
+type ApicurioRegistryRoleSource string
+
+const (
+	RoleSourceToken       ApicurioRegistryRoleSource = "token"
+	RoleSourceApplication ApicurioRegistryRoleSource = "application"
+)
+
+type ApicurioRegistrySpecConfigurationRegistryAuthorization struct {
+	// Anonymous read access:
+	//
+	// Allow anonymous users to read artifacts.
+	AnonymousReadAccess bool `json:"anonymousReadAccess,omitempty"`
+	// Authenticated read access:
+	//
+	// Allow any authenticated user to read artifacts, regardless of their role.
+	AuthenticatedReadAccess bool `json:"authenticatedReadAccess,omitempty"`
+	// Owner-only authorization:
+	//
+	// Allow only the owner of an artifact to modify it.
+	OwnerOnly bool `json:"ownerOnly,omitempty"`
+	// Role-based authorization:
+	//
+	// Enable role-based authorization.
+	RoleBased bool `json:"roleBased,omitempty"`
+	// Role source:
+	//
+	// Source of the user roles for role-based authorization, one of: token, application.
+	// Default value is `token`.
+	RoleSource ApicurioRegistryRoleSource `json:"roleSource,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationRegistryUI struct {
+	// Web console features
+	Features ApicurioRegistrySpecConfigurationRegistryUIFeatures `json:"features,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationRegistryUIFeatures struct {
+	// Settings:
+	//
+	// Show the settings page in the web console.
+	// To set the web console to read-only mode, use `ui.readOnly`.
+	Settings bool `json:"settings,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationSecurity struct {
+	// Keycloak:
+	//
+	// Configure Apicurio Registry to use Keycloak for Identity and Access Management (IAM).
+	Keycloak ApicurioRegistrySpecConfigurationSecurityKeycloak `json:"keycloak,omitempty"`
+	// HTTPS:
+	//
+	// Configure Apicurio Registry to be accessible using HTTPS.
+	Https ApicurioRegistrySpecConfigurationSecurityHttps `json:"https,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationSecurityHttps struct {
+	// Disable HTTP:
+	//
+	// Disable HTTP if HTTPS is enabled.
+	DisableHttp bool `json:"disableHttp,omitempty"`
+	// HTTPS certificate and private key Secret name:
+	//
+	// Name of a Secret that contains HTTPS certificate under the `tls.crt` key,
+	// and the private key under the `tls.key` key.
+	// Used if the certificate mode is `provided`.
+	SecretName string `json:"secretName,omitempty"`
+	// HTTPS certificate:
+	//
+	// Configure how the HTTPS certificate is provided.
+	Certificate ApicurioRegistrySpecConfigurationSecurityHttpsCertificate `json:"certificate,omitempty"`
+	// Client authentication:
+	//
+	// Whether HTTPS clients must present a certificate, `none` (default), `request` or `required`.
+	// The Operator presents its own client certificate when checking the Apicurio Registry health.
+	ClientAuth ApicurioRegistryHttpsClientAuth `json:"clientAuth,omitempty"`
+	// Client truststore Secret name:
+	//
+	// Name of a Secret that contains the CA certificates used to verify client certificates under the `ca.crt` key.
+	// Required if client authentication is enabled.
+	TruststoreSecretName string `json:"truststoreSecretName,omitempty"`
+}
+
+// ApicurioRegistryHttpsClientAuth is the HTTPS client authentication mode
+// +kubebuilder:validation:Enum=none;request;required
+type ApicurioRegistryHttpsClientAuth string
+
+const (
+	HttpsClientAuthNone     ApicurioRegistryHttpsClientAuth = "none"
+	HttpsClientAuthRequest  ApicurioRegistryHttpsClientAuth = "request"
+	HttpsClientAuthRequired ApicurioRegistryHttpsClientAuth = "required"
+)
+
+type ApicurioRegistrySpecConfigurationSecurityHttpsCertificate struct {
+	// Certificate mode:
+	//
+	// `provided` (default) - the certificate is read from the Secret referenced by `secretName`,
+	// `selfSigned` - the Operator generates a CA and a serving certificate, and renews them before they expire,
+	// `certManager` - the Operator creates a cert-manager Certificate resource using the configured issuer,
+	// `openshift` - the OpenShift service CA issues a service serving certificate,
+	// and the Route uses reencrypt termination.
+	Mode ApicurioRegistryHttpsCertificateMode `json:"mode,omitempty"`
+	// cert-manager issuer:
+	//
+	// Issuer used to sign the certificate, required if the certificate mode is `certManager`.
+	Issuer ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer `json:"issuer,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer struct {
+	// Name of the cert-manager issuer
+	Name string `json:"name,omitempty"`
+	// Kind of the cert-manager issuer, `Issuer` (default) or `ClusterIssuer`.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind string `json:"kind,omitempty"`
+}
+
+// ApicurioRegistryHttpsCertificateMode is the way the HTTPS certificate is provided
+// +kubebuilder:validation:Enum=provided;selfSigned;certManager;openshift
+type ApicurioRegistryHttpsCertificateMode string
+
+const (
+	HttpsCertificateModeProvided    ApicurioRegistryHttpsCertificateMode = "provided"
+	HttpsCertificateModeSelfSigned  ApicurioRegistryHttpsCertificateMode = "selfSigned"
+	HttpsCertificateModeCertManager ApicurioRegistryHttpsCertificateMode = "certManager"
+	HttpsCertificateModeOpenShift   ApicurioRegistryHttpsCertificateMode = "openshift"
+)
+
+type ApicurioRegistrySpecConfigurationSecurityKeycloak struct {
+	// Keycloak auth URL:
+	//
+	// URL of the Keycloak auth endpoint, must end with `/auth`.
+	Url string `json:"url,omitempty"`
+	// Keycloak realm
+	Realm string `json:"realm,omitempty"`
+	// Client ID for the REST API
+	ApiClientId string `json:"apiClientId,omitempty"`
+	// Client ID for the UI
+	UiClientId string `json:"uiClientId,omitempty"`
+	// Operator client:
+	//
+	// Confidential Keycloak client with the admin role, used by the Operator to manage the Apicurio Registry content,
+	// for example using the ApicurioRegistryGlobalRule resources.
+	OperatorClient ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient `json:"operatorClient,omitempty"`
+}
+
+type ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient struct {
+	// Client ID
+	ClientId string `json:"clientId,omitempty"`
+	// Client secret:
+	//
+	// Secret of the client, preferably provided as a reference to a Secret key.
+	ClientSecret ApicurioRegistrySecretValue `json:"clientSecret,omitempty"`
+	// Keycloak truststore Secret name:
+	//
+	// Name of a Secret that contains the CA certificates used to verify the Keycloak server certificate
+	// under the `ca.crt` key, in addition to the system CA certificates.
+	TruststoreSecretName string `json:"truststoreSecretName,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentMetadata struct {
+	// Annotations:
+	//
+	// Additional Apicurio Registry Pod annotations.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Labels:
+	//
+	// Additional Apicurio Registry Pod labels.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentManagedResourcesMetadata struct {
+	// Annotations:
+	//
+	// Additional annotations of the managed resources.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Labels:
+	//
+	// Additional labels of the managed resources.
+	// Labels set by the Operator, such as the selector labels, cannot be overridden.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type ApicurioRegistrySpecDeployment struct {
+	// Replicas:
+	//
+	// The required number of Apicurio Registry pods. Default value is 1.
+	Replicas int32 `json:"replicas,omitempty"`
+	// Hostnames:
+	//
+	// Apicurio Registry application hostnames (parts of the URL without the protocol and path).
+	Hosts ApicurioRegistrySpecDeploymentHosts `json:"hosts,omitempty"`
+	// Affinity
+	Affinity *core.Affinity `json:"affinity,omitempty"`
+	// Tolerations
+	Tolerations []core.Toleration `json:"tolerations,omitempty"`
+	// Deployment strategy:
+	//
+	// Strategy used to replace the Apicurio Registry pods, `RollingUpdate` or `Recreate`.
+	// Default value is `Recreate` for the in-memory persistence, because the pods do not share the data,
+	// and `RollingUpdate` otherwise.
+	Strategy *apps.DeploymentStrategy `json:"strategy,omitempty"`
+	// Upgrade:
+	//
+	// Configure how the Operator handles Apicurio Registry image upgrades.
+	Upgrade ApicurioRegistrySpecDeploymentUpgrade `json:"upgrade,omitempty"`
+	// High availability:
+	//
+	// Spread the Apicurio Registry pods across nodes or zones, if there is more than one replica.
+	HighAvailability ApicurioRegistrySpecDeploymentHighAvailability `json:"highAvailability,omitempty"`
+	// Metadata of the Apicurio Registry pod
+	Metadata ApicurioRegistrySpecDeploymentMetadata `json:"metadata,omitempty"`
+	// Metadata of the managed resources:
+	//
+	// Labels and annotations added to all resources managed by the Operator,
+	// including the Deployment, Service, Ingress, Routes, NetworkPolicy, PodDisruptionBudget and ServiceMonitor.
+	ManagedResourcesMetadata ApicurioRegistrySpecDeploymentManagedResourcesMetadata `json:"managedResourcesMetadata,omitempty"`
+	// Apicurio Registry image:
+	//
+	// Replaces the default Apicurio Registry application image.
+	// Overrides the values in the REGISTRY_IMAGE_MEM, REGISTRY_IMAGE_KAFKASQL and REGISTRY_IMAGE_SQL Operator environment variables.
+	Image string `json:"image,omitempty"`
+	// Apicurio Registry version:
+	//
+	// Pins the Apicurio Registry version, by replacing the tag of the default Apicurio Registry application image.
+	// Not used if `image` is set.
+	Version string `json:"version,omitempty"`
+	// Apicurio Registry image pull secrets:
+	//
+	// List of Secrets to use when pulling the Apicurio Registry image.
+	ImagePullSecrets []core.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Apicurio Registry managed resources:
+	//
+	// Configure how the Operator manages Kubernetes resources.
+	ManagedResources ApicurioRegistrySpecDeploymentManagedResources `json:"managedResources,omitempty"`
+	// OpenShift Route:
+	//
+	// Configure the Routes, which the Operator creates instead of the Ingress on OpenShift,
+	// one for each hostname.
+	Route ApicurioRegistrySpecDeploymentRoute `json:"route,omitempty"`
+	// NetworkPolicy:
+	//
+	// Configure the NetworkPolicy managed by the Operator.
+	NetworkPolicy ApicurioRegistrySpecDeploymentNetworkPolicy `json:"networkPolicy,omitempty"`
+	// PodDisruptionBudget:
+	//
+	// Configure the PodDisruptionBudget managed by the Operator.
+	// The PodDisruptionBudget is not created if there is only one replica, so it does not block node drains.
+	PodDisruptionBudget ApicurioRegistrySpecDeploymentPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	// Service:
+	//
+	// Configure the Service managed by the Operator.
+	Service ApicurioRegistrySpecDeploymentService `json:"service,omitempty"`
+	// Configure Apicurio Registry pod template:
+	//
+	// With some restrictions, the Apicurio Registry Operator forwards the data from this field
+	// to the corresponding "spec.template" field in the Apicurio Registry Deployment.
+	// This feature provides greater configuration flexibility, without the need for the Operator to natively support each use case.
+	PodTemplateSpec ApicurioRegistryPodTemplateSpec `json:"podTemplateSpec,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentHosts struct {
+	// Primary hostname:
+	//
+	// Hostname used by the Ingress (or Route), and reported in the status.
+	// If empty, the Operator generates a default value.
+	Primary string `json:"primary,omitempty"`
+	// Additional hostnames:
+	//
+	// Additional hostnames, under which the Apicurio Registry application is accessible.
+	Additional []string `json:"additional,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentUpgrade struct {
+	// Upgrade policy:
+	//
+	// How the Operator upgrades Apicurio Registry when the default image changes, for example after the Operator is upgraded,
+	// `automatic` (default), `manual` or `patch-only`.
+	// With the `manual` policy, the Operator reports the new image in the `UpgradeAvailable` condition,
+	// and waits until the `apicur.io/approved-upgrade-image` annotation of the ApicurioRegistry resource is set to the new image.
+	// The `patch-only` policy upgrades automatically only if the major and minor versions do not change.
+	// Not used if `spec.deployment.image` or `spec.deployment.version` is set.
+	Policy ApicurioRegistryUpgradePolicy `json:"policy,omitempty"`
+	// Automatic rollback:
+	//
+	// If the rollout of a new Apicurio Registry image fails, or the pods do not become ready within the readiness deadline,
+	// the Operator reverts the Deployment to the last known good image.
+	// The image is not applied again until it is changed.
+	AutoRollback bool `json:"autoRollback,omitempty"`
+	// Readiness deadline:
+	//
+	// Number of seconds the pods with a new image have to become ready, before the Operator reverts the Deployment.
+	// Used only if `autoRollback` is enabled. Default value is 600.
+	ReadinessDeadlineSeconds int32 `json:"readinessDeadlineSeconds,omitempty"`
+}
+
+// ApicurioRegistryUpgradePolicy is the policy for Apicurio Registry upgrades
+// +kubebuilder:validation:Enum=automatic;manual;patch-only
+type ApicurioRegistryUpgradePolicy string
+
+const (
+	UpgradePolicyAutomatic ApicurioRegistryUpgradePolicy = "automatic"
+	UpgradePolicyManual    ApicurioRegistryUpgradePolicy = "manual"
+	UpgradePolicyPatchOnly ApicurioRegistryUpgradePolicy = "patch-only"
+)
+
+type ApicurioRegistrySpecDeploymentHighAvailability struct {
+	// High availability preset:
+	//
+	// `node` spreads the pods across nodes, `zone` spreads the pods across zones, and then across nodes.
+	// The Operator generates the topology spread constraints and soft pod anti-affinity,
+	// which are merged with the values in `spec.deployment.affinity` and `spec.deployment.podTemplateSpec`.
+	// Topology keys that are already used in these fields are skipped.
+	// If empty, the pods are not spread.
+	Preset ApicurioRegistryHighAvailabilityPreset `json:"preset,omitempty"`
+}
+
+// ApicurioRegistryHighAvailabilityPreset is the high availability preset
+// +kubebuilder:validation:Enum=node;zone
+type ApicurioRegistryHighAvailabilityPreset string
+
+const (
+	HighAvailabilityPresetNode ApicurioRegistryHighAvailabilityPreset = "node"
+	HighAvailabilityPresetZone ApicurioRegistryHighAvailabilityPreset = "zone"
+)
+
+type ApicurioRegistrySpecDeploymentPodDisruptionBudget struct {
+	// Minimum available pods:
+	//
+	// Number or percentage of pods that must remain available during an eviction.
+	// Can not be used together with `maxUnavailable`.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// Maximum unavailable pods:
+	//
+	// Number or percentage of pods that can be unavailable during an eviction.
+	// Default value is 1, if `minAvailable` is not set.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// Unhealthy pod eviction policy:
+	//
+	// When unhealthy pods can be evicted, `IfHealthyBudget` or `AlwaysAllow`.
+	// If empty, the cluster default is used.
+	// +kubebuilder:validation:Enum=IfHealthyBudget;AlwaysAllow
+	UnhealthyPodEvictionPolicy string `json:"unhealthyPodEvictionPolicy,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentService struct {
+	// Type:
+	//
+	// Type of the Service, `ClusterIP` (default), `NodePort` or `LoadBalancer`.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type core.ServiceType `json:"type,omitempty"`
+	// Annotations:
+	//
+	// Additional annotations of the Service, for example to configure a cloud load balancer.
+	// The values take precedence over spec.deployment.managedResourcesMetadata.annotations.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Load balancer source ranges:
+	//
+	// Client IP ranges allowed to access the load balancer, if supported by the cloud provider.
+	// Used only if the type is `LoadBalancer`.
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// External traffic policy:
+	//
+	// How the traffic from external sources is routed, `Cluster` or `Local`.
+	// Used only if the type is `NodePort` or `LoadBalancer`. If empty, the cluster default is used.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy core.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+	// Additional ports:
+	//
+	// Ports exposed by the Service in addition to the `http` and `https` ports managed by the Operator.
+	// The NetworkPolicy managed by the Operator allows the traffic to the target ports.
+	Ports []ApicurioRegistrySpecDeploymentServicePort `json:"ports,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentServicePort struct {
+	// Name:
+	//
+	// Name of the port, must be unique, and must not be `http` or `https`.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Port:
+	//
+	// Port exposed by the Service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Target port:
+	//
+	// Number or name of the port on the Apicurio Registry pods. Default value is the value of `port`.
+	TargetPort *intstr.IntOrString `json:"targetPort,omitempty"`
+	// Protocol:
+	//
+	// `TCP` (default), `UDP` or `SCTP`.
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	Protocol core.Protocol `json:"protocol,omitempty"`
+	// Node port:
+	//
+	// Port on each node, used only if the type is `NodePort` or `LoadBalancer`.
+	// If empty, the port is allocated by Kubernetes.
+	NodePort int32 `json:"nodePort,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentNetworkPolicy struct {
+	// Ingress:
+	//
+	// Configure which peers can connect to Apicurio Registry.
+	Ingress ApicurioRegistrySpecDeploymentNetworkPolicyIngress `json:"ingress,omitempty"`
+	// Egress:
+	//
+	// Configure which destinations Apicurio Registry can connect to.
+	Egress ApicurioRegistrySpecDeploymentNetworkPolicyEgress `json:"egress,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentNetworkPolicyIngress struct {
+	// Allowed peers:
+	//
+	// Peers, such as namespaces or pods, that can connect to the Apicurio Registry ports.
+	// If empty, connections from everywhere are allowed.
+	From []networking.NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentNetworkPolicyEgress struct {
+	// Restrict egress:
+	//
+	// Restrict the egress traffic to DNS, the SQL database, Kafka bootstrap servers and Keycloak,
+	// which are derived from the configuration, and to the additional egress rules.
+	Enabled bool `json:"enabled,omitempty"`
+	// Additional egress rules:
+	//
+	// Allow egress traffic to destinations that are not derived from the configuration.
+	Rules []networking.NetworkPolicyEgressRule `json:"rules,omitempty"`
+}
+
+type ApicurioRegistrySpecDeploymentRoute struct {
+	// TLS termination:
+	//
+	// Type of the Route TLS termination, `edge`, `passthrough` or `reencrypt`.
+	// If empty, the Route is not secured, unless the HTTPS certificate mode is `openshift`,
+	// in which case `reencrypt` is used.
+	Termination ApicurioRegistryRouteTermination `json:"termination,omitempty"`
+	// Insecure edge termination policy:
+	//
+	// What to do with insecure connections to a secured Route, `None`, `Allow` or `Redirect`.
+	// The `Allow` value can not be used with the `passthrough` termination.
+	InsecureEdgeTerminationPolicy ApicurioRegistryRouteInsecureEdgeTerminationPolicy `json:"insecureEdgeTerminationPolicy,omitempty"`
+	// Certificate Secret name:
+	//
+	// Name of a Secret that contains the Route certificate under the `tls.crt` key,
+	// the private key under the `tls.key` key, and optionally the CA certificate under the `ca.crt` key.
+	// If empty, the default router certificate is used. Not used with the `passthrough` termination.
+	CertificateSecretName string `json:"certificateSecretName,omitempty"`
+	// Destination CA certificate Secret name:
+	//
+	// Name of a Secret that contains the CA certificate of the Apicurio Registry HTTPS endpoint under the `ca.crt` key.
+	// Used with the `reencrypt` termination. If empty, the router trusts the OpenShift service CA.
+	DestinationCaCertificateSecretName string `json:"destinationCaCertificateSecretName,omitempty"`
+}
+
+// ApicurioRegistryRouteTermination is the type of the Route TLS termination
+// +kubebuilder:validation:Enum=edge;passthrough;reencrypt
+type ApicurioRegistryRouteTermination string
+
+const (
+	RouteTerminationEdge        ApicurioRegistryRouteTermination = "edge"
+	RouteTerminationPassthrough ApicurioRegistryRouteTermination = "passthrough"
+	RouteTerminationReencrypt   ApicurioRegistryRouteTermination = "reencrypt"
+)
+
+// ApicurioRegistryRouteInsecureEdgeTerminationPolicy is the Route policy for insecure connections
+// +kubebuilder:validation:Enum=None;Allow;Redirect
+type ApicurioRegistryRouteInsecureEdgeTerminationPolicy string
+
+type ApicurioRegistrySpecDeploymentManagedResources struct {
+	// Disable Ingress:
+	//
+	// Operator will not create or manage an Ingress (or Routes on OpenShift) for Apicurio Registry, so it can be done manually.
+	DisableIngress bool `json:"disableIngress,omitempty"`
+	// Disable NetworkPolicy:
+	//
+	// Operator will not create or manage a NetworkPolicy for Apicurio Registry, so it can be done manually.
+	DisableNetworkPolicy bool `json:"disableNetworkPolicy,omitempty"`
+	// Disable PodDisruptionBudget:
+	//
+	// Operator will not create or manage a PodDisruptionBudget for Apicurio Registry, so it can be done manually.
+	DisablePodDisruptionBudget bool `json:"disablePodDisruptionBudget,omitempty"`
+	// Retain on deletion:
+	//
+	// Kinds of the managed resources that are kept when the ApicurioRegistry resource is deleted,
+	// for example `KafkaTopic`, so the data is not lost.
+	// The Operator removes the owner reference from the retained resources, so they are not deleted by the garbage collector.
+	RetainOnDeletion []ApicurioRegistryManagedResourceKind `json:"retainOnDeletion,omitempty"`
+}
+
+// ApicurioRegistryManagedResourceKind is the kind of a resource managed by the Operator
+// +kubebuilder:validation:Enum=Deployment;Service;Ingress;Route;NetworkPolicy;PodDisruptionBudget;KafkaUser;KafkaTopic
+type ApicurioRegistryManagedResourceKind string
+
+const (
+	ManagedResourceKindDeployment          ApicurioRegistryManagedResourceKind = "Deployment"
+	ManagedResourceKindService             ApicurioRegistryManagedResourceKind = "Service"
+	ManagedResourceKindIngress             ApicurioRegistryManagedResourceKind = "Ingress"
+	ManagedResourceKindRoute               ApicurioRegistryManagedResourceKind = "Route"
+	ManagedResourceKindNetworkPolicy       ApicurioRegistryManagedResourceKind = "NetworkPolicy"
+	ManagedResourceKindPodDisruptionBudget ApicurioRegistryManagedResourceKind = "PodDisruptionBudget"
+	ManagedResourceKindKafkaUser           ApicurioRegistryManagedResourceKind = "KafkaUser"
+	ManagedResourceKindKafkaTopic          ApicurioRegistryManagedResourceKind = "KafkaTopic"
+)
+
+// ### Status
+
+type ApicurioRegistryStatus struct {
+	// Phase:
+	//
+	// Lifecycle phase of the ApicurioRegistry resource, one of:
+	// `Active` (the Operator manages the Apicurio Registry deployment),
+	// `Deleting` (the resource has been deleted, and the Operator is removing the managed resources),
+	// `DeletionFailed` (some of the managed resources could not be removed, the Operator retries the cleanup).
+	Phase ApicurioRegistryPhase `json:"phase,omitempty"`
+	// Information about the Apicurio Registry application
+	Info ApicurioRegistryStatusInfo `json:"info,omitempty"`
+	// Conditions:
+	//
+	// Apicurio Registry application and Operator conditions.
+	Conditions []meta.Condition `json:"conditions,omitempty"`
+	// Managed Resources:
+	//
+	// Kubernetes resources managed by the Apicurio Registry Operator.
+	ManagedResources []ApicurioRegistryStatusManagedResource `json:"managedResources,omitempty"`
+	// Upgrade:
+	//
+	// State of the Apicurio Registry image upgrade, used by the automatic rollback.
+	Upgrade ApicurioRegistryStatusUpgrade `json:"upgrade,omitempty"`
+}
+
+// ApicurioRegistryPhase is the lifecycle phase of the ApicurioRegistry resource
+type ApicurioRegistryPhase string
+
+const (
+	PhaseActive         ApicurioRegistryPhase = "Active"
+	PhaseDeleting       ApicurioRegistryPhase = "Deleting"
+	PhaseDeletionFailed ApicurioRegistryPhase = "DeletionFailed"
+)
+
+type ApicurioRegistryStatusInfo struct {
+	// Apicurio Registry URL
+	Host string `json:"host,omitempty"`
+}
+
+type ApicurioRegistryStatusUpgrade struct {
+	// Last Apicurio Registry image that has been rolled out successfully
+	LastKnownGoodImage string `json:"lastKnownGoodImage,omitempty"`
+	// Apicurio Registry image that has been rolled back
+	FailedImage string `json:"failedImage,omitempty"`
+}
+
+type ApicurioRegistryStatusManagedResource struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ### Roots
+
+// ApicurioRegistry represents an Apicurio Registry instance
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+type ApicurioRegistry struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApicurioRegistrySpec   `json:"spec,omitempty"`
+	Status ApicurioRegistryStatus `json:"status,omitempty"`
+}
+
+// ApicurioRegistryList contains a list of ApicurioRegistry
+// +kubebuilder:object:root=true
+type ApicurioRegistryList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ApicurioRegistry `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApicurioRegistry{}, &ApicurioRegistryList{})
+}
+
+// These are slightly modified copies of core.PodTemplateSpec and some nested structs,
+// for the purpose of:
+// - allowing some fields to be empty or nil,
+// - for generating a better CRD,
+// - working deep equality operation
+//
+// The modified struct must be de/serializable from/to the original PodTemplateSpec via JSON.
+//
+// Comments removed to avoid an error "Too long: must have at most 262144 bytes" when executing "kubectl apply".
+// By using kubectl apply to create/update resources, an annotation "kubectl.kubernetes.io/last-applied-configuration"
+// is created by K8s API to store the latest version of the resource.
+// However, it has a size limit and if the CRD has many long descriptions, it will result the error.
+
+// ApicurioRegistryPodTemplateSpec describes the data a pod should have when created from a template
+type ApicurioRegistryPodTemplateSpec struct {
+
+	// +optional
+	Metadata ApicurioRegistryObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// +optional
+	Spec ApicurioRegistryPodSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// ApicurioRegistryObjectMeta is metadata that all persisted resources must have, which includes all objects
+// users must create.
+type ApicurioRegistryObjectMeta struct {
+
+	// +optional
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+
+	// +optional
+	GenerateName string `json:"generateName,omitempty" protobuf:"bytes,2,opt,name=generateName"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,3,opt,name=namespace"`
+
+	// +optional
+	SelfLink string `json:"selfLink,omitempty" protobuf:"bytes,4,opt,name=selfLink"`
+
+	// +optional
+	UID types.UID `json:"uid,omitempty" protobuf:"bytes,5,opt,name=uid,casttype=k8s.io/kubernetes/pkg/types.UID"`
+
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty" protobuf:"bytes,6,opt,name=resourceVersion"`
+
+	// +optional
+	Generation int64 `json:"generation,omitempty" protobuf:"varint,7,opt,name=generation"`
+
+	// +optional
+	CreationTimestamp *meta.Time `json:"creationTimestamp,omitempty" protobuf:"bytes,8,opt,name=creationTimestamp"` // Modified
+
+	// +optional
+	DeletionTimestamp *meta.Time `json:"deletionTimestamp,omitempty" protobuf:"bytes,9,opt,name=deletionTimestamp"`
+
+	// +optional
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty" protobuf:"varint,10,opt,name=deletionGracePeriodSeconds"`
+
+	// +optional
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,11,rep,name=labels"`
+
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,12,rep,name=annotations"`
+
+	// +optional
+	// +patchMergeKey=uid
+	// +patchStrategy=merge
+	OwnerReferences []meta.OwnerReference `json:"ownerReferences,omitempty" patchStrategy:"merge" patchMergeKey:"uid" protobuf:"bytes,13,rep,name=ownerReferences"`
+
+	// +optional
+	// +patchStrategy=merge
+	Finalizers []string `json:"finalizers,omitempty" patchStrategy:"merge" protobuf:"bytes,14,rep,name=finalizers"`
+
+	// +optional
+	ClusterName string `json:"clusterName,omitempty" protobuf:"bytes,15,opt,name=clusterName"`
+
+	// +optional
+	ManagedFields []meta.ManagedFieldsEntry `json:"managedFields,omitempty" protobuf:"bytes,17,rep,name=managedFields"`
+}
+
+// ApicurioRegistryPodSpec is a description of a pod.
+type ApicurioRegistryPodSpec struct {
+
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	Volumes []core.Volume `json:"volumes,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name" protobuf:"bytes,1,rep,name=volumes"`
+
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	InitContainers []core.Container `json:"initContainers,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,20,rep,name=initContainers"`
+
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Containers []core.Container `json:"containers,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,2,rep,name=containers"` // Modified
+
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	EphemeralContainers []core.EphemeralContainer `json:"ephemeralContainers,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,34,rep,name=ephemeralContainers"`
+
+	// +optional
+	RestartPolicy core.RestartPolicy `json:"restartPolicy,omitempty" protobuf:"bytes,3,opt,name=restartPolicy,casttype=RestartPolicy"`
+
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty" protobuf:"varint,4,opt,name=terminationGracePeriodSeconds"`
+
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty" protobuf:"varint,5,opt,name=activeDeadlineSeconds"`
+
+	// +optional
+	DNSPolicy core.DNSPolicy `json:"dnsPolicy,omitempty" protobuf:"bytes,6,opt,name=dnsPolicy,casttype=DNSPolicy"`
+
+	// +optional
+	// +mapType=atomic
+	NodeSelector map[string]string `json:"nodeSelector,omitempty" protobuf:"bytes,7,rep,name=nodeSelector"`
+
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty" protobuf:"bytes,8,opt,name=serviceAccountName"`
+
+	// +k8s:conversion-gen=false
+	// +optional
+	DeprecatedServiceAccount string `json:"serviceAccount,omitempty" protobuf:"bytes,9,opt,name=serviceAccount"`
+
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty" protobuf:"varint,21,opt,name=automountServiceAccountToken"`
+
+	// +optional
+	NodeName string `json:"nodeName,omitempty" protobuf:"bytes,10,opt,name=nodeName"`
+
+	// +k8s:conversion-gen=false
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty" protobuf:"varint,11,opt,name=hostNetwork"`
+
+	// +k8s:conversion-gen=false
+	// +optional
+	HostPID bool `json:"hostPID,omitempty" protobuf:"varint,12,opt,name=hostPID"`
+
+	// +k8s:conversion-gen=false
+	// +optional
+	HostIPC bool `json:"hostIPC,omitempty" protobuf:"varint,13,opt,name=hostIPC"`
+
+	// +k8s:conversion-gen=false
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty" protobuf:"varint,27,opt,name=shareProcessNamespace"`
+
+	// +optional
+	SecurityContext *core.PodSecurityContext `json:"securityContext,omitempty" protobuf:"bytes,14,opt,name=securityContext"`
+
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	ImagePullSecrets []core.LocalObjectReference `json:"imagePullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,15,rep,name=imagePullSecrets"`
+
+	// +optional
+	Hostname string `json:"hostname,omitempty" protobuf:"bytes,16,opt,name=hostname"`
+
+	// +optional
+	Subdomain string `json:"subdomain,omitempty" protobuf:"bytes,17,opt,name=subdomain"`
+
+	// +optional
+	Affinity *core.Affinity `json:"affinity,omitempty" protobuf:"bytes,18,opt,name=affinity"`
+
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty" protobuf:"bytes,19,opt,name=schedulerName"`
+
+	// +optional
+	Tolerations []core.Toleration `json:"tolerations,omitempty" protobuf:"bytes,22,opt,name=tolerations"`
+
+	// +optional
+	// +patchMergeKey=ip
+	// +patchStrategy=merge
+	HostAliases []core.HostAlias `json:"hostAliases,omitempty" patchStrategy:"merge" patchMergeKey:"ip" protobuf:"bytes,23,rep,name=hostAliases"`
+
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty" protobuf:"bytes,24,opt,name=priorityClassName"`
+
+	// +optional
+	Priority *int32 `json:"priority,omitempty" protobuf:"bytes,25,opt,name=priority"`
+
+	// +optional
+	DNSConfig *core.PodDNSConfig `json:"dnsConfig,omitempty" protobuf:"bytes,26,opt,name=dnsConfig"`
+
+	// +optional
+	ReadinessGates []core.PodReadinessGate `json:"readinessGates,omitempty" protobuf:"bytes,28,opt,name=readinessGates"`
+
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty" protobuf:"bytes,29,opt,name=runtimeClassName"`
+
+	// +optional
+	EnableServiceLinks *bool `json:"enableServiceLinks,omitempty" protobuf:"varint,30,opt,name=enableServiceLinks"`
+
+	// +optional
+	PreemptionPolicy *core.PreemptionPolicy `json:"preemptionPolicy,omitempty" protobuf:"bytes,31,opt,name=preemptionPolicy"`
+
+	// +optional
+	Overhead core.ResourceList `json:"overhead,omitempty" protobuf:"bytes,32,opt,name=overhead"`
+
+	// +optional
+	// +patchMergeKey=topologyKey
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=topologyKey
+	// +listMapKey=whenUnsatisfiable
+	TopologySpreadConstraints []core.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty" patchStrategy:"merge" patchMergeKey:"topologyKey" protobuf:"bytes,33,opt,name=topologySpreadConstraints"`
+
+	// +optional
+	SetHostnameAsFQDN *bool `json:"setHostnameAsFQDN,omitempty" protobuf:"varint,35,opt,name=setHostnameAsFQDN"`
+
+	// +optional
+	OS *core.PodOS `json:"os,omitempty" protobuf:"bytes,36,opt,name=os"`
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApicurioRegistryArtifactType is the type of the artifact content
+// +kubebuilder:validation:Enum=AVRO;PROTOBUF;JSON;OPENAPI;ASYNCAPI;GRAPHQL;KCONNECT;WSDL;XSD;XML
+type ApicurioRegistryArtifactType string
+
+const (
+	ArtifactTypeAvro     ApicurioRegistryArtifactType = "AVRO"
+	ArtifactTypeProtobuf ApicurioRegistryArtifactType = "PROTOBUF"
+	ArtifactTypeJson     ApicurioRegistryArtifactType = "JSON"
+	ArtifactTypeOpenapi  ApicurioRegistryArtifactType = "OPENAPI"
+	ArtifactTypeAsyncapi ApicurioRegistryArtifactType = "ASYNCAPI"
+	ArtifactTypeGraphql  ApicurioRegistryArtifactType = "GRAPHQL"
+	ArtifactTypeKconnect ApicurioRegistryArtifactType = "KCONNECT"
+	ArtifactTypeWsdl     ApicurioRegistryArtifactType = "WSDL"
+	ArtifactTypeXsd      ApicurioRegistryArtifactType = "XSD"
+	ArtifactTypeXml      ApicurioRegistryArtifactType = "XML"
+)
+
+// ApicurioRegistryArtifactVersionPolicy configures how changes of the content are applied
+// +kubebuilder:validation:Enum=CreateVersion;KeepExisting
+type ApicurioRegistryArtifactVersionPolicy string
+
+const (
+	// A new version is created when the content differs from the latest version
+	ArtifactVersionPolicyCreateVersion ApicurioRegistryArtifactVersionPolicy = "CreateVersion"
+	// The artifact is created if it does not exist, but an existing artifact is not changed
+	ArtifactVersionPolicyKeepExisting ApicurioRegistryArtifactVersionPolicy = "KeepExisting"
+)
+
+// ApicurioRegistryArtifactDeletionPolicy configures what happens to the artifact when the resource is deleted
+// +kubebuilder:validation:Enum=Delete;Disable;Retain
+type ApicurioRegistryArtifactDeletionPolicy string
+
+const (
+	ArtifactDeletionPolicyDelete  ApicurioRegistryArtifactDeletionPolicy = "Delete"
+	ArtifactDeletionPolicyDisable ApicurioRegistryArtifactDeletionPolicy = "Disable"
+	ArtifactDeletionPolicyRetain  ApicurioRegistryArtifactDeletionPolicy = "Retain"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.value)",message="exactly one of configMapKeyRef and value must be set"
+type ApicurioRegistryArtifactContent struct {
+	// ConfigMap key reference:
+	//
+	// Reference to a key of a ConfigMap in the same namespace, which contains the content.
+	// Changes of the ConfigMap are applied when the artifact is synchronized next time,
+	// within the interval set by the `content.resyncInterval` key of the Operator configuration, 5 minutes by default.
+	ConfigMapKeyRef *core.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// Value:
+	//
+	// Inline content of the artifact.
+	Value string `json:"value,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.registry.name == oldSelf.registry.name",message="registry.name is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.groupId) == has(oldSelf.groupId) && (!has(self.groupId) || self.groupId == oldSelf.groupId)",message="groupId is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.artifactId) == has(oldSelf.artifactId) && (!has(self.artifactId) || self.artifactId == oldSelf.artifactId)",message="artifactId is immutable"
+type ApicurioRegistryArtifactSpec struct {
+	// Apicurio Registry:
+	//
+	// Apicurio Registry instance that contains the artifact.
+	Registry ApicurioRegistryReference `json:"registry"`
+	// Group ID:
+	//
+	// ID of the artifact group. Default value is `default`.
+	GroupId string `json:"groupId,omitempty"`
+	// Artifact ID:
+	//
+	// ID of the artifact. Default value is the name of this resource.
+	ArtifactId string `json:"artifactId,omitempty"`
+	// Type:
+	//
+	// Type of the artifact content. If it is not set, Apicurio Registry detects the type from the content.
+	Type ApicurioRegistryArtifactType `json:"type,omitempty"`
+	// Content:
+	//
+	// Content of the artifact, provided inline or as a reference to a ConfigMap key.
+	Content ApicurioRegistryArtifactContent `json:"content"`
+	// Version:
+	//
+	// Version of the artifact that is created for the content.
+	// If it is not set, Apicurio Registry generates the version.
+	// When the content changes, the version must be changed as well.
+	Version string `json:"version,omitempty"`
+	// Version policy:
+	//
+	// How the content is applied to an existing artifact, one of:
+	// `CreateVersion` (a new version is created when the content differs from the latest version),
+	// `KeepExisting` (an existing artifact is never changed).
+	// Default value is `CreateVersion`.
+	VersionPolicy ApicurioRegistryArtifactVersionPolicy `json:"versionPolicy,omitempty"`
+	// Deletion policy:
+	//
+	// What happens to the artifact when this resource is deleted, one of:
+	// `Delete` (the artifact and all its versions are deleted),
+	// `Disable` (all versions of the artifact are disabled, so they can be enabled again using the REST API),
+	// `Retain` (the artifact is not changed).
+	// Default value is `Disable`.
+	DeletionPolicy ApicurioRegistryArtifactDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+type ApicurioRegistryArtifactStatus struct {
+	ApicurioRegistryContentStatus `json:",inline"`
+	// Global ID:
+	//
+	// Global ID of the latest version of the artifact.
+	GlobalId int64 `json:"globalId,omitempty"`
+	// Version:
+	//
+	// Latest version of the artifact.
+	Version string `json:"version,omitempty"`
+}
+
+// ApicurioRegistryArtifact represents an artifact in an Apicurio Registry instance
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type ApicurioRegistryArtifact struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApicurioRegistryArtifactSpec   `json:"spec,omitempty"`
+	Status ApicurioRegistryArtifactStatus `json:"status,omitempty"`
+}
+
+// ApicurioRegistryArtifactList contains a list of ApicurioRegistryArtifact
+// +kubebuilder:object:root=true
+type ApicurioRegistryArtifactList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ApicurioRegistryArtifact `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApicurioRegistryArtifact{}, &ApicurioRegistryArtifactList{})
+}
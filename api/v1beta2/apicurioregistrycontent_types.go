@@ -0,0 +1,65 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Types shared by the resources that manage the content of an Apicurio Registry instance,
+// using the Apicurio Registry REST API.
+
+type ApicurioRegistryReference struct {
+	// Name:
+	//
+	// Name of the ApicurioRegistry resource in the same namespace.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ApicurioRegistryRuleType is the type of an Apicurio Registry rule
+// +kubebuilder:validation:Enum=VALIDITY;COMPATIBILITY;INTEGRITY
+type ApicurioRegistryRuleType string
+
+const (
+	RuleTypeValidity      ApicurioRegistryRuleType = "VALIDITY"
+	RuleTypeCompatibility ApicurioRegistryRuleType = "COMPATIBILITY"
+	RuleTypeIntegrity     ApicurioRegistryRuleType = "INTEGRITY"
+)
+
+type ApicurioRegistryRule struct {
+	// Type:
+	//
+	// Type of the rule, one of: VALIDITY, COMPATIBILITY, INTEGRITY.
+	Type ApicurioRegistryRuleType `json:"type"`
+	// Configuration:
+	//
+	// Configuration of the rule, for example `FULL` for the VALIDITY rule, or `BACKWARD` for the COMPATIBILITY rule.
+	// +kubebuilder:validation:MinLength=1
+	Config string `json:"config"`
+}
+
+type ApicurioRegistryContentStatus struct {
+	// Observed generation:
+	//
+	// Generation of the resource that has been synchronized with Apicurio Registry most recently.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions:
+	//
+	// State of the synchronization with Apicurio Registry.
+	Conditions []meta.Condition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.registry.name == oldSelf.registry.name",message="registry.name is immutable"
+// +kubebuilder:validation:XValidation:rule="self.type == oldSelf.type",message="type is immutable"
+type ApicurioRegistryGlobalRuleSpec struct {
+	// Apicurio Registry:
+	//
+	// Apicurio Registry instance that this global rule is configured in.
+	Registry ApicurioRegistryReference `json:"registry"`
+	// Global rule:
+	//
+	// Global rule applied to artifacts that do not have their own rule of the same type.
+	// There should be only one ApicurioRegistryGlobalRule of each type for an Apicurio Registry instance.
+	ApicurioRegistryRule `json:",inline"`
+}
+
+// ApicurioRegistryGlobalRule represents a global rule configured in an Apicurio Registry instance
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type ApicurioRegistryGlobalRule struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApicurioRegistryGlobalRuleSpec `json:"spec,omitempty"`
+	Status ApicurioRegistryContentStatus  `json:"status,omitempty"`
+}
+
+// ApicurioRegistryGlobalRuleList contains a list of ApicurioRegistryGlobalRule
+// +kubebuilder:object:root=true
+type ApicurioRegistryGlobalRuleList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ApicurioRegistryGlobalRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApicurioRegistryGlobalRule{}, &ApicurioRegistryGlobalRuleList{})
+}
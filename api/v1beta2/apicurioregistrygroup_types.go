@@ -0,0 +1,71 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.registry.name == oldSelf.registry.name",message="registry.name is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.groupId) == has(oldSelf.groupId) && (!has(self.groupId) || self.groupId == oldSelf.groupId)",message="groupId is immutable"
+type ApicurioRegistryGroupSpec struct {
+	// Apicurio Registry:
+	//
+	// Apicurio Registry instance that contains the group.
+	Registry ApicurioRegistryReference `json:"registry"`
+	// Group ID:
+	//
+	// ID of the artifact group. Default value is the name of this resource.
+	GroupId string `json:"groupId,omitempty"`
+	// Rules:
+	//
+	// Rules applied to every artifact in the group, including the artifacts created later.
+	// There can be at most one rule of each type.
+	Rules []ApicurioRegistryRule `json:"rules,omitempty"`
+}
+
+type ApicurioRegistryGroupStatus struct {
+	ApicurioRegistryContentStatus `json:",inline"`
+	// Applied rules:
+	//
+	// Rules that have been applied to the artifacts in the group,
+	// so they can be removed when they are removed from the spec.
+	AppliedRules []ApicurioRegistryRule `json:"appliedRules,omitempty"`
+}
+
+// ApicurioRegistryGroup represents an artifact group in an Apicurio Registry instance
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type ApicurioRegistryGroup struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApicurioRegistryGroupSpec   `json:"spec,omitempty"`
+	Status ApicurioRegistryGroupStatus `json:"status,omitempty"`
+}
+
+// ApicurioRegistryGroupList contains a list of ApicurioRegistryGroup
+// +kubebuilder:object:root=true
+type ApicurioRegistryGroupList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ApicurioRegistryGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApicurioRegistryGroup{}, &ApicurioRegistryGroupList{})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApicurioRegistryRole is the name of an Apicurio Registry role
+// +kubebuilder:validation:Enum=READ_ONLY;DEVELOPER;ADMIN
+type ApicurioRegistryRole string
+
+const (
+	RoleReadOnly  ApicurioRegistryRole = "READ_ONLY"
+	RoleDeveloper ApicurioRegistryRole = "DEVELOPER"
+	RoleAdmin     ApicurioRegistryRole = "ADMIN"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.registry.name == oldSelf.registry.name",message="registry.name is immutable"
+// +kubebuilder:validation:XValidation:rule="self.principalId == oldSelf.principalId",message="principalId is immutable"
+type ApicurioRegistryRoleMappingSpec struct {
+	// Apicurio Registry:
+	//
+	// Apicurio Registry instance that this role mapping is configured in.
+	// Role mappings are used only if role-based authorization is enabled,
+	// and the source of the roles is `application`, see `spec.configuration.registry.authorization`.
+	Registry ApicurioRegistryReference `json:"registry"`
+	// Principal ID:
+	//
+	// ID of the user or the service account.
+	// +kubebuilder:validation:MinLength=1
+	PrincipalId string `json:"principalId"`
+	// Principal name:
+	//
+	// Human-readable name of the user or the service account.
+	PrincipalName string `json:"principalName,omitempty"`
+	// Role:
+	//
+	// Role granted to the principal, one of: READ_ONLY, DEVELOPER, ADMIN.
+	Role ApicurioRegistryRole `json:"role"`
+}
+
+// ApicurioRegistryRoleMapping represents a role mapping configured in an Apicurio Registry instance
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type ApicurioRegistryRoleMapping struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApicurioRegistryRoleMappingSpec `json:"spec,omitempty"`
+	Status ApicurioRegistryContentStatus   `json:"status,omitempty"`
+}
+
+// ApicurioRegistryRoleMappingList contains a list of ApicurioRegistryRoleMapping
+// +kubebuilder:object:root=true
+type ApicurioRegistryRoleMappingList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []ApicurioRegistryRoleMapping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApicurioRegistryRoleMapping{}, &ApicurioRegistryRoleMappingList{})
+}
@@ -0,0 +1,1589 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta2
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistry) DeepCopyInto(out *ApicurioRegistry) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistry.
+func (in *ApicurioRegistry) DeepCopy() *ApicurioRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistry) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryArtifact) DeepCopyInto(out *ApicurioRegistryArtifact) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryArtifact.
+func (in *ApicurioRegistryArtifact) DeepCopy() *ApicurioRegistryArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryArtifact) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryArtifactContent) DeepCopyInto(out *ApicurioRegistryArtifactContent) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryArtifactContent.
+func (in *ApicurioRegistryArtifactContent) DeepCopy() *ApicurioRegistryArtifactContent {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryArtifactContent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryArtifactList) DeepCopyInto(out *ApicurioRegistryArtifactList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApicurioRegistryArtifact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryArtifactList.
+func (in *ApicurioRegistryArtifactList) DeepCopy() *ApicurioRegistryArtifactList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryArtifactList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryArtifactList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryArtifactSpec) DeepCopyInto(out *ApicurioRegistryArtifactSpec) {
+	*out = *in
+	out.Registry = in.Registry
+	in.Content.DeepCopyInto(&out.Content)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryArtifactSpec.
+func (in *ApicurioRegistryArtifactSpec) DeepCopy() *ApicurioRegistryArtifactSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryArtifactSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryArtifactStatus) DeepCopyInto(out *ApicurioRegistryArtifactStatus) {
+	*out = *in
+	in.ApicurioRegistryContentStatus.DeepCopyInto(&out.ApicurioRegistryContentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryArtifactStatus.
+func (in *ApicurioRegistryArtifactStatus) DeepCopy() *ApicurioRegistryArtifactStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryArtifactStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryContentStatus) DeepCopyInto(out *ApicurioRegistryContentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryContentStatus.
+func (in *ApicurioRegistryContentStatus) DeepCopy() *ApicurioRegistryContentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryContentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryGlobalRule) DeepCopyInto(out *ApicurioRegistryGlobalRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryGlobalRule.
+func (in *ApicurioRegistryGlobalRule) DeepCopy() *ApicurioRegistryGlobalRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryGlobalRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryGlobalRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryGlobalRuleList) DeepCopyInto(out *ApicurioRegistryGlobalRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApicurioRegistryGlobalRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryGlobalRuleList.
+func (in *ApicurioRegistryGlobalRuleList) DeepCopy() *ApicurioRegistryGlobalRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryGlobalRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryGlobalRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryGlobalRuleSpec) DeepCopyInto(out *ApicurioRegistryGlobalRuleSpec) {
+	*out = *in
+	out.Registry = in.Registry
+	out.ApicurioRegistryRule = in.ApicurioRegistryRule
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryGlobalRuleSpec.
+func (in *ApicurioRegistryGlobalRuleSpec) DeepCopy() *ApicurioRegistryGlobalRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryGlobalRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryGroup) DeepCopyInto(out *ApicurioRegistryGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryGroup.
+func (in *ApicurioRegistryGroup) DeepCopy() *ApicurioRegistryGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryGroupList) DeepCopyInto(out *ApicurioRegistryGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApicurioRegistryGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryGroupList.
+func (in *ApicurioRegistryGroupList) DeepCopy() *ApicurioRegistryGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryGroupSpec) DeepCopyInto(out *ApicurioRegistryGroupSpec) {
+	*out = *in
+	out.Registry = in.Registry
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ApicurioRegistryRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryGroupSpec.
+func (in *ApicurioRegistryGroupSpec) DeepCopy() *ApicurioRegistryGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryGroupStatus) DeepCopyInto(out *ApicurioRegistryGroupStatus) {
+	*out = *in
+	in.ApicurioRegistryContentStatus.DeepCopyInto(&out.ApicurioRegistryContentStatus)
+	if in.AppliedRules != nil {
+		in, out := &in.AppliedRules, &out.AppliedRules
+		*out = make([]ApicurioRegistryRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryGroupStatus.
+func (in *ApicurioRegistryGroupStatus) DeepCopy() *ApicurioRegistryGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryList) DeepCopyInto(out *ApicurioRegistryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApicurioRegistry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryList.
+func (in *ApicurioRegistryList) DeepCopy() *ApicurioRegistryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryObjectMeta) DeepCopyInto(out *ApicurioRegistryObjectMeta) {
+	*out = *in
+	if in.CreationTimestamp != nil {
+		in, out := &in.CreationTimestamp, &out.CreationTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.DeletionTimestamp != nil {
+		in, out := &in.DeletionTimestamp, &out.DeletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.DeletionGracePeriodSeconds != nil {
+		in, out := &in.DeletionGracePeriodSeconds, &out.DeletionGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OwnerReferences != nil {
+		in, out := &in.OwnerReferences, &out.OwnerReferences
+		*out = make([]metav1.OwnerReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Finalizers != nil {
+		in, out := &in.Finalizers, &out.Finalizers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFields != nil {
+		in, out := &in.ManagedFields, &out.ManagedFields
+		*out = make([]metav1.ManagedFieldsEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryObjectMeta.
+func (in *ApicurioRegistryObjectMeta) DeepCopy() *ApicurioRegistryObjectMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryObjectMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryPodSpec) DeepCopyInto(out *ApicurioRegistryPodSpec) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EphemeralContainers != nil {
+		in, out := &in.EphemeralContainers, &out.EphemeralContainers
+		*out = make([]v1.EphemeralContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ShareProcessNamespace != nil {
+		in, out := &in.ShareProcessNamespace, &out.ShareProcessNamespace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]v1.PodReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableServiceLinks != nil {
+		in, out := &in.EnableServiceLinks, &out.EnableServiceLinks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PreemptionPolicy != nil {
+		in, out := &in.PreemptionPolicy, &out.PreemptionPolicy
+		*out = new(v1.PreemptionPolicy)
+		**out = **in
+	}
+	if in.Overhead != nil {
+		in, out := &in.Overhead, &out.Overhead
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SetHostnameAsFQDN != nil {
+		in, out := &in.SetHostnameAsFQDN, &out.SetHostnameAsFQDN
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OS != nil {
+		in, out := &in.OS, &out.OS
+		*out = new(v1.PodOS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryPodSpec.
+func (in *ApicurioRegistryPodSpec) DeepCopy() *ApicurioRegistryPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryPodTemplateSpec) DeepCopyInto(out *ApicurioRegistryPodTemplateSpec) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryPodTemplateSpec.
+func (in *ApicurioRegistryPodTemplateSpec) DeepCopy() *ApicurioRegistryPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryReference) DeepCopyInto(out *ApicurioRegistryReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryReference.
+func (in *ApicurioRegistryReference) DeepCopy() *ApicurioRegistryReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryRoleMapping) DeepCopyInto(out *ApicurioRegistryRoleMapping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryRoleMapping.
+func (in *ApicurioRegistryRoleMapping) DeepCopy() *ApicurioRegistryRoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryRoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryRoleMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryRoleMappingList) DeepCopyInto(out *ApicurioRegistryRoleMappingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApicurioRegistryRoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryRoleMappingList.
+func (in *ApicurioRegistryRoleMappingList) DeepCopy() *ApicurioRegistryRoleMappingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryRoleMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApicurioRegistryRoleMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryRoleMappingSpec) DeepCopyInto(out *ApicurioRegistryRoleMappingSpec) {
+	*out = *in
+	out.Registry = in.Registry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryRoleMappingSpec.
+func (in *ApicurioRegistryRoleMappingSpec) DeepCopy() *ApicurioRegistryRoleMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryRoleMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryRule) DeepCopyInto(out *ApicurioRegistryRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryRule.
+func (in *ApicurioRegistryRule) DeepCopy() *ApicurioRegistryRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySecretValue) DeepCopyInto(out *ApicurioRegistrySecretValue) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySecretValue.
+func (in *ApicurioRegistrySecretValue) DeepCopy() *ApicurioRegistrySecretValue {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySecretValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpec) DeepCopyInto(out *ApicurioRegistrySpec) {
+	*out = *in
+	in.Configuration.DeepCopyInto(&out.Configuration)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpec.
+func (in *ApicurioRegistrySpec) DeepCopy() *ApicurioRegistrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfiguration) DeepCopyInto(out *ApicurioRegistrySpecConfiguration) {
+	*out = *in
+	in.Sql.DeepCopyInto(&out.Sql)
+	in.Kafkasql.DeepCopyInto(&out.Kafkasql)
+	out.UI = in.UI
+	in.Security.DeepCopyInto(&out.Security)
+	out.Registry = in.Registry
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfiguration.
+func (in *ApicurioRegistrySpecConfiguration) DeepCopy() *ApicurioRegistrySpecConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationDataSource) DeepCopyInto(out *ApicurioRegistrySpecConfigurationDataSource) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationDataSource.
+func (in *ApicurioRegistrySpecConfigurationDataSource) DeepCopy() *ApicurioRegistrySpecConfigurationDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurity) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkaSecurity) {
+	*out = *in
+	out.Tls = in.Tls
+	out.Scram = in.Scram
+	out.OAuth = in.OAuth
+	out.Plain = in.Plain
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkaSecurity.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurity) DeepCopy() *ApicurioRegistrySpecConfigurationKafkaSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkaSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityOAuth) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkaSecurityOAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkaSecurityOAuth.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityOAuth) DeepCopy() *ApicurioRegistrySpecConfigurationKafkaSecurityOAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkaSecurityOAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityPlain) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkaSecurityPlain) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkaSecurityPlain.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityPlain) DeepCopy() *ApicurioRegistrySpecConfigurationKafkaSecurityPlain {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkaSecurityPlain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityScram) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkaSecurityScram) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkaSecurityScram.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityScram) DeepCopy() *ApicurioRegistrySpecConfigurationKafkaSecurityScram {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkaSecurityScram)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityTls) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkaSecurityTls) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkaSecurityTls.
+func (in *ApicurioRegistrySpecConfigurationKafkaSecurityTls) DeepCopy() *ApicurioRegistrySpecConfigurationKafkaSecurityTls {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkaSecurityTls)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkasql) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkasql) {
+	*out = *in
+	out.Security = in.Security
+	out.Strimzi = in.Strimzi
+	in.Topic.DeepCopyInto(&out.Topic)
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkasql.
+func (in *ApicurioRegistrySpecConfigurationKafkasql) DeepCopy() *ApicurioRegistrySpecConfigurationKafkasql {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkasql)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkasqlStrimzi) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkasqlStrimzi) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkasqlStrimzi.
+func (in *ApicurioRegistrySpecConfigurationKafkasqlStrimzi) DeepCopy() *ApicurioRegistrySpecConfigurationKafkasqlStrimzi {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkasqlStrimzi)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationKafkasqlTopic) DeepCopyInto(out *ApicurioRegistrySpecConfigurationKafkasqlTopic) {
+	*out = *in
+	if in.AutoCreate != nil {
+		in, out := &in.AutoCreate, &out.AutoCreate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationKafkasqlTopic.
+func (in *ApicurioRegistrySpecConfigurationKafkasqlTopic) DeepCopy() *ApicurioRegistrySpecConfigurationKafkasqlTopic {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationKafkasqlTopic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationRegistry) DeepCopyInto(out *ApicurioRegistrySpecConfigurationRegistry) {
+	*out = *in
+	out.Rules = in.Rules
+	out.Limits = in.Limits
+	out.Authorization = in.Authorization
+	out.UI = in.UI
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationRegistry.
+func (in *ApicurioRegistrySpecConfigurationRegistry) DeepCopy() *ApicurioRegistrySpecConfigurationRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationRegistryAuthorization) DeepCopyInto(out *ApicurioRegistrySpecConfigurationRegistryAuthorization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationRegistryAuthorization.
+func (in *ApicurioRegistrySpecConfigurationRegistryAuthorization) DeepCopy() *ApicurioRegistrySpecConfigurationRegistryAuthorization {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationRegistryAuthorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationRegistryLimits) DeepCopyInto(out *ApicurioRegistrySpecConfigurationRegistryLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationRegistryLimits.
+func (in *ApicurioRegistrySpecConfigurationRegistryLimits) DeepCopy() *ApicurioRegistrySpecConfigurationRegistryLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationRegistryLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationRegistryRules) DeepCopyInto(out *ApicurioRegistrySpecConfigurationRegistryRules) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationRegistryRules.
+func (in *ApicurioRegistrySpecConfigurationRegistryRules) DeepCopy() *ApicurioRegistrySpecConfigurationRegistryRules {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationRegistryRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationRegistryUI) DeepCopyInto(out *ApicurioRegistrySpecConfigurationRegistryUI) {
+	*out = *in
+	out.Features = in.Features
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationRegistryUI.
+func (in *ApicurioRegistrySpecConfigurationRegistryUI) DeepCopy() *ApicurioRegistrySpecConfigurationRegistryUI {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationRegistryUI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationRegistryUIFeatures) DeepCopyInto(out *ApicurioRegistrySpecConfigurationRegistryUIFeatures) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationRegistryUIFeatures.
+func (in *ApicurioRegistrySpecConfigurationRegistryUIFeatures) DeepCopy() *ApicurioRegistrySpecConfigurationRegistryUIFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationRegistryUIFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationSecurity) DeepCopyInto(out *ApicurioRegistrySpecConfigurationSecurity) {
+	*out = *in
+	in.Keycloak.DeepCopyInto(&out.Keycloak)
+	out.Https = in.Https
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationSecurity.
+func (in *ApicurioRegistrySpecConfigurationSecurity) DeepCopy() *ApicurioRegistrySpecConfigurationSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationSecurityHttps) DeepCopyInto(out *ApicurioRegistrySpecConfigurationSecurityHttps) {
+	*out = *in
+	out.Certificate = in.Certificate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationSecurityHttps.
+func (in *ApicurioRegistrySpecConfigurationSecurityHttps) DeepCopy() *ApicurioRegistrySpecConfigurationSecurityHttps {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationSecurityHttps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationSecurityHttpsCertificate) DeepCopyInto(out *ApicurioRegistrySpecConfigurationSecurityHttpsCertificate) {
+	*out = *in
+	out.Issuer = in.Issuer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationSecurityHttpsCertificate.
+func (in *ApicurioRegistrySpecConfigurationSecurityHttpsCertificate) DeepCopy() *ApicurioRegistrySpecConfigurationSecurityHttpsCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationSecurityHttpsCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer) DeepCopyInto(out *ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer.
+func (in *ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer) DeepCopy() *ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationSecurityHttpsCertificateIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationSecurityKeycloak) DeepCopyInto(out *ApicurioRegistrySpecConfigurationSecurityKeycloak) {
+	*out = *in
+	in.OperatorClient.DeepCopyInto(&out.OperatorClient)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationSecurityKeycloak.
+func (in *ApicurioRegistrySpecConfigurationSecurityKeycloak) DeepCopy() *ApicurioRegistrySpecConfigurationSecurityKeycloak {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationSecurityKeycloak)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient) DeepCopyInto(out *ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient) {
+	*out = *in
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient.
+func (in *ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient) DeepCopy() *ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationSecurityKeycloakOperatorClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationSql) DeepCopyInto(out *ApicurioRegistrySpecConfigurationSql) {
+	*out = *in
+	in.DataSource.DeepCopyInto(&out.DataSource)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationSql.
+func (in *ApicurioRegistrySpecConfigurationSql) DeepCopy() *ApicurioRegistrySpecConfigurationSql {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationSql)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecConfigurationUI) DeepCopyInto(out *ApicurioRegistrySpecConfigurationUI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecConfigurationUI.
+func (in *ApicurioRegistrySpecConfigurationUI) DeepCopy() *ApicurioRegistrySpecConfigurationUI {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecConfigurationUI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeployment) DeepCopyInto(out *ApicurioRegistrySpecDeployment) {
+	*out = *in
+	in.Hosts.DeepCopyInto(&out.Hosts)
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Upgrade = in.Upgrade
+	out.HighAvailability = in.HighAvailability
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.ManagedResourcesMetadata.DeepCopyInto(&out.ManagedResourcesMetadata)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.ManagedResources.DeepCopyInto(&out.ManagedResources)
+	out.Route = in.Route
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	in.Service.DeepCopyInto(&out.Service)
+	in.PodTemplateSpec.DeepCopyInto(&out.PodTemplateSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeployment.
+func (in *ApicurioRegistrySpecDeployment) DeepCopy() *ApicurioRegistrySpecDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentHighAvailability) DeepCopyInto(out *ApicurioRegistrySpecDeploymentHighAvailability) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentHighAvailability.
+func (in *ApicurioRegistrySpecDeploymentHighAvailability) DeepCopy() *ApicurioRegistrySpecDeploymentHighAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentHighAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentHosts) DeepCopyInto(out *ApicurioRegistrySpecDeploymentHosts) {
+	*out = *in
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentHosts.
+func (in *ApicurioRegistrySpecDeploymentHosts) DeepCopy() *ApicurioRegistrySpecDeploymentHosts {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentHosts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentManagedResources) DeepCopyInto(out *ApicurioRegistrySpecDeploymentManagedResources) {
+	*out = *in
+	if in.RetainOnDeletion != nil {
+		in, out := &in.RetainOnDeletion, &out.RetainOnDeletion
+		*out = make([]ApicurioRegistryManagedResourceKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentManagedResources.
+func (in *ApicurioRegistrySpecDeploymentManagedResources) DeepCopy() *ApicurioRegistrySpecDeploymentManagedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentManagedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentManagedResourcesMetadata) DeepCopyInto(out *ApicurioRegistrySpecDeploymentManagedResourcesMetadata) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentManagedResourcesMetadata.
+func (in *ApicurioRegistrySpecDeploymentManagedResourcesMetadata) DeepCopy() *ApicurioRegistrySpecDeploymentManagedResourcesMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentManagedResourcesMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentMetadata) DeepCopyInto(out *ApicurioRegistrySpecDeploymentMetadata) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentMetadata.
+func (in *ApicurioRegistrySpecDeploymentMetadata) DeepCopy() *ApicurioRegistrySpecDeploymentMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentNetworkPolicy) DeepCopyInto(out *ApicurioRegistrySpecDeploymentNetworkPolicy) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.Egress.DeepCopyInto(&out.Egress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentNetworkPolicy.
+func (in *ApicurioRegistrySpecDeploymentNetworkPolicy) DeepCopy() *ApicurioRegistrySpecDeploymentNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentNetworkPolicyEgress) DeepCopyInto(out *ApicurioRegistrySpecDeploymentNetworkPolicyEgress) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentNetworkPolicyEgress.
+func (in *ApicurioRegistrySpecDeploymentNetworkPolicyEgress) DeepCopy() *ApicurioRegistrySpecDeploymentNetworkPolicyEgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentNetworkPolicyEgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentNetworkPolicyIngress) DeepCopyInto(out *ApicurioRegistrySpecDeploymentNetworkPolicyIngress) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentNetworkPolicyIngress.
+func (in *ApicurioRegistrySpecDeploymentNetworkPolicyIngress) DeepCopy() *ApicurioRegistrySpecDeploymentNetworkPolicyIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentNetworkPolicyIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentPodDisruptionBudget) DeepCopyInto(out *ApicurioRegistrySpecDeploymentPodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentPodDisruptionBudget.
+func (in *ApicurioRegistrySpecDeploymentPodDisruptionBudget) DeepCopy() *ApicurioRegistrySpecDeploymentPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentRoute) DeepCopyInto(out *ApicurioRegistrySpecDeploymentRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentRoute.
+func (in *ApicurioRegistrySpecDeploymentRoute) DeepCopy() *ApicurioRegistrySpecDeploymentRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentService) DeepCopyInto(out *ApicurioRegistrySpecDeploymentService) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]ApicurioRegistrySpecDeploymentServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentService.
+func (in *ApicurioRegistrySpecDeploymentService) DeepCopy() *ApicurioRegistrySpecDeploymentService {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentServicePort) DeepCopyInto(out *ApicurioRegistrySpecDeploymentServicePort) {
+	*out = *in
+	if in.TargetPort != nil {
+		in, out := &in.TargetPort, &out.TargetPort
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentServicePort.
+func (in *ApicurioRegistrySpecDeploymentServicePort) DeepCopy() *ApicurioRegistrySpecDeploymentServicePort {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentServicePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistrySpecDeploymentUpgrade) DeepCopyInto(out *ApicurioRegistrySpecDeploymentUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistrySpecDeploymentUpgrade.
+func (in *ApicurioRegistrySpecDeploymentUpgrade) DeepCopy() *ApicurioRegistrySpecDeploymentUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistrySpecDeploymentUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryStatus) DeepCopyInto(out *ApicurioRegistryStatus) {
+	*out = *in
+	out.Info = in.Info
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagedResources != nil {
+		in, out := &in.ManagedResources, &out.ManagedResources
+		*out = make([]ApicurioRegistryStatusManagedResource, len(*in))
+		copy(*out, *in)
+	}
+	out.Upgrade = in.Upgrade
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryStatus.
+func (in *ApicurioRegistryStatus) DeepCopy() *ApicurioRegistryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryStatusInfo) DeepCopyInto(out *ApicurioRegistryStatusInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryStatusInfo.
+func (in *ApicurioRegistryStatusInfo) DeepCopy() *ApicurioRegistryStatusInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryStatusInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryStatusManagedResource) DeepCopyInto(out *ApicurioRegistryStatusManagedResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryStatusManagedResource.
+func (in *ApicurioRegistryStatusManagedResource) DeepCopy() *ApicurioRegistryStatusManagedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryStatusManagedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicurioRegistryStatusUpgrade) DeepCopyInto(out *ApicurioRegistryStatusUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicurioRegistryStatusUpgrade.
+func (in *ApicurioRegistryStatusUpgrade) DeepCopy() *ApicurioRegistryStatusUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicurioRegistryStatusUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -2,8 +2,9 @@ package controllers
 
 import (
 	go_ctx "context"
+	"encoding/json"
 	"errors"
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/cf"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/cf/condition"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/cf/kafkasql"
@@ -14,6 +15,7 @@ import (
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/impl"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	ocp_route "github.com/openshift/api/route/v1"
 	monitoring "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"go.uber.org/zap"
 	apps "k8s.io/api/apps/v1"
@@ -21,24 +23,40 @@ import (
 	networking "k8s.io/api/networking/v1"
 	policy_v1 "k8s.io/api/policy/v1"
 	policy_v1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
 	cr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"time"
 )
 
 var _ reconcile.Reconciler = &ApicurioRegistryReconciler{}
 
+// Makes sure the control functions perform the cleanup before the ApicurioRegistry resource is deleted
+const CleanupFinalizer = "registry.apicur.io/cleanup"
+
+const cleanupRetryDelay = 10 * time.Second
+
 type ApicurioRegistryReconciler struct {
-	log      *zap.Logger
-	clients  *client.Clients
-	testing  *c.TestSupport
-	loops    map[string]loop.ControlLoop
-	features *c.SupportedFeatures
+	log     *zap.Logger
+	clients *client.Clients
+	testing *c.TestSupport
+	loops   map[string]loop.ControlLoop
+	// Features supported by the cluster, some of them can be disabled in the Operator configuration
+	detectedFeatures *c.SupportedFeatures
+	features         *c.SupportedFeatures
+	scope            *NamespaceScope
+	// Revision of the Operator configuration used by the loops
+	operatorConfig         *c.OperatorConfig
+	operatorConfigRevision string
 }
 
-func NewApicurioRegistryReconciler(mgr manager.Manager, rootLog *zap.Logger, testing *c.TestSupport) (*ApicurioRegistryReconciler, error) {
+func NewApicurioRegistryReconciler(mgr manager.Manager, rootLog *zap.Logger, testing *c.TestSupport, scope *NamespaceScope,
+	operatorConfig *c.OperatorConfig) (*ApicurioRegistryReconciler, error) {
 
 	clients := client.NewClients(
 		rootLog.Named("clients"),
@@ -82,15 +100,39 @@ func NewApicurioRegistryReconciler(mgr manager.Manager, rootLog *zap.Logger, tes
 		rootLog.Sugar().Info("Install prometheus-operator in your cluster to create ServiceMonitor objects, restart apicurio-registry operator after installing prometheus-operator")
 	}
 	features.SupportsMonitoring = isMonitoring
-	testing.SetSupportedFeatures(features)
+
+	isStrimzi, err := clients.Discovery().IsStrimziInstalled()
+	if err != nil {
+		rootLog.Sugar().Errorw("could not determine if Strimzi resources are installed", "error", err)
+		return nil, err
+	}
+	if isStrimzi {
+		rootLog.Sugar().Info("Strimzi is installed, kafkasql.strimzi configuration option is supported")
+	}
+	features.SupportsStrimzi = isStrimzi
+
+	isCertManager, err := clients.Discovery().IsCertManagerInstalled()
+	if err != nil {
+		rootLog.Sugar().Errorw("could not determine if cert-manager resources are installed", "error", err)
+		return nil, err
+	}
+	if isCertManager {
+		rootLog.Sugar().Info("cert-manager is installed, certManager HTTPS certificate mode is supported")
+	}
+	features.SupportsCertManager = isCertManager
 
 	result := &ApicurioRegistryReconciler{
-		log:      rootLog.Named("controller"),
-		clients:  clients,
-		testing:  testing,
-		loops:    make(map[string]loop.ControlLoop),
-		features: features,
+		log:                    rootLog.Named("controller"),
+		clients:                clients,
+		testing:                testing,
+		loops:                  make(map[string]loop.ControlLoop),
+		detectedFeatures:       features,
+		features:               operatorConfig.Get().ApplyToFeatures(features),
+		scope:                  scope,
+		operatorConfig:         operatorConfig,
+		operatorConfigRevision: operatorConfig.GetRevision(),
 	}
+	testing.SetSupportedFeatures(result.features)
 
 	if err := result.setupWithManager(mgr); err != nil {
 		return nil, err
@@ -109,7 +151,11 @@ func (this *ApicurioRegistryReconciler) setupWithManager(mgr cr.Manager) error {
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			if e.ObjectOld.GetObjectKind().GroupVersionKind().Kind == "ApicurioRegistry" {
 				// Ignore updates to the ApicurioRegistry status, in which case metadata.Generation does not change.
-				return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+				// Annotation changes do not change metadata.Generation either, but an upgrade can be approved by one.
+				// The deletion timestamp is checked as well, although the generation changes when it is set.
+				return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+					e.ObjectOld.GetAnnotations()[cf.UpgradeApprovedImageAnnotation] != e.ObjectNew.GetAnnotations()[cf.UpgradeApprovedImageAnnotation] ||
+					!reflect.DeepEqual(e.ObjectOld.GetDeletionTimestamp(), e.ObjectNew.GetDeletionTimestamp())
 			}
 			return true
 		},
@@ -117,16 +163,36 @@ func (this *ApicurioRegistryReconciler) setupWithManager(mgr cr.Manager) error {
 
 	builder.Owns(&apps.Deployment{})
 	builder.Owns(&core.Service{})
+	builder.Owns(&core.Secret{})
 	builder.Owns(&networking.Ingress{})
-	if this.features.SupportsPDBv1beta1 {
+	if this.detectedFeatures.IsOCP {
+		builder.Owns(&ocp_route.Route{})
+	}
+	if this.detectedFeatures.SupportsPDBv1beta1 {
 		builder.Owns(&policy_v1beta1.PodDisruptionBudget{})
 	}
-	if this.features.SupportsPDBv1 {
+	if this.detectedFeatures.SupportsPDBv1 {
 		builder.Owns(&policy_v1.PodDisruptionBudget{})
 	}
-	if this.features.SupportsMonitoring {
+	if this.detectedFeatures.SupportsMonitoring {
 		builder.Owns(&monitoring.ServiceMonitor{})
 	}
+	if this.detectedFeatures.SupportsStrimzi {
+		for _, kind := range []string{"KafkaUser", "KafkaTopic"} {
+			owned := &unstructured.Unstructured{}
+			owned.SetAPIVersion(client.STRIMZI_API_GROUP_VERSION)
+			owned.SetKind(kind)
+			builder.Owns(owned)
+		}
+	}
+	if this.detectedFeatures.SupportsCertManager {
+		owned := &unstructured.Unstructured{}
+		owned.SetAPIVersion(client.CERT_MANAGER_API_GROUP_VERSION)
+		owned.SetKind("Certificate")
+		builder.Owns(owned)
+	}
+	this.scope.Watch(builder, &ar.ApicurioRegistryList{})
+	this.operatorConfig.Watch(builder, &ar.ApicurioRegistryList{})
 
 	return builder.Complete(this)
 }
@@ -151,6 +217,13 @@ func (this *ApicurioRegistryReconciler) setupWithManager(mgr cr.Manager) error {
 // Monitoring
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=*
 
+// Strimzi
+// +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkausers;kafkatopics,verbs=*
+
+// cert-manager
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=*
+
 // Cluster Info (k8s vs. OCP)
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get
 
@@ -165,6 +238,8 @@ func (this *ApicurioRegistryReconciler) Reconcile(_ go_ctx.Context, request reco
 
 	this.log.Sugar().Info("reconciler executing")
 
+	this.reloadOperatorConfig()
+
 	// Find the spec
 	spec, err := this.clients.CRD().GetApicurioRegistry(appNamespace, appName)
 	if err != nil {
@@ -174,29 +249,45 @@ func (this *ApicurioRegistryReconciler) Reconcile(_ go_ctx.Context, request reco
 	// Get the target control loop
 	key := appNamespace.Str() + "/" + appName.Str() // TODO Use types.NamespacedName ?
 	controlLoop, exists := this.loops[key]
-	if exists {
-		// If control loop exists, but spec is not found, do a cleanup
-		if spec == nil {
-			controlLoop.Cleanup()
+
+	inScope, err := this.scope.Contains(appNamespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !inScope {
+		return this.release(spec, key)
+	}
+
+	if spec == nil {
+		// The cleanup has been performed before the finalizer was removed
+		if exists {
 			delete(this.loops, key)
 			controlLoop.GetContext().GetLog().Sugar().Info("context was deleted")
-			return reconcile.Result{}, nil
-		} else {
-			// Run and reload spec into the cache
-			controlLoop.GetContext().GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(appName, spec))
 		}
-	} else {
-		if spec == nil {
-			// Error
-			return reconcile.Result{}, nil
-		} else {
-			// Create new loop, and requeue
-			controlLoop = this.createNewLoop(appName, appNamespace, this.features)
-			this.loops[key] = controlLoop
-			return reconcile.Result{Requeue: true}, nil
+		return reconcile.Result{}, nil
+	}
+	if spec.GetDeletionTimestamp() != nil {
+		return this.finalize(spec, key)
+	}
+
+	// Make sure the cleanup is performed even if the Operator is restarted before the resource is deleted
+	if controllerutil.AddFinalizer(spec, CleanupFinalizer) {
+		spec, err = this.clients.CRD().UpdateApicurioRegistry(appNamespace, spec)
+		if err != nil {
+			return reconcile.Result{}, err
 		}
 	}
 
+	if exists {
+		// Run and reload spec into the cache
+		controlLoop.GetContext().GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(appName, spec))
+	} else {
+		// Create new loop, and requeue
+		controlLoop = this.createNewLoop(appName, appNamespace, this.features)
+		this.loops[key] = controlLoop
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	// Loop is established, run it
 	controlLoop.Run()
 
@@ -205,13 +296,105 @@ func (this *ApicurioRegistryReconciler) Reconcile(_ go_ctx.Context, request reco
 	return reconcile.Result{Requeue: requeue, RequeueAfter: delay}, nil
 }
 
+// Performs the cleanup using the control functions, and removes the finalizer when it has finished
+func (this *ApicurioRegistryReconciler) finalize(spec *ar.ApicurioRegistry, key string) (reconcile.Result, error) {
+	appName := c.Name(spec.Name)
+	appNamespace := c.Namespace(spec.Namespace)
+
+	controlLoop, exists := this.loops[key]
+	if exists && this.testing.IsEnabled() && this.testing.GetMockOperatorRestart(appNamespace.Str()) {
+		this.testing.SetMockOperatorRestart(appNamespace.Str(), false)
+		delete(this.loops, key)
+		exists = false
+	}
+	if !controllerutil.ContainsFinalizer(spec, CleanupFinalizer) {
+		if exists {
+			delete(this.loops, key)
+			controlLoop.GetContext().GetLog().Sugar().Info("context was deleted")
+		}
+		return reconcile.Result{}, nil
+	}
+	// The loop does not exist if the Operator has been restarted
+	if !exists {
+		controlLoop = this.createNewLoop(appName, appNamespace, this.features)
+		this.loops[key] = controlLoop
+	}
+	controlLoop.GetContext().GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(appName, spec))
+
+	if spec.Status.Phase != ar.PhaseDeleting {
+		if err := this.setPhase(spec, ar.PhaseDeleting); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if !controlLoop.Cleanup() {
+		if err := this.setPhase(spec, ar.PhaseDeletionFailed); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: cleanupRetryDelay}, nil
+	}
+
+	controllerutil.RemoveFinalizer(spec, CleanupFinalizer)
+	if _, err := this.clients.CRD().UpdateApicurioRegistry(appNamespace, spec); err != nil {
+		return reconcile.Result{}, err
+	}
+	delete(this.loops, key)
+	controlLoop.GetContext().GetLog().Sugar().Info("context was deleted")
+	return reconcile.Result{}, nil
+}
+
+// Stops managing the resources when the namespace has left the scope of the Operator.
+// The managed resources are kept, and the finalizer is removed so the deletion of the ApicurioRegistry resource is not blocked.
+func (this *ApicurioRegistryReconciler) release(spec *ar.ApicurioRegistry, key string) (reconcile.Result, error) {
+	if controlLoop, exists := this.loops[key]; exists {
+		delete(this.loops, key)
+		controlLoop.GetContext().GetLog().Sugar().Info("namespace is not in scope, context was deleted")
+	}
+	if spec != nil && spec.GetDeletionTimestamp() != nil && controllerutil.RemoveFinalizer(spec, CleanupFinalizer) {
+		if _, err := this.clients.CRD().UpdateApicurioRegistry(c.Namespace(spec.Namespace), spec); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// The loops are created again when the Operator configuration changes, so it is reflected in every loop,
+// including the control functions that depend on the features. The important state of the loops is persisted.
+func (this *ApicurioRegistryReconciler) reloadOperatorConfig() {
+	if err := this.operatorConfig.Refresh(); err != nil {
+		this.log.Sugar().Errorw("could not reload the Operator configuration", "error", err)
+	}
+	if revision := this.operatorConfig.GetRevision(); revision != this.operatorConfigRevision {
+		this.operatorConfigRevision = revision
+		this.features = this.operatorConfig.Get().ApplyToFeatures(this.detectedFeatures)
+		this.testing.SetSupportedFeatures(this.features)
+		for key, controlLoop := range this.loops {
+			delete(this.loops, key)
+			controlLoop.GetContext().GetLog().Sugar().Info("Operator configuration has changed, context was deleted")
+		}
+	}
+}
+
+func (this *ApicurioRegistryReconciler) setPhase(spec *ar.ApicurioRegistry, phase ar.ApicurioRegistryPhase) error {
+	patchData, err := json.Marshal(map[string]interface{}{
+		"phase": phase,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := this.clients.CRD().PatchApicurioRegistryStatus(c.Namespace(spec.Namespace), c.Name(spec.Name), patchData); err != nil {
+		return err
+	}
+	spec.Status.Phase = phase
+	return nil
+}
+
 func (this *ApicurioRegistryReconciler) createNewLoop(appName c.Name, appNamespace c.Namespace, features *c.SupportedFeatures) loop.ControlLoop {
 
 	loopKey := appNamespace.Str() + "/" + appName.Str()
 	log := this.log.Sugar().With("contextId", loopKey)
 	log.Info("creating a new context")
 
-	ctx := context.NewLoopContext(appName, appNamespace, log.Desugar(), this.clients, this.testing, features)
+	ctx := context.NewLoopContext(appName, appNamespace, log.Desugar(), this.clients, this.testing, features, this.operatorConfig)
 	loopServices := services.NewLoopServices(ctx)
 	result := impl.NewControlLoopImpl(ctx, loopServices)
 
@@ -227,29 +410,45 @@ func (this *ApicurioRegistryReconciler) createNewLoop(appName c.Name, appNamespa
 	//deployment modifiers
 	result.AddControlFunction(cf.NewUpgradeCF(ctx))
 	result.AddControlFunction(cf.NewPodTemplateSpecCF(ctx, loopServices))
-	result.AddControlFunction(cf.NewAffinityCF(ctx))
+	result.AddControlFunction(cf.NewAffinityCF(ctx, loopServices))
+	result.AddControlFunction(cf.NewTopologySpreadConstraintsCF(ctx, loopServices))
 	result.AddControlFunction(cf.NewTolerationCF(ctx))
 	result.AddControlFunction(cf.NewAnnotationsCF(ctx))
+	result.AddControlFunction(cf.NewImageRollbackCF(ctx, loopServices))
 	result.AddControlFunction(cf.NewImageCF(ctx, loopServices))
 	result.AddControlFunction(cf.NewImagePullPolicyCF(ctx))
 	result.AddControlFunction(cf.NewImagePullSecretsCF(ctx))
 	result.AddControlFunction(cf.NewReplicasCF(ctx, loopServices))
+	result.AddControlFunction(cf.NewStrategyCF(ctx, loopServices))
 
 	//deployment env vars modifiers
 	result.AddControlFunction(cf.NewSqlCF(ctx))
-	result.AddControlFunction(kafkasql.NewKafkasqlCF(ctx))
+	if features.SupportsStrimzi {
+		result.AddControlFunction(kafkasql.NewKafkasqlStrimziCF(ctx, loopServices))
+	}
+	result.AddControlFunction(kafkasql.NewKafkasqlCF(ctx, loopServices))
+	result.AddControlFunction(kafkasql.NewKafkasqlPropertiesCF(ctx, loopServices))
 	result.AddControlFunction(kafkasql.NewKafkasqlSecurityScramCF(ctx))
 	result.AddControlFunction(kafkasql.NewKafkasqlSecurityTLSCF(ctx))
+	result.AddControlFunction(kafkasql.NewKafkasqlSecurityTLSPemCF(ctx, loopServices))
+	result.AddControlFunction(kafkasql.NewKafkasqlSecurityOAuthCF(ctx))
+	result.AddControlFunction(kafkasql.NewKafkasqlSecurityPlainCF(ctx))
 	result.AddControlFunction(cf.NewLogLevelCF(ctx))
 	result.AddControlFunction(cf.NewProfileCF(ctx))
 	result.AddControlFunction(cf.NewUICF(ctx))
 	result.AddControlFunction(cf.NewKeycloakCF(ctx))
 	result.AddControlFunction(cf.NewCorsCF(ctx))
+	result.AddControlFunction(cf.NewRegistryRulesCF(ctx, loopServices))
+	result.AddControlFunction(cf.NewRegistryLimitsCF(ctx, loopServices))
+	result.AddControlFunction(cf.NewRegistryAuthorizationCF(ctx, loopServices))
+	result.AddControlFunction(cf.NewRegistryUIFeaturesCF(ctx, loopServices))
 
 	//env vars from CR
 	result.AddControlFunction(cf.NewEnvCF(ctx))
 	//env vars applier
 	result.AddControlFunction(cf.NewEnvApplyCF(ctx))
+	//env vars from ConfigMaps and Secrets
+	result.AddControlFunction(cf.NewEnvFromCF(ctx, loopServices))
 
 	//depends on deployment
 	if features.SupportsPDBv1beta1 {
@@ -263,7 +462,9 @@ func (this *ApicurioRegistryReconciler) createNewLoop(appName c.Name, appNamespa
 	result.AddControlFunction(cf.NewServiceCF(ctx, loopServices))
 
 	// service modifiers
+	result.AddControlFunction(cf.NewHttpsCertificateCF(ctx, loopServices))
 	result.AddControlFunction(cf.NewHttpsCF(ctx, loopServices))
+	result.AddControlFunction(cf.NewHttpsClientAuthCF(ctx, loopServices))
 
 	// depends on service
 	if features.SupportsMonitoring {
@@ -274,19 +475,26 @@ func (this *ApicurioRegistryReconciler) createNewLoop(appName c.Name, appNamespa
 
 	// network policy
 	result.AddControlFunction(cf.NewNetworkPolicyCF(ctx, loopServices))
+	result.AddControlFunction(cf.NewNetworkPolicyRulesCF(ctx))
+
+	// depends on service and network policy
+	result.AddControlFunction(cf.NewServiceConfigCF(ctx, loopServices))
 
 	// ingress
 	result.AddControlFunction(cf.NewIngressCF(ctx, loopServices))
 
 	//dependents of ingress
 	if features.IsOCP {
+		result.AddControlFunction(cf.NewRouteOcpCF(ctx, loopServices))
 		result.AddControlFunction(cf.NewHostInitRouteOcpCF(ctx))
+	} else {
+		result.AddControlFunction(cf.NewHostCF(ctx, loopServices))
 	}
-	result.AddControlFunction(cf.NewHostCF(ctx, loopServices))
 
 	// Other / Dependent on everything :)
 	result.AddControlFunction(cf.NewLabelsCF(ctx, loopServices))
 	result.AddControlFunction(condition.NewAppHealthCF(ctx, loopServices))
+	result.AddControlFunction(condition.NewRolloutCF(ctx, loopServices))
 
 	return result
 }
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	go_ctx "context"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strings"
+)
+
+var _ reconcile.Reconciler = &ApicurioRegistryArtifactReconciler{}
+
+// Creates the artifact, or a new version of it when the content changes
+type ApicurioRegistryArtifactReconciler struct {
+	log     *zap.Logger
+	clients *client.Clients
+	content *registryContentSupport
+}
+
+func NewApicurioRegistryArtifactReconciler(mgr manager.Manager, rootLog *zap.Logger, clients *client.Clients, scope *NamespaceScope,
+	operatorConfig *c.OperatorConfig) (*ApicurioRegistryArtifactReconciler, error) {
+	log := rootLog.Named("artifact-controller")
+	result := &ApicurioRegistryArtifactReconciler{
+		log:     log,
+		clients: clients,
+		content: newRegistryContentSupport(log, clients, scope, operatorConfig),
+	}
+	controllerBuilder := cr.NewControllerManagedBy(mgr).
+		For(&ar.ApicurioRegistryArtifact{}, builder.WithPredicates(newContentPredicate()))
+	scope.Watch(controllerBuilder, &ar.ApicurioRegistryArtifactList{})
+	if err := controllerBuilder.Complete(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryartifacts,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryartifacts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryartifacts/finalizers,verbs=update
+
+func (this *ApicurioRegistryArtifactReconciler) Reconcile(_ go_ctx.Context, request reconcile.Request) (reconcile.Result, error) {
+	namespace := c.Namespace(request.Namespace)
+	artifact, err := this.clients.CRD().GetApicurioRegistryArtifact(namespace, c.Name(request.Name))
+	if err != nil || artifact == nil {
+		return reconcile.Result{}, err
+	}
+
+	groupId := artifact.Spec.GroupId
+	if groupId == "" {
+		groupId = client.REGISTRY_DEFAULT_GROUP
+	}
+	artifactId := artifact.Spec.ArtifactId
+	if artifactId == "" {
+		artifactId = artifact.Name
+	}
+	return this.content.reconcile(&registryContentResource{
+		object:   artifact,
+		registry: artifact.Spec.Registry,
+		status:   &artifact.Status.ApicurioRegistryContentStatus,
+		update: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryArtifact(namespace, artifact)
+			if err == nil {
+				*artifact = *res
+			}
+			return err
+		},
+		updateStatus: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryArtifactStatus(namespace, artifact)
+			if err == nil {
+				*artifact = *res
+			}
+			return err
+		},
+		sync: func(registryClient *client.RegistryClient) error {
+			content, err := this.getContent(namespace, artifact)
+			if err != nil {
+				return err
+			}
+			// Apicurio Registry returns the latest version if its content is the same,
+			// so the content is applied again every time, to correct the drift
+			ifExists := "RETURN_OR_UPDATE"
+			if artifact.Spec.VersionPolicy == ar.ArtifactVersionPolicyKeepExisting {
+				ifExists = "RETURN"
+			}
+			metaData, err := registryClient.CreateArtifact(groupId, artifactId, string(artifact.Spec.Type), artifact.Spec.Version,
+				ifExists, getArtifactContentType(artifact.Spec.Type, content), []byte(content))
+			if err != nil {
+				return err
+			}
+			if metaData.GlobalId != artifact.Status.GlobalId {
+				this.log.Sugar().Infow("artifact version has been applied", "namespace", namespace, "name", artifact.Name,
+					"groupId", groupId, "artifactId", artifactId, "version", metaData.Version, "globalId", metaData.GlobalId)
+			}
+			artifact.Status.GlobalId = metaData.GlobalId
+			artifact.Status.Version = metaData.Version
+			return nil
+		},
+		cleanup: func(registryClient *client.RegistryClient) error {
+			switch artifact.Spec.DeletionPolicy {
+			case ar.ArtifactDeletionPolicyRetain:
+				return nil
+			case ar.ArtifactDeletionPolicyDelete:
+				if err := registryClient.DeleteArtifact(groupId, artifactId); err != nil && !client.IsRegistryApiNotFound(err) {
+					return err
+				}
+				return nil
+			default:
+				versions, err := registryClient.GetArtifactVersions(groupId, artifactId)
+				if client.IsRegistryApiNotFound(err) {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				for _, version := range versions {
+					if err := registryClient.UpdateArtifactVersionState(groupId, artifactId, version, "DISABLED"); err != nil &&
+						!client.IsRegistryApiNotFound(err) {
+						return err
+					}
+				}
+				return nil
+			}
+		},
+	})
+}
+
+// Returns the inline content, or the content of the referenced ConfigMap key
+func (this *ApicurioRegistryArtifactReconciler) getContent(namespace c.Namespace, artifact *ar.ApicurioRegistryArtifact) (string, error) {
+	ref := artifact.Spec.Content.ConfigMapKeyRef
+	if ref == nil {
+		if artifact.Spec.Content.Value == "" {
+			return "", newContentError(CONTENT_CONDITION_REASON_INVALID, "content of the artifact is empty")
+		}
+		return artifact.Spec.Content.Value, nil
+	}
+	configMap, err := this.clients.Kube().GetConfigMap(namespace, c.Name(ref.Name), &meta.GetOptions{})
+	if err != nil {
+		return "", newContentError(CONTENT_CONDITION_REASON_INVALID, "could not get ConfigMap %s: %s", ref.Name, err.Error())
+	}
+	if value, exists := configMap.Data[ref.Key]; exists && value != "" {
+		return value, nil
+	}
+	if value, exists := configMap.BinaryData[ref.Key]; exists && len(value) > 0 {
+		return string(value), nil
+	}
+	return "", newContentError(CONTENT_CONDITION_REASON_INVALID, "ConfigMap %s does not contain the key %s", ref.Name, ref.Key)
+}
+
+// Returns the media type that Apicurio Registry expects for the content
+func getArtifactContentType(artifactType ar.ApicurioRegistryArtifactType, content string) string {
+	switch artifactType {
+	case ar.ArtifactTypeProtobuf:
+		return "application/x-protobuf"
+	case ar.ArtifactTypeGraphql:
+		return "application/graphql"
+	case ar.ArtifactTypeWsdl, ar.ArtifactTypeXsd, ar.ArtifactTypeXml:
+		return "application/xml"
+	}
+	// JSON based types can be provided as YAML as well
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return "application/json"
+	}
+	if artifactType == "" && strings.HasPrefix(trimmed, "<") {
+		return "application/xml"
+	}
+	if artifactType == ar.ArtifactTypeAvro || artifactType == ar.ArtifactTypeJson || artifactType == ar.ArtifactTypeKconnect {
+		return "application/json"
+	}
+	return "application/x-yaml"
+}
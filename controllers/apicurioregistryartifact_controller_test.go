@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	go_ctx "context"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"io"
+	core "k8s.io/api/core/v1"
+	api_meta "k8s.io/apimachinery/pkg/api/meta"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+	"time"
+)
+
+type artifactRequest struct {
+	method      string
+	uri         string
+	version     string
+	contentType string
+	body        string
+}
+
+// Apicurio Registry with a single artifact that has two versions, the responses can be replaced
+type artifactRegistryMock struct {
+	server    *httptest.Server
+	requests  []artifactRequest
+	responses map[string]int
+}
+
+func newArtifactRegistryMock(t *testing.T) *artifactRegistryMock {
+	this := &artifactRegistryMock{
+		requests:  make([]artifactRequest, 0),
+		responses: make(map[string]int),
+	}
+	this.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		c.AssertEquals(t, nil, err)
+		this.requests = append(this.requests, artifactRequest{
+			method:      r.Method,
+			uri:         r.URL.RequestURI(),
+			version:     r.Header.Get("X-Registry-Version"),
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		})
+		if status, exists := this.responses[r.Method+" "+r.URL.Path]; exists {
+			w.WriteHeader(status)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"groupId":"group","id":"artifact","version":"2","globalId":7,"state":"ENABLED"}`))
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"versions":[{"version":"1"},{"version":"2"}],"count":2}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(this.server.Close)
+	return this
+}
+
+func newTestArtifactReconciler(t *testing.T, registryMock *artifactRegistryMock, artifact *ar.ApicurioRegistryArtifact,
+	kubeObjects ...runtime.Object) (*ApicurioRegistryArtifactReconciler, *apiServerMock) {
+	registry, service := newTestRegistry("http")
+	content, apiServer := newTestRegistryContentSupport(t, registry, append(kubeObjects, service)...)
+	content.dialRegistry = dialServer(registryMock.server)
+	artifact.TypeMeta = meta.TypeMeta{APIVersion: ar.GroupVersion.String(), Kind: "ApicurioRegistryArtifact"}
+	artifact.Name = "artifact"
+	artifact.Namespace = testNamespace
+	artifact.Spec.Registry.Name = testRegistryName
+	apiServer.set("apicurioregistryartifacts", artifact.Name, artifact)
+	return &ApicurioRegistryArtifactReconciler{
+		log:     content.log,
+		clients: content.clients,
+		content: content,
+	}, apiServer
+}
+
+func reconcileArtifact(t *testing.T, this *ApicurioRegistryArtifactReconciler) *ar.ApicurioRegistryArtifact {
+	_, err := this.Reconcile(go_ctx.TODO(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "artifact"},
+	})
+	c.AssertEquals(t, nil, err)
+	artifact, err := this.clients.CRD().GetApicurioRegistryArtifact(testNamespace, "artifact")
+	c.AssertEquals(t, nil, err)
+	return artifact
+}
+
+func TestArtifactVersionPolicy(t *testing.T) {
+	tests := []struct {
+		policy           ar.ApicurioRegistryArtifactVersionPolicy
+		expectedIfExists string
+	}{
+		{policy: "", expectedIfExists: "RETURN_OR_UPDATE"},
+		{policy: ar.ArtifactVersionPolicyCreateVersion, expectedIfExists: "RETURN_OR_UPDATE"},
+		{policy: ar.ArtifactVersionPolicyKeepExisting, expectedIfExists: "RETURN"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			registryMock := newArtifactRegistryMock(t)
+			artifact := &ar.ApicurioRegistryArtifact{}
+			artifact.Spec.GroupId = "group"
+			artifact.Spec.Type = ar.ArtifactTypeAvro
+			artifact.Spec.Version = "2"
+			artifact.Spec.VersionPolicy = test.policy
+			artifact.Spec.Content.Value = `{"type":"string"}`
+			this, _ := newTestArtifactReconciler(t, registryMock, artifact)
+
+			artifact = reconcileArtifact(t, this)
+			c.AssertEquals(t, []artifactRequest{{
+				method:      http.MethodPost,
+				uri:         "/apis/registry/v2/groups/group/artifacts?ifExists=" + test.expectedIfExists,
+				version:     "2",
+				contentType: "application/json",
+				body:        `{"type":"string"}`,
+			}}, registryMock.requests)
+			c.AssertEquals(t, int64(7), artifact.Status.GlobalId)
+			c.AssertEquals(t, "2", artifact.Status.Version)
+			c.AssertEquals(t, true, api_meta.IsStatusConditionTrue(artifact.Status.Conditions, CONTENT_CONDITION_TYPE_SYNCED))
+			c.AssertEquals(t, []string{RegistryContentFinalizer}, artifact.Finalizers)
+		})
+	}
+}
+
+func TestArtifactConfigMapContent(t *testing.T) {
+	configMap := &core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: "schemas", Namespace: testNamespace},
+		Data: map[string]string{
+			"schema.yaml": "type: object",
+			"empty":       "",
+		},
+		BinaryData: map[string][]byte{
+			"schema.proto": []byte(`syntax = "proto3";`),
+		},
+	}
+	tests := []struct {
+		name                string
+		ref                 *core.ConfigMapKeySelector
+		expectedContentType string
+		expectedBody        string
+	}{
+		{name: "data", ref: &core.ConfigMapKeySelector{LocalObjectReference: core.LocalObjectReference{Name: "schemas"}, Key: "schema.yaml"},
+			expectedContentType: "application/x-yaml", expectedBody: "type: object"},
+		{name: "binary data", ref: &core.ConfigMapKeySelector{LocalObjectReference: core.LocalObjectReference{Name: "schemas"}, Key: "schema.proto"},
+			expectedContentType: "application/x-protobuf", expectedBody: `syntax = "proto3";`},
+		{name: "empty key", ref: &core.ConfigMapKeySelector{LocalObjectReference: core.LocalObjectReference{Name: "schemas"}, Key: "empty"}},
+		{name: "missing key", ref: &core.ConfigMapKeySelector{LocalObjectReference: core.LocalObjectReference{Name: "schemas"}, Key: "missing"}},
+		{name: "missing ConfigMap", ref: &core.ConfigMapKeySelector{LocalObjectReference: core.LocalObjectReference{Name: "missing"}, Key: "schema.yaml"}},
+		{name: "empty inline content"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registryMock := newArtifactRegistryMock(t)
+			artifact := &ar.ApicurioRegistryArtifact{}
+			if test.expectedContentType == "application/x-protobuf" {
+				artifact.Spec.Type = ar.ArtifactTypeProtobuf
+			}
+			artifact.Spec.Content.ConfigMapKeyRef = test.ref
+			this, _ := newTestArtifactReconciler(t, registryMock, artifact, configMap.DeepCopy())
+
+			artifact = reconcileArtifact(t, this)
+			condition := api_meta.FindStatusCondition(artifact.Status.Conditions, CONTENT_CONDITION_TYPE_SYNCED)
+			if test.expectedBody == "" {
+				// The content is not sent to Apicurio Registry
+				c.AssertEquals(t, 0, len(registryMock.requests))
+				c.AssertEquals(t, meta.ConditionFalse, condition.Status)
+				c.AssertEquals(t, string(CONTENT_CONDITION_REASON_INVALID), condition.Reason)
+				return
+			}
+			c.AssertEquals(t, 1, len(registryMock.requests))
+			c.AssertEquals(t, "/apis/registry/v2/groups/default/artifacts?ifExists=RETURN_OR_UPDATE", registryMock.requests[0].uri)
+			c.AssertEquals(t, test.expectedContentType, registryMock.requests[0].contentType)
+			c.AssertEquals(t, test.expectedBody, registryMock.requests[0].body)
+			c.AssertEquals(t, meta.ConditionTrue, condition.Status)
+		})
+	}
+}
+
+func TestArtifactDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           ar.ApicurioRegistryArtifactDeletionPolicy
+		responses        map[string]int
+		expectedRequests []string
+		removed          bool
+	}{
+		{name: "default", policy: "", expectedRequests: []string{
+			"GET /apis/registry/v2/groups/default/artifacts/artifact/versions?limit=100&offset=0",
+			"PUT /apis/registry/v2/groups/default/artifacts/artifact/versions/1/state",
+			"PUT /apis/registry/v2/groups/default/artifacts/artifact/versions/2/state",
+		}, removed: true},
+		{name: "disable not found", policy: ar.ArtifactDeletionPolicyDisable, responses: map[string]int{
+			"GET /apis/registry/v2/groups/default/artifacts/artifact/versions": http.StatusNotFound,
+		}, expectedRequests: []string{
+			"GET /apis/registry/v2/groups/default/artifacts/artifact/versions?limit=100&offset=0",
+		}, removed: true},
+		{name: "disable failed", policy: ar.ArtifactDeletionPolicyDisable, responses: map[string]int{
+			"PUT /apis/registry/v2/groups/default/artifacts/artifact/versions/1/state": http.StatusInternalServerError,
+		}, expectedRequests: []string{
+			"GET /apis/registry/v2/groups/default/artifacts/artifact/versions?limit=100&offset=0",
+			"PUT /apis/registry/v2/groups/default/artifacts/artifact/versions/1/state",
+		}, removed: false},
+		{name: "delete", policy: ar.ArtifactDeletionPolicyDelete, expectedRequests: []string{
+			"DELETE /apis/registry/v2/groups/default/artifacts/artifact",
+		}, removed: true},
+		{name: "delete not found", policy: ar.ArtifactDeletionPolicyDelete, responses: map[string]int{
+			"DELETE /apis/registry/v2/groups/default/artifacts/artifact": http.StatusNotFound,
+		}, expectedRequests: []string{
+			"DELETE /apis/registry/v2/groups/default/artifacts/artifact",
+		}, removed: true},
+		{name: "delete failed", policy: ar.ArtifactDeletionPolicyDelete, responses: map[string]int{
+			"DELETE /apis/registry/v2/groups/default/artifacts/artifact": http.StatusInternalServerError,
+		}, expectedRequests: []string{
+			"DELETE /apis/registry/v2/groups/default/artifacts/artifact",
+		}, removed: false},
+		{name: "retain", policy: ar.ArtifactDeletionPolicyRetain, expectedRequests: []string{}, removed: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registryMock := newArtifactRegistryMock(t)
+			if test.responses != nil {
+				registryMock.responses = test.responses
+			}
+			now := meta.NewTime(time.Now())
+			artifact := &ar.ApicurioRegistryArtifact{}
+			artifact.DeletionTimestamp = &now
+			artifact.Finalizers = []string{RegistryContentFinalizer}
+			artifact.Spec.DeletionPolicy = test.policy
+			artifact.Spec.Content.Value = `{"type":"string"}`
+			this, _ := newTestArtifactReconciler(t, registryMock, artifact)
+
+			artifact = reconcileArtifact(t, this)
+			requests := make([]string, 0)
+			for _, req := range registryMock.requests {
+				requests = append(requests, req.method+" "+req.uri)
+				if req.method == http.MethodPut {
+					c.AssertEquals(t, `{"state":"DISABLED"}`, req.body)
+				}
+			}
+			c.AssertEquals(t, test.expectedRequests, requests)
+			c.AssertEquals(t, test.removed, len(artifact.Finalizers) == 0)
+			if !test.removed {
+				condition := api_meta.FindStatusCondition(artifact.Status.Conditions, CONTENT_CONDITION_TYPE_SYNCED)
+				c.AssertEquals(t, string(CONTENT_CONDITION_REASON_CLEANUP_FAILED), condition.Reason)
+			}
+		})
+	}
+}
+
+func TestGetArtifactContentType(t *testing.T) {
+	c.AssertEquals(t, "application/json", getArtifactContentType(ar.ArtifactTypeAvro, `  {"type":"string"}`))
+	c.AssertEquals(t, "application/x-yaml", getArtifactContentType(ar.ArtifactTypeOpenapi, "openapi: 3.0.0"))
+	c.AssertEquals(t, "application/x-yaml", getArtifactContentType("", "openapi: 3.0.0"))
+	c.AssertEquals(t, "application/xml", getArtifactContentType("", "<xs:schema/>"))
+	c.AssertEquals(t, "application/xml", getArtifactContentType(ar.ArtifactTypeWsdl, "<definitions/>"))
+	c.AssertEquals(t, "application/graphql", getArtifactContentType(ar.ArtifactTypeGraphql, "type Query {}"))
+}
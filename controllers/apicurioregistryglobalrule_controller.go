@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	go_ctx "context"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	cr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ reconcile.Reconciler = &ApicurioRegistryGlobalRuleReconciler{}
+
+// Configures the global rules in Apicurio Registry
+type ApicurioRegistryGlobalRuleReconciler struct {
+	log     *zap.Logger
+	clients *client.Clients
+	content *registryContentSupport
+}
+
+func NewApicurioRegistryGlobalRuleReconciler(mgr manager.Manager, rootLog *zap.Logger, clients *client.Clients, scope *NamespaceScope,
+	operatorConfig *c.OperatorConfig) (*ApicurioRegistryGlobalRuleReconciler, error) {
+	log := rootLog.Named("globalrule-controller")
+	result := &ApicurioRegistryGlobalRuleReconciler{
+		log:     log,
+		clients: clients,
+		content: newRegistryContentSupport(log, clients, scope, operatorConfig),
+	}
+	controllerBuilder := cr.NewControllerManagedBy(mgr).
+		For(&ar.ApicurioRegistryGlobalRule{}, builder.WithPredicates(newContentPredicate()))
+	scope.Watch(controllerBuilder, &ar.ApicurioRegistryGlobalRuleList{})
+	if err := controllerBuilder.Complete(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryglobalrules,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryglobalrules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryglobalrules/finalizers,verbs=update
+
+func (this *ApicurioRegistryGlobalRuleReconciler) Reconcile(_ go_ctx.Context, request reconcile.Request) (reconcile.Result, error) {
+	namespace := c.Namespace(request.Namespace)
+	rule, err := this.clients.CRD().GetApicurioRegistryGlobalRule(namespace, c.Name(request.Name))
+	if err != nil || rule == nil {
+		return reconcile.Result{}, err
+	}
+
+	target := &client.RegistryRule{
+		Type:   string(rule.Spec.Type),
+		Config: rule.Spec.Config,
+	}
+	return this.content.reconcile(&registryContentResource{
+		object:   rule,
+		registry: rule.Spec.Registry,
+		status:   &rule.Status,
+		update: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryGlobalRule(namespace, rule)
+			if err == nil {
+				*rule = *res
+			}
+			return err
+		},
+		updateStatus: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryGlobalRuleStatus(namespace, rule)
+			if err == nil {
+				*rule = *res
+			}
+			return err
+		},
+		sync: func(registryClient *client.RegistryClient) error {
+			if err := validateRule(rule.Spec.ApicurioRegistryRule); err != nil {
+				return err
+			}
+			changed, err := syncRule(
+				func() (*client.RegistryRule, error) { return registryClient.GetGlobalRule(target.Type) },
+				func() error { return registryClient.CreateGlobalRule(target) },
+				func() error { return registryClient.UpdateGlobalRule(target) },
+				target)
+			if changed && err == nil {
+				this.log.Sugar().Infow("global rule has been configured", "namespace", namespace, "name", rule.Name,
+					"type", target.Type, "config", target.Config)
+			}
+			return err
+		},
+		cleanup: func(registryClient *client.RegistryClient) error {
+			if err := registryClient.DeleteGlobalRule(target.Type); err != nil && !client.IsRegistryApiNotFound(err) {
+				return err
+			}
+			return nil
+		},
+	})
+}
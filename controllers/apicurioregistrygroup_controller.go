@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	go_ctx "context"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	cr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ reconcile.Reconciler = &ApicurioRegistryGroupReconciler{}
+
+// Applies the group rules to the artifacts in the group.
+// Apicurio Registry 2.x does not support rules on the group level, so they are configured for each artifact.
+type ApicurioRegistryGroupReconciler struct {
+	log     *zap.Logger
+	clients *client.Clients
+	content *registryContentSupport
+}
+
+func NewApicurioRegistryGroupReconciler(mgr manager.Manager, rootLog *zap.Logger, clients *client.Clients, scope *NamespaceScope,
+	operatorConfig *c.OperatorConfig) (*ApicurioRegistryGroupReconciler, error) {
+	log := rootLog.Named("group-controller")
+	result := &ApicurioRegistryGroupReconciler{
+		log:     log,
+		clients: clients,
+		content: newRegistryContentSupport(log, clients, scope, operatorConfig),
+	}
+	controllerBuilder := cr.NewControllerManagedBy(mgr).
+		For(&ar.ApicurioRegistryGroup{}, builder.WithPredicates(newContentPredicate()))
+	scope.Watch(controllerBuilder, &ar.ApicurioRegistryGroupList{})
+	if err := controllerBuilder.Complete(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistrygroups,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistrygroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistrygroups/finalizers,verbs=update
+
+func (this *ApicurioRegistryGroupReconciler) Reconcile(_ go_ctx.Context, request reconcile.Request) (reconcile.Result, error) {
+	namespace := c.Namespace(request.Namespace)
+	group, err := this.clients.CRD().GetApicurioRegistryGroup(namespace, c.Name(request.Name))
+	if err != nil || group == nil {
+		return reconcile.Result{}, err
+	}
+
+	groupId := group.Spec.GroupId
+	if groupId == "" {
+		groupId = group.Name
+	}
+	return this.content.reconcile(&registryContentResource{
+		object:   group,
+		registry: group.Spec.Registry,
+		status:   &group.Status.ApicurioRegistryContentStatus,
+		update: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryGroup(namespace, group)
+			if err == nil {
+				*group = *res
+			}
+			return err
+		},
+		updateStatus: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryGroupStatus(namespace, group)
+			if err == nil {
+				*group = *res
+			}
+			return err
+		},
+		sync: func(registryClient *client.RegistryClient) error {
+			types := make(map[ar.ApicurioRegistryRuleType]bool, len(group.Spec.Rules))
+			for _, rule := range group.Spec.Rules {
+				if err := validateRule(rule); err != nil {
+					return err
+				}
+				if types[rule.Type] {
+					return newContentError(CONTENT_CONDITION_REASON_INVALID, "there are multiple %s rules", rule.Type)
+				}
+				types[rule.Type] = true
+			}
+			// Rules that have been removed from the spec
+			removed := make([]ar.ApicurioRegistryRule, 0)
+			for _, rule := range group.Status.AppliedRules {
+				if !types[rule.Type] {
+					removed = append(removed, rule)
+				}
+			}
+			artifactIds, err := registryClient.GetArtifactIds(groupId)
+			if client.IsRegistryApiNotFound(err) {
+				artifactIds = []string{}
+			} else if err != nil {
+				return err
+			}
+			for _, artifactId := range artifactIds {
+				for _, rule := range group.Spec.Rules {
+					target := &client.RegistryRule{
+						Type:   string(rule.Type),
+						Config: rule.Config,
+					}
+					changed, err := syncRule(
+						func() (*client.RegistryRule, error) {
+							return registryClient.GetArtifactRule(groupId, artifactId, target.Type)
+						},
+						func() error { return registryClient.CreateArtifactRule(groupId, artifactId, target) },
+						func() error { return registryClient.UpdateArtifactRule(groupId, artifactId, target) },
+						target)
+					if err != nil {
+						return err
+					}
+					if changed {
+						this.log.Sugar().Infow("artifact rule has been configured", "namespace", namespace, "name", group.Name,
+							"groupId", groupId, "artifactId", artifactId, "type", target.Type, "config", target.Config)
+					}
+				}
+				if err := this.deleteRules(registryClient, groupId, artifactId, removed); err != nil {
+					return err
+				}
+			}
+			group.Status.AppliedRules = group.Spec.Rules
+			return nil
+		},
+		cleanup: func(registryClient *client.RegistryClient) error {
+			artifactIds, err := registryClient.GetArtifactIds(groupId)
+			if client.IsRegistryApiNotFound(err) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			for _, artifactId := range artifactIds {
+				if err := this.deleteRules(registryClient, groupId, artifactId, group.Status.AppliedRules); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// Deletes the artifact rules, unless they have been changed in Apicurio Registry
+func (this *ApicurioRegistryGroupReconciler) deleteRules(registryClient *client.RegistryClient, groupId string, artifactId string, rules []ar.ApicurioRegistryRule) error {
+	for _, rule := range rules {
+		existing, err := registryClient.GetArtifactRule(groupId, artifactId, string(rule.Type))
+		if client.IsRegistryApiNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if existing.Config == rule.Config {
+			if err := registryClient.DeleteArtifactRule(groupId, artifactId, string(rule.Type)); err != nil && !client.IsRegistryApiNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	go_ctx "context"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	cr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ reconcile.Reconciler = &ApicurioRegistryRoleMappingReconciler{}
+
+// Configures the role mappings in Apicurio Registry
+type ApicurioRegistryRoleMappingReconciler struct {
+	log     *zap.Logger
+	clients *client.Clients
+	content *registryContentSupport
+}
+
+func NewApicurioRegistryRoleMappingReconciler(mgr manager.Manager, rootLog *zap.Logger, clients *client.Clients, scope *NamespaceScope,
+	operatorConfig *c.OperatorConfig) (*ApicurioRegistryRoleMappingReconciler, error) {
+	log := rootLog.Named("rolemapping-controller")
+	result := &ApicurioRegistryRoleMappingReconciler{
+		log:     log,
+		clients: clients,
+		content: newRegistryContentSupport(log, clients, scope, operatorConfig),
+	}
+	controllerBuilder := cr.NewControllerManagedBy(mgr).
+		For(&ar.ApicurioRegistryRoleMapping{}, builder.WithPredicates(newContentPredicate()))
+	scope.Watch(controllerBuilder, &ar.ApicurioRegistryRoleMappingList{})
+	if err := controllerBuilder.Complete(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryrolemappings,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryrolemappings/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=registry.apicur.io,resources=apicurioregistryrolemappings/finalizers,verbs=update
+
+func (this *ApicurioRegistryRoleMappingReconciler) Reconcile(_ go_ctx.Context, request reconcile.Request) (reconcile.Result, error) {
+	namespace := c.Namespace(request.Namespace)
+	mapping, err := this.clients.CRD().GetApicurioRegistryRoleMapping(namespace, c.Name(request.Name))
+	if err != nil || mapping == nil {
+		return reconcile.Result{}, err
+	}
+
+	target := &client.RegistryRoleMapping{
+		PrincipalId:   mapping.Spec.PrincipalId,
+		Role:          string(mapping.Spec.Role),
+		PrincipalName: mapping.Spec.PrincipalName,
+	}
+	return this.content.reconcile(&registryContentResource{
+		object:   mapping,
+		registry: mapping.Spec.Registry,
+		status:   &mapping.Status,
+		update: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryRoleMapping(namespace, mapping)
+			if err == nil {
+				*mapping = *res
+			}
+			return err
+		},
+		updateStatus: func() error {
+			res, err := this.clients.CRD().UpdateApicurioRegistryRoleMappingStatus(namespace, mapping)
+			if err == nil {
+				*mapping = *res
+			}
+			return err
+		},
+		sync: func(registryClient *client.RegistryClient) error {
+			existing, err := registryClient.GetRoleMapping(target.PrincipalId)
+			if client.IsRegistryApiNotFound(err) {
+				err = registryClient.CreateRoleMapping(target)
+			} else if err == nil && existing.Role != target.Role {
+				// The principal name can not be updated
+				err = registryClient.UpdateRoleMapping(target)
+			} else {
+				return err
+			}
+			if err == nil {
+				this.log.Sugar().Infow("role mapping has been configured", "namespace", namespace, "name", mapping.Name,
+					"principalId", target.PrincipalId, "role", target.Role)
+			}
+			return err
+		},
+		cleanup: func(registryClient *client.RegistryClient) error {
+			if err := registryClient.DeleteRoleMapping(target.PrincipalId); err != nil && !client.IsRegistryApiNotFound(err) {
+				return err
+			}
+			return nil
+		},
+	})
+}
@@ -5,9 +5,11 @@ import (
 
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -17,16 +19,18 @@ var _ loop.ControlFunction = &AffinityCF{}
 type AffinityCF struct {
 	ctx                   context.LoopContext
 	svcResourceCache      resources.ResourceCache
+	svcKubeFactory        *factory.KubeFactory
 	deploymentEntry       resources.ResourceCacheEntry
 	deploymentEntryExists bool
 	existingAffinity      *corev1.Affinity
 	targetAffinity        *corev1.Affinity
 }
 
-func NewAffinityCF(ctx context.LoopContext) loop.ControlFunction {
+func NewAffinityCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
 	return &AffinityCF{
 		ctx:                   ctx,
 		svcResourceCache:      ctx.GetResourceCache(),
+		svcKubeFactory:        services.GetKubeFactory(),
 		deploymentEntry:       nil,
 		deploymentEntryExists: false,
 		existingAffinity:      nil,
@@ -49,9 +53,10 @@ func (this *AffinityCF) Sense() {
 		this.existingAffinity = this.deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Spec.Affinity
 
 		// Observation #3
-		// Get the target affinity
+		// Get the target affinity, including the high availability preset
 		if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
-			this.targetAffinity = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Affinity
+			spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+			this.targetAffinity = NewHighAvailabilityAffinity(&spec, this.svcKubeFactory.GetSelectorLabels())
 		}
 	}
 }
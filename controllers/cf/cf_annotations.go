@@ -1,7 +1,7 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
@@ -46,9 +46,11 @@ func (this *AnnotationsCF) Sense() {
 		this.existingAnnotations = this.deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Annotations
 
 		// Observation #3
-		// Get the target pod annotations
+		// Get the target pod annotations, the spec overrides the Operator configuration
 		if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
-			this.targetAnnotations = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Metadata.Annotations
+			this.targetAnnotations = make(map[string]string)
+			common.LabelsUpdate(&this.targetAnnotations, this.ctx.GetOperatorConfig().Get().DefaultPodAnnotations)
+			common.LabelsUpdate(&this.targetAnnotations, specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Metadata.Annotations)
 		}
 	}
 }
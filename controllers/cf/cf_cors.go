@@ -1,7 +1,7 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
@@ -42,9 +42,17 @@ func (this *CorsCF) Sense() {
 	this.targetCors = ""
 	this.overriddenCors = ""
 	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
-		host := specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Host
-		if host != "" {
-			this.targetCors = "http://" + host + "," + "https://" + host
+		hosts := specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Hosts
+		if hosts.Primary != "" {
+			for _, host := range append([]string{hosts.Primary}, hosts.Additional...) {
+				if host == "" {
+					continue
+				}
+				if this.targetCors != "" {
+					this.targetCors = this.targetCors + ","
+				}
+				this.targetCors = this.targetCors + "http://" + host + "," + "https://" + host
+			}
 		}
 		keycloak := specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Configuration.Security.Keycloak.Url
 		if keycloak != "" {
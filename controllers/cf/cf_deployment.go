@@ -1,6 +1,7 @@
 package cf
 
 import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
@@ -11,11 +12,11 @@ import (
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
 	"go.uber.org/zap"
 	apps "k8s.io/api/apps/v1"
-	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ loop.ControlFunction = &DeploymentCF{}
+var _ loop.CleanupDiscovery = &DeploymentCF{}
 
 type DeploymentCF struct {
 	ctx              context.LoopContext
@@ -124,14 +125,34 @@ func (this *DeploymentCF) Cleanup() bool {
 		// Delete the service first
 		return false
 	}
-	if deploymentEntry, deploymentExists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT); deploymentExists {
-		if err := this.svcClients.Kube().DeleteDeployment(deploymentEntry.GetValue().(*apps.Deployment)); err != nil && !api_errors.IsNotFound(err) {
-			this.log.Errorw("could not delete deployment during cleanup", "error", err)
-			return false
-		} else {
-			this.svcResourceCache.Remove(resources.RC_KEY_DEPLOYMENT)
-			this.ctx.GetLog().Info("Deployment has been deleted.")
+	return cleanupCachedResource(this.ctx, this.log, resources.RC_KEY_DEPLOYMENT, ar.ManagedResourceKindDeployment,
+		func(value interface{}) error {
+			_, err := this.svcClients.Kube().UpdateDeployment(this.ctx.GetAppNamespace(), value.(*apps.Deployment))
+			return err
+		},
+		func(value interface{}) error {
+			return this.svcClients.Kube().DeleteDeployment(value.(*apps.Deployment))
+		})
+}
+
+func (this *DeploymentCF) DiscoverForCleanup() {
+	if _, exists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT); exists {
+		return
+	}
+	deployments, err := this.svcClients.Kube().GetDeployments(
+		this.ctx.GetAppNamespace(),
+		meta.ListOptions{
+			LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+		})
+	if err != nil {
+		this.log.Errorw("could not list Deployments during cleanup", "error", err)
+		return
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.GetDeletionTimestamp() == nil && IsOwnedByApp(this.ctx, deployment) {
+			this.svcResourceCache.Set(resources.RC_KEY_DEPLOYMENT, resources.NewResourceCacheEntry(common.Name(deployment.Name), deployment))
+			return
 		}
 	}
-	return true
 }
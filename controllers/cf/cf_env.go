@@ -1,11 +1,12 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"reflect"
@@ -18,7 +19,8 @@ type EnvCF struct {
 	log              *zap.SugaredLogger
 	svcResourceCache resources.ResourceCache
 	svcEnvCache      env.EnvCache
-	// To know which were deleted, we need to compare with previous ones
+	// To know which were deleted, we need to compare with previous ones,
+	// they are persisted so the comparison works after the Operator has been restarted
 	previousTargetEnv []corev1.EnvVar
 	targetEnv         []corev1.EnvVar
 	remove            map[string]corev1.EnvVar
@@ -53,6 +55,9 @@ func (this *EnvCF) Sense() {
 
 	this.targetEnv = make([]corev1.EnvVar, 0)
 
+	this.previousTargetEnv = make([]corev1.EnvVar, 0)
+	this.ctx.GetState().Get(state.STATE_KEY_ENV_PREVIOUS_TARGET, &this.previousTargetEnv)
+
 	// Spec resource must be available
 	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
 		envConfig := specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Configuration.Env
@@ -119,6 +124,7 @@ func (this *EnvCF) Respond() {
 	}
 
 	this.previousTargetEnv = this.targetEnv
+	this.ctx.GetState().Set(state.STATE_KEY_ENV_PREVIOUS_TARGET, this.previousTargetEnv)
 	this.log.Debugw("env cache after", "value", this.svcEnvCache.GetSorted())
 }
 
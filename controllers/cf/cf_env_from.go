@@ -0,0 +1,188 @@
+package cf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+)
+
+// Hash of the data in the envFrom sources, so the pods are restarted when the data changes
+const EnvFromHashAnnotation = "apicur.io/env-from-hash"
+
+// ConfigMaps and Secrets are not watched, check them periodically
+const envFromCheckDelaySec = 60
+
+var _ loop.ControlFunction = &EnvFromCF{}
+
+// This CF applies spec.configuration.envFrom to the registry container.
+// The envFrom sources from spec.deployment.podTemplateSpec are applied by PodTemplateSpecCF, so they are kept as well.
+type EnvFromCF struct {
+	ctx              context.LoopContext
+	log              *zap.SugaredLogger
+	services         services.LoopServices
+	svcResourceCache resources.ResourceCache
+	deploymentEntry  resources.ResourceCacheEntry
+	existingEnvFrom  []core.EnvFromSource
+	existingHash     string
+	targetEnvFrom    []core.EnvFromSource
+	targetHash       string
+}
+
+func NewEnvFromCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &EnvFromCF{
+		ctx:              ctx,
+		services:         services,
+		svcResourceCache: ctx.GetResourceCache(),
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *EnvFromCF) Describe() string {
+	return "EnvFromCF"
+}
+
+func (this *EnvFromCF) Sense() {
+	// Observation #1
+	// Get the existing envFrom sources and the hash
+	this.deploymentEntry = nil
+	this.existingEnvFrom = nil
+	this.existingHash = ""
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT); exists {
+		deployment := entry.GetValue().(*apps.Deployment)
+		if container := common.GetContainerByName(deployment.Spec.Template.Spec.Containers, factory.REGISTRY_CONTAINER_NAME); container != nil {
+			this.deploymentEntry = entry
+			this.existingEnvFrom = container.EnvFrom
+			this.existingHash = deployment.Spec.Template.Annotations[EnvFromHashAnnotation]
+		}
+	}
+
+	// Observation #2
+	// Get the target envFrom sources
+	this.targetEnvFrom = nil
+	var configEnvFrom []core.EnvFromSource
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		if container := common.GetContainerByName(spec.Deployment.PodTemplateSpec.Spec.Containers, factory.REGISTRY_CONTAINER_NAME); container != nil {
+			this.targetEnvFrom = append(this.targetEnvFrom, container.EnvFrom...)
+		}
+		configEnvFrom = spec.Configuration.EnvFrom
+		this.targetEnvFrom = append(this.targetEnvFrom, configEnvFrom...)
+	}
+
+	// Observation #3
+	// Compute the hash of the data in the sources
+	this.targetHash = ""
+	if len(configEnvFrom) > 0 {
+		if data, ok := this.readSources(configEnvFrom); ok {
+			hash := sha256.Sum256(data)
+			this.targetHash = hex.EncodeToString(hash[:])
+		} else {
+			// Keep the previous value until the sources are available
+			this.targetHash = this.existingHash
+		}
+		this.ctx.SetRequeueDelaySec(envFromCheckDelaySec)
+	}
+}
+
+func (this *EnvFromCF) Compare() bool {
+	// Condition #1
+	// Deployment exists
+	// Condition #2
+	// Existing envFrom sources or the hash are different from the target
+	return this.deploymentEntry != nil &&
+		(!reflect.DeepEqual(this.existingEnvFrom, this.targetEnvFrom) || this.existingHash != this.targetHash)
+}
+
+func (this *EnvFromCF) Respond() {
+	// Response #1
+	// Patch the resource
+	this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+		deployment := value.(*apps.Deployment).DeepCopy()
+		container := common.GetContainerByName(deployment.Spec.Template.Spec.Containers, factory.REGISTRY_CONTAINER_NAME)
+		container.EnvFrom = this.targetEnvFrom
+		if this.targetHash != "" {
+			common.LabelsUpdate(&deployment.Spec.Template.Annotations, map[string]string{
+				EnvFromHashAnnotation: this.targetHash,
+			})
+		} else {
+			delete(deployment.Spec.Template.Annotations, EnvFromHashAnnotation)
+		}
+		return deployment
+	})
+}
+
+func (this *EnvFromCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
+
+// Returns the serialized data of the sources, or false if a required source could not be read
+func (this *EnvFromCF) readSources(sources []core.EnvFromSource) ([]byte, bool) {
+	type sourceData struct {
+		Prefix string
+		Kind   string
+		Name   string
+		Data   map[string][]byte
+	}
+	res := make([]sourceData, 0)
+	for _, source := range sources {
+		if source.ConfigMapRef != nil {
+			configMap, err := this.ctx.GetClients().Kube().GetConfigMap(this.ctx.GetAppNamespace(), common.Name(source.ConfigMapRef.Name), &meta.GetOptions{})
+			if err == nil {
+				data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+				for k, v := range configMap.Data {
+					data[k] = []byte(v)
+				}
+				for k, v := range configMap.BinaryData {
+					data[k] = v
+				}
+				res = append(res, sourceData{source.Prefix, "ConfigMap", configMap.Name, data})
+			} else if !api_errors.IsNotFound(err) || !isOptional(source.ConfigMapRef.Optional) {
+				this.reportSourceError("ConfigMap", source.ConfigMapRef.Name, err)
+				return nil, false
+			}
+		}
+		if source.SecretRef != nil {
+			secret, err := this.ctx.GetClients().Kube().GetSecret(this.ctx.GetAppNamespace(), common.Name(source.SecretRef.Name), &meta.GetOptions{})
+			if err == nil {
+				res = append(res, sourceData{source.Prefix, "Secret", secret.Name, secret.Data})
+			} else if !api_errors.IsNotFound(err) || !isOptional(source.SecretRef.Optional) {
+				this.reportSourceError("Secret", source.SecretRef.Name, err)
+				return nil, false
+			}
+		}
+	}
+	// Map keys are sorted, so the result is stable
+	data, err := json.Marshal(res)
+	if err != nil {
+		this.log.Errorw("could not serialize the envFrom sources", "error", err)
+		return nil, false
+	}
+	return data, true
+}
+
+func (this *EnvFromCF) reportSourceError(kind string, name string, err error) {
+	this.log.Warnw("could not read the envFrom source", "kind", kind, "name", name, "error", err)
+	if api_errors.IsNotFound(err) {
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(kind+" "+name+" not found", "spec.configuration.envFrom")
+	}
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
@@ -0,0 +1,124 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func newConfigMapEnvFrom(name string, optional bool) core.EnvFromSource {
+	return core.EnvFromSource{ConfigMapRef: &core.ConfigMapEnvSource{
+		LocalObjectReference: core.LocalObjectReference{Name: name},
+		Optional:             &optional,
+	}}
+}
+
+func newSecretEnvFrom(name string, optional bool) core.EnvFromSource {
+	return core.EnvFromSource{SecretRef: &core.SecretEnvSource{
+		LocalObjectReference: core.LocalObjectReference{Name: name},
+		Optional:             &optional,
+	}}
+}
+
+func TestEnvFromCF(t *testing.T) {
+	podTemplateEnvFrom := newConfigMapEnvFrom("pod-template", false)
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Deployment.PodTemplateSpec.Spec.Containers = []core.Container{{
+		Name:    factory.REGISTRY_CONTAINER_NAME,
+		EnvFrom: []core.EnvFromSource{podTemplateEnvFrom},
+	}}
+	spec.Spec.Configuration.EnvFrom = []core.EnvFromSource{
+		newConfigMapEnvFrom("config", false),
+		newSecretEnvFrom("credentials", false),
+	}
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	kube := ctx.GetClients().Kube()
+	configMap, err := kube.CreateConfigMap(spec, ctx.GetAppNamespace(), &core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: "config", Namespace: ctx.GetAppNamespace().Str()},
+		Data:       map[string]string{"LOG_LEVEL": "INFO"},
+	})
+	c.AssertEquals(t, nil, err)
+	secret, err := kube.CreateSecret(spec, ctx.GetAppNamespace(), &core.Secret{
+		ObjectMeta: meta.ObjectMeta{Name: "credentials", Namespace: ctx.GetAppNamespace().Str()},
+		Data:       map[string][]byte{"PASSWORD": []byte("secret")},
+	})
+	c.AssertEquals(t, nil, err)
+	deploymentEntry := resources.NewResourceCacheEntry(ctx.GetAppName(), services.GetKubeFactory().CreateDeployment())
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, deploymentEntry)
+	this := NewEnvFromCF(ctx, services)
+	container := func() *core.Container {
+		return c.GetContainerByName(deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Spec.Containers, factory.REGISTRY_CONTAINER_NAME)
+	}
+	hash := func() string {
+		return deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Annotations[EnvFromHashAnnotation]
+	}
+
+	// The sources from the pod template are kept, and ordered before the configuration sources
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, append([]core.EnvFromSource{podTemplateEnvFrom}, spec.Spec.Configuration.EnvFrom...), container().EnvFrom)
+	c.AssertEquals(t, true, hash() != "")
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The hash changes when the data of the ConfigMap changes
+	previousHash := hash()
+	configMap.Data["LOG_LEVEL"] = "DEBUG"
+	_, err = kube.UpdateConfigMap(ctx.GetAppNamespace(), configMap)
+	c.AssertEquals(t, nil, err)
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, true, hash() != previousHash)
+
+	// The hash changes when the data of the Secret changes
+	previousHash = hash()
+	secret.Data["PASSWORD"] = []byte("changed")
+	_, err = kube.UpdateSecret(ctx.GetAppNamespace(), secret)
+	c.AssertEquals(t, nil, err)
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, true, hash() != previousHash)
+
+	// The hash is kept while a required source is missing, and the error is reported
+	previousHash = hash()
+	c.AssertEquals(t, false, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+	c.AssertEquals(t, nil, kube.DeleteSecret(secret, &meta.DeleteOptions{}))
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+	c.AssertEquals(t, previousHash, hash())
+	c.AssertEquals(t, true, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+}
+
+func TestEnvFromCFOptionalSource(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Configuration.EnvFrom = []core.EnvFromSource{
+		newConfigMapEnvFrom("optional-config", true),
+		newSecretEnvFrom("optional-credentials", true),
+	}
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	deploymentEntry := resources.NewResourceCacheEntry(ctx.GetAppName(), services.GetKubeFactory().CreateDeployment())
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, deploymentEntry)
+	this := NewEnvFromCF(ctx, services)
+
+	// The missing sources are tolerated
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	deployment := deploymentEntry.GetValue().(*apps.Deployment)
+	c.AssertEquals(t, spec.Spec.Configuration.EnvFrom, deployment.Spec.Template.Spec.Containers[0].EnvFrom)
+	c.AssertEquals(t, true, deployment.Spec.Template.Annotations[EnvFromHashAnnotation] != "")
+	c.AssertEquals(t, false, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+}
@@ -1,13 +1,14 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
 	networking "k8s.io/api/networking/v1"
+	"reflect"
 )
 
 var _ loop.ControlFunction = &HostCF{}
@@ -19,8 +20,8 @@ type HostCF struct {
 	ingressEntry     resources.ResourceCacheEntry
 	ingressExists    bool
 	serviceName      string
-	existingHost     string
-	targetHost       string
+	existingHosts    []string
+	targetHosts      []string
 }
 
 // This CF makes sure number of host is aligned
@@ -34,8 +35,8 @@ func NewHostCF(ctx context.LoopContext, services services.LoopServices) loop.Con
 		ingressEntry:     nil,
 		ingressExists:    false,
 		serviceName:      resources.RC_NOT_CREATED_NAME_EMPTY,
-		existingHost:     "",
-		targetHost:       "",
+		existingHosts:    make([]string, 0),
+		targetHosts:      make([]string, 0),
 	}
 }
 
@@ -61,20 +62,33 @@ func (this *HostCF) Sense() {
 	}
 
 	// Observation #3
-	// Get the existing host (if present)
-	this.existingHost = resources.RC_NOT_CREATED_NAME_EMPTY
+	// Get the existing hosts (if present), the primary host is the first one
+	this.existingHosts = make([]string, 0)
 	if this.ingressExists && this.serviceName != resources.RC_NOT_CREATED_NAME_EMPTY {
-		this.existingHost = readHost(this.serviceName, this.ingressEntry.GetValue().(*networking.Ingress))
+		this.existingHosts = readHosts(this.serviceName, this.ingressEntry.GetValue().(*networking.Ingress))
 	}
 
 	// Observation #4
-	// Get target host
+	// Get target hosts
 	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
-		this.targetHost = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Host
+		hosts := specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Hosts
+		this.targetHosts = make([]string, 0)
+		if hosts.Primary != "" {
+			this.targetHosts = append(this.targetHosts, hosts.Primary)
+			for _, host := range hosts.Additional {
+				if host != "" && host != hosts.Primary {
+					this.targetHosts = append(this.targetHosts, host)
+				}
+			}
+		}
 	}
 
 	// Update state
-	this.svcStatus.SetConfig(status.CFG_STA_ROUTE, this.existingHost)
+	existingHost := resources.RC_NOT_CREATED_NAME_EMPTY
+	if len(this.existingHosts) > 0 {
+		existingHost = this.existingHosts[0]
+	}
+	this.svcStatus.SetConfig(status.CFG_STA_ROUTE, existingHost)
 }
 
 func (this *HostCF) Compare() bool {
@@ -83,13 +97,13 @@ func (this *HostCF) Compare() bool {
 	// Condition #2
 	// Service exists & is created
 	// Condition #3
-	// Existing host is not the same as the target host (assuming it is never empty)
+	// Existing hosts are not the same as the target hosts
 	// Condition #4
-	// Host must not be empty
+	// Primary host must not be empty
 	return this.ingressEntry != nil &&
 		this.serviceName != resources.RC_NOT_CREATED_NAME_EMPTY &&
-		this.existingHost != this.targetHost &&
-		this.targetHost != ""
+		!reflect.DeepEqual(this.existingHosts, this.targetHosts) &&
+		len(this.targetHosts) > 0
 }
 
 func (this *HostCF) Respond() {
@@ -97,7 +111,7 @@ func (this *HostCF) Respond() {
 	// Patch the resource
 	this.ingressEntry.ApplyPatch(func(value interface{}) interface{} {
 		ingress := value.(*networking.Ingress).DeepCopy()
-		writeHost(this.serviceName, ingress, this.targetHost)
+		writeHosts(this.serviceName, ingress, this.targetHosts)
 		return ingress
 	})
 }
@@ -107,26 +121,49 @@ func (this *HostCF) Cleanup() bool {
 	return true
 }
 
-func readHost(serviceName string, ingress *networking.Ingress) string {
+func readHosts(serviceName string, ingress *networking.Ingress) []string {
+	res := make([]string, 0)
 	for _, rule := range ingress.Spec.Rules {
-		for _, path := range rule.HTTP.Paths {
-			if path.Backend.Service.Name == serviceName {
-				return rule.Host
-			}
+		if isServiceRule(serviceName, rule) {
+			res = append(res, rule.Host)
 		}
 	}
-	return resources.RC_NOT_CREATED_NAME_EMPTY
+	return res
 }
 
-func writeHost(serviceName string, ingress *networking.Ingress, host string) {
+// Replaces the rules pointing to the Service with a rule for each of the hosts.
+// The first existing rule is used as a template.
+func writeHosts(serviceName string, ingress *networking.Ingress, hosts []string) {
+	var template *networking.IngressRuleValue
+	rules := make([]networking.IngressRule, 0)
 	for i, rule := range ingress.Spec.Rules {
+		if isServiceRule(serviceName, rule) {
+			if template == nil {
+				template = &ingress.Spec.Rules[i].IngressRuleValue
+			}
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+	if template == nil {
+		return
+	}
+	for _, host := range hosts {
+		rules = append(rules, networking.IngressRule{
+			Host:             host,
+			IngressRuleValue: *template.DeepCopy(),
+		})
+	}
+	ingress.Spec.Rules = rules
+}
+
+func isServiceRule(serviceName string, rule networking.IngressRule) bool {
+	if rule.HTTP != nil {
 		for _, path := range rule.HTTP.Paths {
-			if path.Backend.Service.Name == serviceName {
-				ingress.Spec.Rules[i] = networking.IngressRule{
-					Host:             host,
-					IngressRuleValue: rule.IngressRuleValue,
-				}
+			if path.Backend.Service != nil && path.Backend.Service.Name == serviceName {
+				return true
 			}
 		}
 	}
+	return false
 }
@@ -1,10 +1,11 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
 )
 
 var _ loop.ControlFunction = &HostInitCF{}
@@ -35,6 +36,12 @@ func (this *HostInitCF) Describe() string {
 }
 
 func (this *HostInitCF) Sense() {
+	// Observation #1
+	// The default host is set only once, even if the Operator has been restarted
+	done := false
+	this.ctx.GetState().Get(state.STATE_KEY_HOST_INIT_DONE, &done)
+	this.isFirstRun = !done
+
 	// Optimization
 	if !this.isFirstRun {
 		return
@@ -44,7 +51,7 @@ func (this *HostInitCF) Sense() {
 	// Get spec for patching & the target host
 	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
 		this.specEntry = specEntry
-		this.targetHost = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Host
+		this.targetHost = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Hosts.Primary
 	}
 
 }
@@ -64,12 +71,13 @@ func (this *HostInitCF) Respond() {
 		if dotNamespace == ".default" {
 			dotNamespace = ""
 		}
-		spec.Spec.Deployment.Host = this.ctx.GetAppName().Str() + dotNamespace
+		spec.Spec.Deployment.Hosts.Primary = this.ctx.GetAppName().Str() + dotNamespace
 		return spec
 	})
 
 	// We are going to try this only once
 	this.isFirstRun = false
+	this.ctx.GetState().Set(state.STATE_KEY_HOST_INIT_DONE, true)
 }
 
 func (this *HostInitCF) Cleanup() bool {
@@ -3,7 +3,7 @@ package cf
 import (
 	"strings"
 
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
@@ -49,7 +49,7 @@ func (this *HostInitRouteOcpCF) Sense() {
 	this.existingHost = ""
 	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
 		this.specEntry = specEntry
-		this.existingHost = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Host
+		this.existingHost = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Hosts.Primary
 	}
 
 	// Observation #2
@@ -77,7 +77,7 @@ func (this *HostInitRouteOcpCF) Respond() {
 		// Patch the resource
 		this.specEntry.ApplyPatch(func(value interface{}) interface{} {
 			spec := value.(*ar.ApicurioRegistry).DeepCopy()
-			spec.Spec.Deployment.Host = this.existingHost + "." + this.existingRouterCanonicalHostname
+			spec.Spec.Deployment.Hosts.Primary = this.existingHost + "." + this.existingRouterCanonicalHostname
 			return spec
 		})
 	}
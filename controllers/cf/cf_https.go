@@ -1,7 +1,7 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
@@ -16,6 +16,7 @@ import (
 	networking "k8s.io/api/networking/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"reflect"
 )
 
 var _ loop.ControlFunction = &HttpsCF{}
@@ -47,6 +48,7 @@ type HttpsCF struct {
 	serviceHttpsPortExists bool
 
 	secretVolumeExists       bool
+	secretVolumeOutdated     bool
 	secretVolumeMountExists  bool
 	containerHttpsPortExists bool
 	networkPolicyExists      bool
@@ -79,10 +81,17 @@ func (this *HttpsCF) Sense() {
 	// Read config values from the Apicurio custom resource
 	this.targetSecretName = ""
 	this.httpEnabled = true
+	generatedSecret := false
 	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
 		spec := entry.GetValue().(*ar.ApicurioRegistry).Spec
 		this.targetSecretName = spec.Configuration.Security.Https.SecretName
 		this.httpEnabled = !spec.Configuration.Security.Https.DisableHttp
+		// The Secret is provided by HttpsCertificateCF
+		switch spec.Configuration.Security.Https.Certificate.Mode {
+		case ar.HttpsCertificateModeSelfSigned, ar.HttpsCertificateModeCertManager, ar.HttpsCertificateModeOpenShift:
+			this.targetSecretName = this.services.GetCertificateFactory().GetHttpsSecretName()
+			generatedSecret = true
+		}
 	}
 	this.log.Debugw("Observation #1", "this.targetSecretName", this.targetSecretName,
 		"this.httpEnabled", this.httpEnabled)
@@ -94,7 +103,11 @@ func (this *HttpsCF) Sense() {
 		secret, err := this.svcClients.Kube().
 			GetSecret(this.ctx.GetAppNamespace(), common.Name(this.targetSecretName), &meta.GetOptions{})
 
-		if err == nil {
+		if generatedSecret && (err != nil || !common.SecretHasField(secret, "tls.crt") || !common.SecretHasField(secret, "tls.key")) {
+			// Not a configuration error, the certificate has not been issued yet
+			this.log.Debugw("waiting for the HTTPS certificate Secret", "secretName", this.targetSecretName)
+			this.ctx.SetRequeueDelaySoon()
+		} else if err == nil {
 			if !common.SecretHasField(secret, "tls.crt") || !common.SecretHasField(secret, "tls.key") {
 				this.log.Errorw("HTTPS secret referenced in Apicurio Registry CR must have both tls.crt and tls.key fields",
 					"secretName", this.targetSecretName)
@@ -136,6 +149,7 @@ func (this *HttpsCF) Sense() {
 	// Observation #4
 	// Check deployment has mounted the secret from the config as a volume
 	this.secretVolumeExists = false
+	this.secretVolumeOutdated = false
 	this.secretVolumeMountExists = false
 	this.containerHttpsPortExists = false
 	this.containerHttpPortExists = false
@@ -148,6 +162,8 @@ func (this *HttpsCF) Sense() {
 			for _, volume := range deployment.Spec.Template.Spec.Volumes {
 				if volume.Name == this.targetSecretName {
 					this.secretVolumeExists = true
+					this.secretVolumeOutdated = volume.Secret == nil ||
+						!reflect.DeepEqual(volume.Secret.Items, NewSecretVolume(this.targetSecretName).Secret.Items)
 					break
 				}
 			}
@@ -170,6 +186,7 @@ func (this *HttpsCF) Sense() {
 		}
 	}
 	this.log.Debugw("Observation #4", "this.secretVolumeExists", this.secretVolumeExists,
+		"this.secretVolumeOutdated", this.secretVolumeOutdated,
 		"this.secretVolumeMountExists", this.secretVolumeMountExists,
 		"this.containerHttpsPortExists", this.containerHttpsPortExists,
 		"this.containerHttpPortExists", this.containerHttpPortExists)
@@ -226,6 +243,7 @@ func (this *HttpsCF) Compare() bool {
 	actionNeeded := (this.secretExists && this.targetSecretName != this.previousSecretName) || // Secret renamed or removed
 		(this.httpsEnabled != this.serviceHttpsPortExists) || // Observation #3
 		(this.httpsEnabled != this.secretVolumeExists) || // Observation #4
+		(this.httpsEnabled && this.secretVolumeOutdated) || // Observation #4
 		(this.httpsEnabled != this.secretVolumeMountExists) || // Observation #4
 		(this.httpsEnabled != this.containerHttpsPortExists) || // Observation #4
 		(this.httpsEnabled != this.javaOptionsExists) || // Observation #5
@@ -255,7 +273,7 @@ func (this *HttpsCF) Respond() {
 				Protocol:      core.ProtocolTCP,
 			}
 
-			if this.httpsEnabled && !this.secretVolumeExists {
+			if this.httpsEnabled && (!this.secretVolumeExists || this.secretVolumeOutdated) {
 				// Remove old secret reference if exists
 				if this.previousSecretName != "" {
 					common.RemoveVolumeFromDeployment(deployment, NewSecretVolume(this.previousSecretName))
@@ -426,12 +444,18 @@ func (this *HttpsCF) Cleanup() bool {
 	return true
 }
 
+// Only the certificate and the private key are mounted,
+// the Secret generated by the Operator also contains the private key of the self-signed CA
 func NewSecretVolume(name string) *core.Volume {
 	return &core.Volume{
 		Name: name,
 		VolumeSource: core.VolumeSource{
 			Secret: &core.SecretVolumeSource{
 				SecretName: name,
+				Items: []core.KeyToPath{
+					{Key: "tls.crt", Path: "tls.crt"},
+					{Key: "tls.key", Path: "tls.key"},
+				},
 			},
 		},
 	}
@@ -0,0 +1,319 @@
+package cf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
+	"time"
+)
+
+var _ loop.ControlFunction = &HttpsCertificateCF{}
+
+// Hash of the HTTPS certificate, so the pods are restarted when the certificate is renewed
+const HttpsCertificateHashAnnotation = "apicur.io/https-certificate-hash"
+
+// The OpenShift service CA operator creates a Secret with a serving certificate for the annotated Service
+const OpenShiftServingCertSecretAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+
+// This control function provides the HTTPS certificate Secret used by HttpsCF,
+// if the certificate is generated by the Operator, cert-manager, or the OpenShift service CA.
+// Self-signed certificates are checked for renewal on every reconciliation.
+type HttpsCertificateCF struct {
+	ctx                context.LoopContext
+	log                *zap.SugaredLogger
+	svcResourceCache   resources.ResourceCache
+	svcClients         *client.Clients
+	services           services.LoopServices
+	certificateFactory *factory.CertificateFactory
+
+	mode       ar.ApicurioRegistryHttpsCertificateMode
+	issuerName string
+	issuerKind string
+	dnsNames   []string
+	enabled    bool
+
+	secret        *core.Secret
+	renewalNeeded bool
+
+	certificate         *unstructured.Unstructured
+	certificateOutdated bool
+
+	deploymentEntry resources.ResourceCacheEntry
+	targetHash      string
+	foundHash       string
+
+	openshift              bool
+	serviceEntry           resources.ResourceCacheEntry
+	foundServiceAnnotation string
+}
+
+func NewHttpsCertificateCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &HttpsCertificateCF{
+		ctx:                ctx,
+		svcResourceCache:   ctx.GetResourceCache(),
+		svcClients:         ctx.GetClients(),
+		services:           services,
+		certificateFactory: services.GetCertificateFactory(),
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *HttpsCertificateCF) Describe() string {
+	return "HttpsCertificateCF"
+}
+
+func (this *HttpsCertificateCF) Sense() {
+	// Observation #1
+	// Read config values from the Apicurio custom resource
+	this.mode = ar.HttpsCertificateModeProvided
+	this.issuerName = ""
+	this.issuerKind = ""
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		certificate := entry.GetValue().(*ar.ApicurioRegistry).Spec.Configuration.Security.Https.Certificate
+		if certificate.Mode != "" {
+			this.mode = certificate.Mode
+		}
+		this.issuerName = certificate.Issuer.Name
+		this.issuerKind = certificate.Issuer.Kind
+		if this.issuerKind == "" {
+			this.issuerKind = factory.DEFAULT_CERT_MANAGER_ISSUER_KIND
+		}
+	}
+
+	// Observation #2
+	// The certificate is issued for the Service DNS names
+	this.dnsNames = nil
+	this.serviceEntry = nil
+	this.foundServiceAnnotation = ""
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_SERVICE); exists {
+		service := entry.GetValue().(*core.Service)
+		this.dnsNames = this.certificateFactory.GetServiceDnsNames(service.Name)
+		this.serviceEntry = entry
+		this.foundServiceAnnotation = service.Annotations[OpenShiftServingCertSecretAnnotation]
+	}
+
+	// Observation #3
+	// Validate the config values
+	this.enabled = (this.mode == ar.HttpsCertificateModeSelfSigned || this.mode == ar.HttpsCertificateModeCertManager ||
+		this.mode == ar.HttpsCertificateModeOpenShift) && this.dnsNames != nil
+	if this.enabled && this.mode == ar.HttpsCertificateModeOpenShift && !this.ctx.GetSupportedFeatures().IsOCP {
+		this.log.Errorw("HTTPS certificate mode " + string(this.mode) + " is only supported on OpenShift")
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(string(this.mode), "spec.configuration.security.https.certificate.mode")
+		this.enabled = false
+	}
+	this.openshift = this.enabled && this.mode == ar.HttpsCertificateModeOpenShift
+	if this.enabled && this.mode == ar.HttpsCertificateModeCertManager {
+		if !this.ctx.GetSupportedFeatures().SupportsCertManager {
+			this.log.Errorw("HTTPS certificate mode " + string(this.mode) + " requires cert-manager to be installed")
+			this.services.GetConditionManager().GetConfigurationErrorCondition().
+				TransitionInvalid(string(this.mode), "spec.configuration.security.https.certificate.mode")
+			this.enabled = false
+		} else if this.issuerName == "" {
+			this.log.Errorw("HTTPS certificate mode " + string(this.mode) + " requires an issuer")
+			this.services.GetConditionManager().GetConfigurationErrorCondition().
+				TransitionInvalid(this.issuerName, "spec.configuration.security.https.certificate.issuer.name")
+			this.enabled = false
+		}
+	}
+
+	this.secret = nil
+	this.renewalNeeded = false
+	this.certificate = nil
+	this.certificateOutdated = false
+	this.targetHash = ""
+	if !this.enabled {
+		return
+	}
+
+	// Observation #4
+	// Get the certificate Secret, it is created by the Operator, cert-manager, or the OpenShift service CA
+	secret, err := this.svcClients.Kube().
+		GetSecret(this.ctx.GetAppNamespace(), common.Name(this.certificateFactory.GetHttpsSecretName()), &meta.GetOptions{})
+	if err == nil {
+		this.secret = secret
+		if common.SecretHasField(secret, "tls.crt") {
+			hash := sha256.Sum256(secret.Data["tls.crt"])
+			this.targetHash = hex.EncodeToString(hash[:])
+		}
+	} else if !api_errors.IsNotFound(err) {
+		this.log.Errorw("could not get HTTPS certificate Secret", "error", err)
+		this.ctx.SetRequeueDelaySec(10)
+		this.enabled = false
+		return
+	}
+	if this.mode == ar.HttpsCertificateModeOpenShift && this.secret == nil {
+		this.log.Debugw("waiting for the OpenShift service CA to issue the HTTPS certificate")
+		this.ctx.SetRequeueDelaySoon()
+	}
+	if this.mode == ar.HttpsCertificateModeSelfSigned {
+		// The CA is kept when only the serving certificate is renewed
+		now := time.Now()
+		this.renewalNeeded = this.secret == nil ||
+			factory.IsCertificateRenewalNeeded(this.secret.Data["tls.crt"], this.dnsNames, now) ||
+			factory.IsCaRenewalNeeded(this.secret.Data["ca.crt"], this.secret.Data[factory.SELF_SIGNED_CA_KEY_KEY], now)
+	}
+
+	// Observation #5
+	// Get the cert-manager Certificate
+	if this.mode == ar.HttpsCertificateModeCertManager {
+		certificate, err := this.svcClients.CertManager().
+			GetCertificate(this.ctx.GetAppNamespace(), common.Name(this.certificateFactory.GetHttpsSecretName()))
+		if err == nil {
+			this.certificate = certificate
+		} else if !api_errors.IsNotFound(err) {
+			this.log.Errorw("could not get cert-manager Certificate", "error", err)
+		}
+		this.certificateOutdated = this.certificate == nil || !this.isCertificateUpToDate()
+		if this.secret == nil {
+			this.log.Debugw("waiting for cert-manager to issue the HTTPS certificate")
+			this.ctx.SetRequeueDelaySoon()
+		}
+	}
+
+	// Observation #6
+	// Check the certificate hash annotation
+	this.foundHash = ""
+	deploymentEntry, deploymentExists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT)
+	if deploymentExists {
+		this.foundHash = deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Annotations[HttpsCertificateHashAnnotation]
+	}
+	this.deploymentEntry = deploymentEntry
+}
+
+func (this *HttpsCertificateCF) Compare() bool {
+	// Condition #1
+	// The Secret or Certificate must be created or updated, act only once per loop
+	// Condition #2
+	// The certificate changed and the pods must be restarted
+	// Condition #3
+	// The Service must be (un)configured for the OpenShift service CA
+	return (this.enabled &&
+		(((this.renewalNeeded || this.certificateOutdated) && this.ctx.GetAttempts() == 0) ||
+			(this.deploymentEntry != nil && this.targetHash != "" && this.targetHash != this.foundHash))) ||
+		this.isServiceOutdated()
+}
+
+func (this *HttpsCertificateCF) Respond() {
+	specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC)
+	if !exists {
+		return
+	}
+	owner := specEntry.GetValue().(*ar.ApicurioRegistry)
+
+	// Response #1
+	// Generate the self-signed certificate
+	if this.renewalNeeded && this.ctx.GetAttempts() == 0 {
+		if secret, err := this.certificateFactory.NewSelfSignedSecret(this.dnsNames, this.secret); err != nil {
+			this.log.Errorw("could not generate self-signed HTTPS certificate", "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+		} else if this.secret == nil {
+			if _, err := this.svcClients.Kube().CreateSecret(owner, this.ctx.GetAppNamespace(), secret); err != nil {
+				this.log.Errorw("could not create HTTPS certificate Secret", "error", err)
+				this.ctx.SetRequeueDelaySec(10)
+			}
+		} else {
+			updated := this.secret.DeepCopy()
+			updated.Data = secret.Data
+			if _, err := this.svcClients.Kube().UpdateSecret(this.ctx.GetAppNamespace(), updated); err != nil {
+				this.log.Errorw("could not update HTTPS certificate Secret", "error", err)
+				this.ctx.SetRequeueDelaySec(10)
+			}
+		}
+	}
+
+	// Response #2
+	// Create or update the cert-manager Certificate
+	if this.certificateOutdated && this.ctx.GetAttempts() == 0 {
+		certificate := this.certificateFactory.NewCertManagerCertificate(this.dnsNames, this.issuerName, this.issuerKind)
+		if this.certificate == nil {
+			if _, err := this.svcClients.CertManager().CreateCertificate(owner, this.ctx.GetAppNamespace(), certificate); err != nil {
+				this.log.Errorw("could not create cert-manager Certificate", "error", err)
+				this.ctx.SetRequeueDelaySec(10)
+			}
+		} else {
+			updated := this.certificate.DeepCopy()
+			updated.Object["spec"] = certificate.Object["spec"]
+			if _, err := this.svcClients.CertManager().UpdateCertificate(this.ctx.GetAppNamespace(), updated); err != nil {
+				this.log.Errorw("could not update cert-manager Certificate", "error", err)
+				this.ctx.SetRequeueDelaySec(10)
+			}
+		}
+	}
+
+	// Response #3
+	// Set the certificate hash annotation
+	if this.enabled && this.deploymentEntry != nil && this.targetHash != "" && this.targetHash != this.foundHash {
+		this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+			deployment := value.(*apps.Deployment).DeepCopy()
+			common.LabelsUpdate(&deployment.Spec.Template.Annotations, map[string]string{
+				HttpsCertificateHashAnnotation: this.targetHash,
+			})
+			return deployment
+		})
+	}
+
+	// Response #4
+	// Request the service serving certificate, or stop requesting it so it does not replace a different certificate
+	if this.isServiceOutdated() {
+		this.serviceEntry.ApplyPatch(func(value interface{}) interface{} {
+			service := value.(*core.Service).DeepCopy()
+			if this.openshift {
+				common.LabelsUpdate(&service.Annotations, map[string]string{
+					OpenShiftServingCertSecretAnnotation: this.certificateFactory.GetHttpsSecretName(),
+				})
+			} else {
+				delete(service.Annotations, OpenShiftServingCertSecretAnnotation)
+			}
+			return service
+		})
+	}
+}
+
+func (this *HttpsCertificateCF) Cleanup() bool {
+	// The Secret and Certificate are owned by the ApicurioRegistry resource and deleted by the garbage collector
+	return true
+}
+
+func (this *HttpsCertificateCF) isServiceOutdated() bool {
+	if this.serviceEntry == nil {
+		return false
+	}
+	if this.openshift {
+		return this.foundServiceAnnotation != this.certificateFactory.GetHttpsSecretName()
+	}
+	// Do not remove annotations not set by the Operator
+	return this.foundServiceAnnotation == this.certificateFactory.GetHttpsSecretName()
+}
+
+// Returns the termination of the Routes managed by RouteOcpCF.
+// With the OpenShift service serving certificate, the Routes use reencrypt termination by default.
+// The destination CA certificate is left empty, so the router trusts the service CA.
+func GetRouteTermination(spec *ar.ApicurioRegistrySpec) ar.ApicurioRegistryRouteTermination {
+	if spec.Deployment.Route.Termination == "" && spec.Configuration.Security.Https.Certificate.Mode == ar.HttpsCertificateModeOpenShift {
+		return ar.RouteTerminationReencrypt
+	}
+	return spec.Deployment.Route.Termination
+}
+
+func (this *HttpsCertificateCF) isCertificateUpToDate() bool {
+	dnsNames, _, _ := unstructured.NestedStringSlice(this.certificate.Object, "spec", "dnsNames")
+	issuerName, _, _ := unstructured.NestedString(this.certificate.Object, "spec", "issuerRef", "name")
+	issuerKind, _, _ := unstructured.NestedString(this.certificate.Object, "spec", "issuerRef", "kind")
+	return reflect.DeepEqual(dnsNames, this.dnsNames) && issuerName == this.issuerName && issuerKind == this.issuerKind
+}
@@ -0,0 +1,143 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"testing"
+)
+
+func newLoopContextWithClientsMock(t *testing.T, spec *ar.ApicurioRegistry) *context.LoopContextMock {
+	ctx := context.NewLoopContextMock()
+	scheme := runtime.NewScheme()
+	c.AssertEquals(t, nil, ar.AddToScheme(scheme))
+	ctx.SetClients(client.NewClientsMock(ctx.GetLog(), scheme))
+	spec.Name = ctx.GetAppName().Str()
+	spec.Namespace = ctx.GetAppNamespace().Str()
+	spec.UID = "registry-uid"
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), spec))
+	return ctx
+}
+
+func TestHttpsCertificateOpenShift(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Configuration.Security.Https.Certificate.Mode = ar.HttpsCertificateModeOpenShift
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	certificateFactory := services.GetCertificateFactory()
+	service := services.GetKubeFactory().CreateService()
+	service.Name = ctx.GetAppName().Str() + "-service"
+	ctx.GetResourceCache().Set(resources.RC_KEY_SERVICE, resources.NewResourceCacheEntry(c.Name(service.Name), service))
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, resources.NewResourceCacheEntry(ctx.GetAppName(), &apps.Deployment{}))
+	this := NewHttpsCertificateCF(ctx, services)
+
+	// Only supported on OpenShift
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+	c.AssertEquals(t, true, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+
+	// The Service is annotated, and the Operator waits for the Secret to be generated
+	ctx.SetSupportedFeatures(&c.SupportedFeatures{IsOCP: true})
+	ctx.Finalize()
+	this.Sense()
+	requeue, _ := ctx.GetRequeueDelay()
+	c.AssertEquals(t, true, requeue)
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	serviceEntry, _ := ctx.GetResourceCache().Get(resources.RC_KEY_SERVICE)
+	c.AssertEquals(t, certificateFactory.GetHttpsSecretName(),
+		serviceEntry.GetValue().(*core.Service).Annotations[OpenShiftServingCertSecretAnnotation])
+	deploymentEntry, _ := ctx.GetResourceCache().Get(resources.RC_KEY_DEPLOYMENT)
+	c.AssertEquals(t, "", deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Annotations[HttpsCertificateHashAnnotation])
+
+	// The Secret is generated, the pods are restarted with the certificate
+	ctx.Finalize()
+	secret, err := certificateFactory.NewSelfSignedSecret(certificateFactory.GetServiceDnsNames(service.Name), nil)
+	c.AssertEquals(t, nil, err)
+	_, err = ctx.GetClients().Kube().CreateSecret(spec, ctx.GetAppNamespace(), secret)
+	c.AssertEquals(t, nil, err)
+	this.Sense()
+	requeue, _ = ctx.GetRequeueDelay()
+	c.AssertEquals(t, false, requeue)
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, true, deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Annotations[HttpsCertificateHashAnnotation] != "")
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The Routes use reencrypt termination, unless configured otherwise
+	c.AssertEquals(t, ar.RouteTerminationReencrypt, GetRouteTermination(&spec.Spec))
+	spec.Spec.Deployment.Route.Termination = ar.RouteTerminationEdge
+	c.AssertEquals(t, ar.RouteTerminationEdge, GetRouteTermination(&spec.Spec))
+
+	// The annotation is removed when the mode changes
+	spec.Spec.Configuration.Security.Https.Certificate.Mode = ar.HttpsCertificateModeProvided
+	spec.Spec.Deployment.Route.Termination = ""
+	c.AssertEquals(t, ar.ApicurioRegistryRouteTermination(""), GetRouteTermination(&spec.Spec))
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	_, found := serviceEntry.GetValue().(*core.Service).Annotations[OpenShiftServingCertSecretAnnotation]
+	c.AssertEquals(t, false, found)
+}
+
+func TestHttpsCertificateSelfSigned(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Configuration.Security.Https.Certificate.Mode = ar.HttpsCertificateModeSelfSigned
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	certificateFactory := services.GetCertificateFactory()
+	service := services.GetKubeFactory().CreateService()
+	service.Name = ctx.GetAppName().Str() + "-service"
+	serviceEntry := resources.NewResourceCacheEntry(c.Name(service.Name), service)
+	ctx.GetResourceCache().Set(resources.RC_KEY_SERVICE, serviceEntry)
+	this := NewHttpsCertificateCF(ctx, services)
+	getSecret := func() *core.Secret {
+		secret, err := ctx.GetClients().Kube().GetSecret(ctx.GetAppNamespace(), c.Name(certificateFactory.GetHttpsSecretName()), &meta.GetOptions{})
+		c.AssertEquals(t, nil, err)
+		return secret
+	}
+
+	// The Secret is generated
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	secret := getSecret()
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The serving certificate is renewed for the new DNS names, the CA is kept
+	serviceEntry.ApplyPatch(func(value interface{}) interface{} {
+		service := value.(*core.Service).DeepCopy()
+		service.Name = ctx.GetAppName().Str() + "-renamed"
+		return service
+	})
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	renewed := getSecret()
+	c.AssertEquals(t, false, string(secret.Data["tls.crt"]) == string(renewed.Data["tls.crt"]))
+	c.AssertEquals(t, secret.Data["ca.crt"], renewed.Data["ca.crt"])
+	c.AssertEquals(t, secret.Data[factory.SELF_SIGNED_CA_KEY_KEY], renewed.Data[factory.SELF_SIGNED_CA_KEY_KEY])
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The Secrets generated by previous versions do not contain the CA private key, a new CA is generated
+	delete(renewed.Data, factory.SELF_SIGNED_CA_KEY_KEY)
+	_, err := ctx.GetClients().Kube().UpdateSecret(ctx.GetAppNamespace(), renewed)
+	c.AssertEquals(t, nil, err)
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, false, string(secret.Data["ca.crt"]) == string(getSecret().Data["ca.crt"]))
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+}
@@ -0,0 +1,361 @@
+package cf
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var _ loop.ControlFunction = &HttpsClientAuthCF{}
+
+const (
+	ENV_QUARKUS_HTTP_SSL_CLIENT_AUTH                       = "QUARKUS_HTTP_SSL_CLIENT_AUTH"
+	ENV_QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE      = "QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE"
+	ENV_QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE_TYPE = "QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE_TYPE"
+	HTTPS_TRUSTSTORE_VOLUME_NAME                           = "registry-https-truststore"
+	HTTPS_TRUSTSTORE_KEY                                   = "ca.crt"
+	TlsTruststoreMountPath                                 = "/certs-truststore"
+)
+
+// Hash of the truststore, so the pods are restarted when the truststore changes
+const HttpsTruststoreHashAnnotation = "apicur.io/https-truststore-hash"
+
+// The source Secret is not watched, so it is checked for changes periodically
+const HTTPS_CLIENT_AUTH_SOURCE_CHECK_DELAY_SEC = 60
+
+// This control function configures HTTPS client authentication.
+// The truststore mounted by Apicurio Registry contains the CA certificates from the user-provided Secret,
+// and the CA of the Operator client certificate, so the Operator can still check the application health,
+// see AppHealthCF and InitializingCF.
+type HttpsClientAuthCF struct {
+	ctx                  context.LoopContext
+	log                  *zap.SugaredLogger
+	svcResourceCache     resources.ResourceCache
+	svcEnvCache          env.EnvCache
+	svcClients           *client.Clients
+	services             services.LoopServices
+	certificateFactory   *factory.CertificateFactory
+	clientAuth           ar.ApicurioRegistryHttpsClientAuth
+	truststoreSecretName string
+	enabled              bool
+	valid                bool
+	deploymentEntry      resources.ResourceCacheEntry
+	foundVolume          bool
+	foundHash            string
+	foundEnv             bool
+	envReady             bool
+	caCertificates       []byte
+	operatorSecret       *core.Secret
+	operatorSecretReady  bool
+	truststoreSecret     *core.Secret
+	targetData           map[string][]byte
+	targetHash           string
+	truststoreReady      bool
+}
+
+func NewHttpsClientAuthCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &HttpsClientAuthCF{
+		ctx:                  ctx,
+		svcResourceCache:     ctx.GetResourceCache(),
+		svcEnvCache:          ctx.GetEnvCache(),
+		svcClients:           ctx.GetClients(),
+		services:             services,
+		certificateFactory:   services.GetCertificateFactory(),
+		clientAuth:           "",
+		truststoreSecretName: "",
+		enabled:              false,
+		valid:                false,
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *HttpsClientAuthCF) Describe() string {
+	return "HttpsClientAuthCF"
+}
+
+func (this *HttpsClientAuthCF) Sense() {
+	// Observation #1
+	// Read the config values
+	this.clientAuth = ""
+	this.truststoreSecretName = ""
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		this.clientAuth = spec.Configuration.Security.Https.ClientAuth
+		this.truststoreSecretName = spec.Configuration.Security.Https.TruststoreSecretName
+	}
+
+	// Observation #2
+	// HTTPS is enabled, see HttpsCF
+	httpsEnabled := false
+	if serviceEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SERVICE); exists {
+		httpsEnabled = common.HasPort("https", serviceEntry.GetValue().(*core.Service).Spec.Ports)
+	}
+
+	// Observation #3
+	// Check the Deployment and env. variables
+	deploymentEntry, deploymentExists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT)
+	this.deploymentEntry = deploymentEntry
+	this.foundVolume = false
+	this.foundHash = ""
+	if deploymentExists {
+		deployment := deploymentEntry.GetValue().(*apps.Deployment)
+		for _, volume := range deployment.Spec.Template.Spec.Volumes {
+			if volume.Name == HTTPS_TRUSTSTORE_VOLUME_NAME {
+				this.foundVolume = true
+			}
+		}
+		this.foundHash = deployment.Spec.Template.Annotations[HttpsTruststoreHashAnnotation]
+	}
+	this.foundEnv = false
+	for name := range this.getTargetEnv() {
+		if _, exists := this.svcEnvCache.Get(name); exists {
+			this.foundEnv = true
+		}
+	}
+
+	this.enabled = (this.clientAuth == ar.HttpsClientAuthRequest || this.clientAuth == ar.HttpsClientAuthRequired) &&
+		httpsEnabled && deploymentExists
+	this.valid = false
+	this.caCertificates = nil
+	this.operatorSecret = nil
+	this.operatorSecretReady = false
+	this.truststoreSecret = nil
+	this.targetData = nil
+	this.targetHash = ""
+	this.truststoreReady = false
+	if !this.enabled {
+		return
+	}
+	this.ctx.SetRequeueDelaySec(HTTPS_CLIENT_AUTH_SOURCE_CHECK_DELAY_SEC)
+
+	// Observation #4
+	// Read the CA certificates from the user-provided Secret
+	if this.truststoreSecretName == "" {
+		this.log.Errorw("HTTPS client authentication requires a truststore Secret")
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(string(this.clientAuth), "spec.configuration.security.https.clientAuth")
+		return
+	}
+	secret, err := this.svcClients.Kube().
+		GetSecret(this.ctx.GetAppNamespace(), common.Name(this.truststoreSecretName), &meta.GetOptions{})
+	if err != nil || !common.SecretHasField(secret, HTTPS_TRUSTSTORE_KEY) {
+		this.log.Errorw("HTTPS truststore Secret referenced in Apicurio Registry CR is missing, "+
+			"or does not have the "+HTTPS_TRUSTSTORE_KEY+" field",
+			"secretName", this.truststoreSecretName, "error", err)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(this.truststoreSecretName, "spec.configuration.security.https.truststoreSecretName")
+		this.ctx.SetRequeueDelaySec(10)
+		return
+	}
+	this.caCertificates = secret.Data[HTTPS_TRUSTSTORE_KEY]
+	this.valid = true
+
+	// Observation #5
+	// Get the Operator client certificate Secret, and check if the certificate must be renewed
+	secret, err = this.svcClients.Kube().GetSecret(this.ctx.GetAppNamespace(),
+		common.Name(this.certificateFactory.GetHttpsOperatorClientSecretName()), &meta.GetOptions{})
+	if err == nil {
+		this.operatorSecret = secret
+		this.operatorSecretReady = common.SecretHasField(secret, "ca.crt") &&
+			!factory.IsCertificateRenewalNeeded(secret.Data["tls.crt"], nil, time.Now())
+	} else if !api_errors.IsNotFound(err) {
+		this.log.Errorw("could not get Secret", "name", this.certificateFactory.GetHttpsOperatorClientSecretName(), "error", err)
+	}
+
+	// Observation #6
+	// Get the truststore Secret
+	secret, err = this.svcClients.Kube().GetSecret(this.ctx.GetAppNamespace(),
+		common.Name(this.certificateFactory.GetHttpsTruststoreSecretName()), &meta.GetOptions{})
+	if err == nil {
+		this.truststoreSecret = secret
+	} else if !api_errors.IsNotFound(err) {
+		this.log.Errorw("could not get Secret", "name", this.certificateFactory.GetHttpsTruststoreSecretName(), "error", err)
+	}
+	this.updateTargetData()
+
+	this.envReady = true
+	for name, value := range this.getTargetEnv() {
+		if entry, exists := this.svcEnvCache.Get(name); !exists || entry.GetValue().Value != value {
+			this.envReady = false
+		}
+	}
+}
+
+func (this *HttpsClientAuthCF) Compare() bool {
+	// Condition #1
+	// Client authentication has been disabled, but the Deployment or env. variables are still configured
+	// Condition #2
+	// A Secret is missing or outdated, act only once per loop so a failing request does not prevent stabilization
+	// Condition #3
+	// The Secrets are up to date, but the Deployment or env. variables are not
+	return (!this.enabled && this.deploymentEntry != nil && (this.foundVolume || this.foundHash != "" || this.foundEnv)) ||
+		(this.enabled && this.valid &&
+			(((!this.operatorSecretReady || !this.truststoreReady) && this.ctx.GetAttempts() == 0) ||
+				(this.truststoreReady && (!this.foundVolume || this.foundHash != this.targetHash || !this.envReady))))
+}
+
+func (this *HttpsClientAuthCF) Respond() {
+	if !this.enabled {
+		// Response #1
+		// Remove the configuration
+		for name := range this.getTargetEnv() {
+			this.svcEnvCache.DeleteByName(name)
+		}
+		this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+			deployment := value.(*apps.Deployment).DeepCopy()
+			common.RemoveVolumeFromDeployment(deployment, this.newVolume())
+			if container := common.GetContainerByName(deployment.Spec.Template.Spec.Containers, factory.REGISTRY_CONTAINER_NAME); container != nil {
+				common.RemoveVolumeMountFromContainer(container, this.newVolumeMount())
+			}
+			delete(deployment.Spec.Template.Annotations, HttpsTruststoreHashAnnotation)
+			return deployment
+		})
+		return
+	}
+
+	// Response #2
+	// Create or renew the Operator client certificate
+	if !this.operatorSecretReady {
+		secret, err := this.writeOperatorSecret()
+		if err != nil {
+			this.log.Errorw("could not create or update Secret", "name", this.certificateFactory.GetHttpsOperatorClientSecretName(), "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+			return
+		}
+		this.operatorSecret = secret
+		this.operatorSecretReady = true
+		this.updateTargetData()
+	}
+
+	// Response #3
+	// Create or update the truststore Secret
+	if !this.truststoreReady {
+		if err := this.writeTruststoreSecret(); err != nil {
+			this.log.Errorw("could not create or update Secret", "name", this.certificateFactory.GetHttpsTruststoreSecretName(), "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+			return
+		}
+		this.truststoreReady = true
+	}
+
+	// Response #4
+	// Set the env. variables, mount the truststore, and set the hash annotation
+	for name, value := range this.getTargetEnv() {
+		this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(name, value).Build())
+	}
+	this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+		deployment := value.(*apps.Deployment).DeepCopy()
+		common.SetVolumeInDeployment(deployment, this.newVolume())
+		if container := common.GetContainerByName(deployment.Spec.Template.Spec.Containers, factory.REGISTRY_CONTAINER_NAME); container != nil {
+			common.AddVolumeMountToContainer(container, this.newVolumeMount())
+		}
+		common.LabelsUpdate(&deployment.Spec.Template.Annotations, map[string]string{
+			HttpsTruststoreHashAnnotation: this.targetHash,
+		})
+		return deployment
+	})
+}
+
+func (this *HttpsClientAuthCF) Cleanup() bool {
+	// The Secrets are owned by the ApicurioRegistry resource and deleted by the garbage collector
+	return true
+}
+
+func (this *HttpsClientAuthCF) getTargetEnv() map[string]string {
+	return map[string]string{
+		ENV_QUARKUS_HTTP_SSL_CLIENT_AUTH:                       strings.ToUpper(string(this.clientAuth)),
+		ENV_QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE:      TlsTruststoreMountPath + "/" + HTTPS_TRUSTSTORE_KEY,
+		ENV_QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE_TYPE: "PEM",
+	}
+}
+
+// The truststore contains the user-provided CA certificates and the CA of the Operator client certificate
+func (this *HttpsClientAuthCF) updateTargetData() {
+	this.targetData = nil
+	this.targetHash = ""
+	this.truststoreReady = false
+	if this.caCertificates == nil || !this.operatorSecretReady {
+		return
+	}
+	truststore := bytes.TrimSpace(this.caCertificates)
+	truststore = append(truststore, '\n')
+	truststore = append(truststore, this.operatorSecret.Data["ca.crt"]...)
+	this.targetData = map[string][]byte{
+		HTTPS_TRUSTSTORE_KEY: truststore,
+	}
+	hash := sha256.Sum256(truststore)
+	this.targetHash = hex.EncodeToString(hash[:])
+	this.truststoreReady = this.truststoreSecret != nil && reflect.DeepEqual(this.truststoreSecret.Data, this.targetData)
+}
+
+func (this *HttpsClientAuthCF) writeOperatorSecret() (*core.Secret, error) {
+	target, err := this.certificateFactory.NewOperatorClientSecret()
+	if err != nil {
+		return nil, err
+	}
+	if this.operatorSecret != nil {
+		secret := this.operatorSecret.DeepCopy()
+		secret.Data = target.Data
+		return this.svcClients.Kube().UpdateSecret(this.ctx.GetAppNamespace(), secret)
+	}
+	specEntry, _ := this.svcResourceCache.Get(resources.RC_KEY_SPEC)
+	return this.svcClients.Kube().CreateSecret(specEntry.GetValue().(*ar.ApicurioRegistry), this.ctx.GetAppNamespace(), target)
+}
+
+func (this *HttpsClientAuthCF) writeTruststoreSecret() error {
+	if this.truststoreSecret != nil {
+		secret := this.truststoreSecret.DeepCopy()
+		secret.Data = this.targetData
+		_, err := this.svcClients.Kube().UpdateSecret(this.ctx.GetAppNamespace(), secret)
+		return err
+	}
+	specEntry, _ := this.svcResourceCache.Get(resources.RC_KEY_SPEC)
+	secret := &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      this.certificateFactory.GetHttpsTruststoreSecretName(),
+			Namespace: this.ctx.GetAppNamespace().Str(),
+			Labels:    this.services.GetKubeFactory().GetLabels(),
+		},
+		Type: core.SecretTypeOpaque,
+		Data: this.targetData,
+	}
+	_, err := this.svcClients.Kube().CreateSecret(specEntry.GetValue().(*ar.ApicurioRegistry), this.ctx.GetAppNamespace(), secret)
+	return err
+}
+
+func (this *HttpsClientAuthCF) newVolume() *core.Volume {
+	return &core.Volume{
+		Name: HTTPS_TRUSTSTORE_VOLUME_NAME,
+		VolumeSource: core.VolumeSource{
+			Secret: &core.SecretVolumeSource{
+				SecretName: this.certificateFactory.GetHttpsTruststoreSecretName(),
+			},
+		},
+	}
+}
+
+func (this *HttpsClientAuthCF) newVolumeMount() *core.VolumeMount {
+	return &core.VolumeMount{
+		Name:      HTTPS_TRUSTSTORE_VOLUME_NAME,
+		MountPath: TlsTruststoreMountPath,
+		ReadOnly:  true,
+	}
+}
@@ -1,13 +1,13 @@
 package cf
 
 import (
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"go.uber.org/zap"
-	"os"
 
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
 
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
@@ -17,9 +17,12 @@ import (
 
 var _ loop.ControlFunction = &ImageCF{}
 
-const ENV_OPERATOR_REGISTRY_IMAGE_MEM = "REGISTRY_IMAGE_MEM"
-const ENV_OPERATOR_REGISTRY_IMAGE_KAFKASQL = "REGISTRY_IMAGE_KAFKASQL"
-const ENV_OPERATOR_REGISTRY_IMAGE_SQL = "REGISTRY_IMAGE_SQL"
+const ENV_OPERATOR_REGISTRY_IMAGE_MEM = common.ENV_REGISTRY_IMAGE_MEM
+const ENV_OPERATOR_REGISTRY_IMAGE_KAFKASQL = common.ENV_REGISTRY_IMAGE_KAFKASQL
+const ENV_OPERATOR_REGISTRY_IMAGE_SQL = common.ENV_REGISTRY_IMAGE_SQL
+
+// Annotation of the ApicurioRegistry resource, used to approve an upgrade with the manual upgrade policy
+const UpgradeApprovedImageAnnotation = "apicur.io/approved-upgrade-image"
 
 // This CF takes care of keeping the "image" section of the CRD applied.
 type ImageCF struct {
@@ -32,8 +35,9 @@ type ImageCF struct {
 	existingImage    string
 	targetImage      string
 	services         services.LoopServices
-	persistence      string
+	persistence      ar.ApicurioRegistryPersistence
 	persistenceError bool
+	upgradeImage     string
 }
 
 func NewImageCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
@@ -77,25 +81,40 @@ func (this *ImageCF) Sense() {
 	// Observation #3
 	// Get the target image name
 	this.persistence = ""
+	version := ""
+	upgradePolicy := ar.UpgradePolicyAutomatic
+	approvedImage := ""
 	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
 		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
 		this.persistence = spec.Configuration.Persistence
 		this.targetImage = spec.Deployment.Image
+		version = spec.Deployment.Version
+		if spec.Deployment.Upgrade.Policy != "" {
+			upgradePolicy = spec.Deployment.Upgrade.Policy
+		}
+		approvedImage = specEntry.GetValue().(*ar.ApicurioRegistry).Annotations[UpgradeApprovedImageAnnotation]
 	}
+	// The image or version is selected by the user, so the upgrade policy does not apply
+	pinned := this.targetImage != "" || version != ""
 
 	if this.targetImage == "" {
+		// The default images are provided by the Operator configuration
+		config := this.ctx.GetOperatorConfig().Get()
 		envImage := ""
 		this.persistenceError = false
 		switch this.persistence {
-		case "", "mem":
-			envImage = os.Getenv(ENV_OPERATOR_REGISTRY_IMAGE_MEM)
-		case "kafkasql":
-			envImage = os.Getenv(ENV_OPERATOR_REGISTRY_IMAGE_KAFKASQL)
-		case "sql":
-			envImage = os.Getenv(ENV_OPERATOR_REGISTRY_IMAGE_SQL)
+		case "", ar.PersistenceMem:
+			envImage = config.RegistryImageMem
+		case ar.PersistenceKafkasql:
+			envImage = config.RegistryImageKafkasql
+		case ar.PersistenceSql:
+			envImage = config.RegistryImageSql
 		}
 		if envImage != "" {
 			this.targetImage = envImage
+			if version != "" {
+				this.targetImage = replaceImageTag(envImage, version)
+			}
 		} else {
 			this.persistenceError = true
 			this.log.Warnw(
@@ -105,6 +124,27 @@ func (this *ImageCF) Sense() {
 		}
 	}
 
+	// Observation #4
+	// Apply the upgrade policy, if the default image has changed
+	this.upgradeImage = ""
+	if !pinned && this.existingImage != "" && this.targetImage != "" && this.existingImage != this.targetImage &&
+		isSameImageRepository(this.existingImage, this.targetImage) && approvedImage != this.targetImage {
+		if upgradePolicy == ar.UpgradePolicyManual ||
+			(upgradePolicy == ar.UpgradePolicyPatchOnly && !isPatchUpgrade(this.existingImage, this.targetImage)) {
+			this.log.Debugw("upgrade is waiting for approval", "image", this.targetImage)
+			this.upgradeImage = this.targetImage
+			this.targetImage = this.existingImage
+		}
+	}
+
+	// Observation #5
+	// Do not apply the image again if it has been rolled back, see ImageRollbackCF
+	failedImage := this.svcStatus.GetConfig(status.CFG_STA_UPGRADE_FAILED_IMAGE)
+	lastKnownGoodImage := this.svcStatus.GetConfig(status.CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE)
+	if failedImage != "" && failedImage == this.targetImage && lastKnownGoodImage != "" {
+		this.targetImage = lastKnownGoodImage
+	}
+
 	// Update state
 	this.svcStatus.SetConfig(status.CFG_STA_IMAGE, this.existingImage)
 }
@@ -116,27 +156,38 @@ func (this *ImageCF) Compare() bool {
 	// Deployment exists
 	// Condition #2
 	// Existing image is not the same as the target image (assuming it is never empty)
+	// Condition #3
+	// Upgrade is waiting for approval, report it once per reconciliation
 	return (this.deploymentEntry != nil && this.existingImage != this.targetImage) ||
-		(this.persistenceError && this.ctx.GetAttempts() == 0)
+		(this.persistenceError && this.ctx.GetAttempts() == 0) ||
+		(this.upgradeImage != "" && this.ctx.GetAttempts() == 0)
 }
 
 func (this *ImageCF) Respond() {
 	if this.persistenceError {
-		this.services.GetConditionManager().GetConfigurationErrorCondition().TransitionInvalidPersistence(this.persistence)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().TransitionInvalidPersistence(string(this.persistence))
 		this.services.GetConditionManager().GetReadyCondition().TransitionError()
 		return
 	}
 	// Response #1
 	// Patch the resource
-	this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
-		deployment := value.(*apps.Deployment).DeepCopy()
-		for i, c := range deployment.Spec.Template.Spec.Containers {
-			if c.Name == factory.REGISTRY_CONTAINER_NAME {
-				deployment.Spec.Template.Spec.Containers[i].Image = this.targetImage
-			}
-		} // TODO report a problem if not found?
-		return deployment
-	})
+	if this.deploymentEntry != nil && this.existingImage != this.targetImage {
+		this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+			deployment := value.(*apps.Deployment).DeepCopy()
+			for i, c := range deployment.Spec.Template.Spec.Containers {
+				if c.Name == factory.REGISTRY_CONTAINER_NAME {
+					deployment.Spec.Template.Spec.Containers[i].Image = this.targetImage
+				}
+			} // TODO report a problem if not found?
+			return deployment
+		})
+	}
+	// Response #2
+	// Report the upgrade waiting for approval
+	if this.upgradeImage != "" {
+		this.services.GetConditionManager().GetUpgradeAvailableCondition().
+			TransitionApprovalRequired(this.upgradeImage, UpgradeApprovedImageAnnotation)
+	}
 }
 
 func (this *ImageCF) Cleanup() bool {
@@ -0,0 +1,205 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
+	"go.uber.org/zap"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"time"
+)
+
+const DeploymentReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+const DefaultReadinessDeadlineSeconds = 600
+
+var _ loop.ControlFunction = &ImageRollbackCF{}
+
+// The image of the Deployment, and when it has been observed for the first time.
+// It is persisted, so the readiness deadline is not reset when the Operator is restarted.
+type imageRollbackCurrentImage struct {
+	Image string    `json:"image"`
+	Since time.Time `json:"since"`
+}
+
+// This CF keeps track of the last Apicurio Registry image that has been rolled out successfully.
+// If the rollout of a new image fails and the automatic rollback is enabled,
+// the image is recorded as failed, and ImageCF reverts the Deployment to the last known good image.
+type ImageRollbackCF struct {
+	ctx                context.LoopContext
+	log                *zap.SugaredLogger
+	services           services.LoopServices
+	svcResourceCache   resources.ResourceCache
+	svcStatus          *status.Status
+	statusRestored     bool
+	enabled            bool
+	readinessDeadline  time.Duration
+	spec               *ar.ApicurioRegistry
+	currentImage       string
+	currentImageSince  time.Time
+	rolledOut          bool
+	rolloutFailed      bool
+	lastKnownGoodImage string
+	failedImage        string
+	rollbackNeeded     bool
+}
+
+func NewImageRollbackCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &ImageRollbackCF{
+		ctx:              ctx,
+		services:         services,
+		svcResourceCache: ctx.GetResourceCache(),
+		svcStatus:        services.GetStatus(),
+		statusRestored:   false,
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *ImageRollbackCF) Describe() string {
+	return "ImageRollbackCF"
+}
+
+func (this *ImageRollbackCF) Sense() {
+	// Observation #1
+	// Restore the state from the status, in case the Operator has been restarted
+	if !this.statusRestored {
+		if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_STATUS); exists {
+			upgrade := entry.GetValue().(*ar.ApicurioRegistryStatus).Upgrade
+			this.lastKnownGoodImage = upgrade.LastKnownGoodImage
+			this.failedImage = upgrade.FailedImage
+			this.statusRestored = true
+		}
+	}
+
+	// Observation #2
+	// Read the config values
+	this.spec = nil
+	this.enabled = false
+	this.readinessDeadline = DefaultReadinessDeadlineSeconds * time.Second
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		this.spec = entry.GetValue().(*ar.ApicurioRegistry)
+		this.enabled = this.spec.Spec.Deployment.Upgrade.AutoRollback
+		if seconds := this.spec.Spec.Deployment.Upgrade.ReadinessDeadlineSeconds; seconds > 0 {
+			this.readinessDeadline = time.Duration(seconds) * time.Second
+		}
+	}
+
+	// Observation #3
+	// Get the rollout state of the current image
+	this.rolledOut = false
+	this.rolloutFailed = false
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT); exists {
+		deployment := entry.GetValue().(*apps.Deployment)
+		image := ""
+		if container := common.GetContainerByName(deployment.Spec.Template.Spec.Containers, factory.REGISTRY_CONTAINER_NAME); container != nil {
+			image = container.Image
+		}
+		current := imageRollbackCurrentImage{}
+		if !this.ctx.GetState().Get(state.STATE_KEY_IMAGE_ROLLBACK_CURRENT_IMAGE, &current) || current.Image != image {
+			current = imageRollbackCurrentImage{
+				Image: image,
+				Since: time.Now(),
+			}
+			this.ctx.GetState().Set(state.STATE_KEY_IMAGE_ROLLBACK_CURRENT_IMAGE, current)
+		}
+		this.currentImage = current.Image
+		this.currentImageSince = current.Since
+		// The Deployment is read from the cluster before the first attempt. During the following attempts,
+		// the cached Deployment can contain a new image that has been applied by ImageCF,
+		// together with the status of the previous image.
+		if this.ctx.GetAttempts() == 0 {
+			this.rolledOut = isDeploymentRolledOut(deployment)
+			this.rolloutFailed = IsDeploymentProgressDeadlineExceeded(deployment)
+		}
+	}
+
+	// Observation #4
+	// Update the last known good image
+	if this.rolledOut && this.currentImage != "" && this.currentImage != this.lastKnownGoodImage {
+		this.log.Infow("new image has been rolled out", "image", this.currentImage)
+		this.lastKnownGoodImage = this.currentImage
+		// The image has been changed after the rollback
+		this.failedImage = ""
+	}
+	if !this.enabled {
+		this.failedImage = ""
+	}
+
+	// Observation #5
+	// Has the rollout of a new image failed?
+	this.rollbackNeeded = this.enabled && this.ctx.GetAttempts() == 0 && !this.rolledOut &&
+		this.currentImage != "" && this.lastKnownGoodImage != "" &&
+		this.currentImage != this.lastKnownGoodImage && this.currentImage != this.failedImage &&
+		(this.rolloutFailed || time.Since(this.currentImageSince) > this.readinessDeadline)
+	if this.enabled && !this.rolledOut && !this.rollbackNeeded && this.currentImage != this.lastKnownGoodImage {
+		// Check the readiness deadline
+		this.ctx.SetRequeueDelaySec(60)
+	}
+
+	// Update the status
+	this.svcStatus.SetConfig(status.CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE, this.lastKnownGoodImage)
+	this.svcStatus.SetConfig(status.CFG_STA_UPGRADE_FAILED_IMAGE, this.failedImage)
+}
+
+func (this *ImageRollbackCF) Compare() bool {
+	// Condition #1
+	// Rollout of a new image has failed
+	// Condition #2
+	// Image has been rolled back, report it once per reconciliation
+	return this.rollbackNeeded ||
+		(this.failedImage != "" && this.ctx.GetAttempts() == 0)
+}
+
+func (this *ImageRollbackCF) Respond() {
+	// Response #1
+	// Record the image as failed, ImageCF will revert the Deployment
+	if this.rollbackNeeded {
+		this.log.Warnw("rollout of a new image has failed, reverting to the last known good image",
+			"failedImage", this.currentImage, "lastKnownGoodImage", this.lastKnownGoodImage)
+		this.failedImage = this.currentImage
+		this.svcStatus.SetConfig(status.CFG_STA_UPGRADE_FAILED_IMAGE, this.failedImage)
+		if this.spec != nil {
+			this.ctx.GetClients().Kube().RecordEvent(this.spec, core.EventTypeWarning, "AutoRollback",
+				"Image "+this.failedImage+" did not become ready, reverting to the last known good image "+this.lastKnownGoodImage)
+		}
+		this.rollbackNeeded = false
+	}
+	// Response #2
+	// Report the rollback
+	this.services.GetConditionManager().GetUpgradeRolledBackCondition().TransitionRolledBack(this.failedImage, this.lastKnownGoodImage)
+}
+
+func (this *ImageRollbackCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
+
+// All replicas have been updated and are available
+func isDeploymentRolledOut(deployment *apps.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Generation <= deployment.Status.ObservedGeneration &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
+
+func IsDeploymentProgressDeadlineExceeded(deployment *apps.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == apps.DeploymentProgressing && condition.Status == core.ConditionFalse &&
+			condition.Reason == DeploymentReasonProgressDeadlineExceeded {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"testing"
+	"time"
+)
+
+const goodImage = "quay.io/apicurio/apicurio-registry-sql:2.6.1.Final"
+const newImage = "quay.io/apicurio/apicurio-registry-sql:2.6.2.Final"
+
+var rolledOutStatus = apps.DeploymentStatus{
+	Replicas:          1,
+	UpdatedReplicas:   1,
+	AvailableReplicas: 1,
+}
+
+var progressingStatus = apps.DeploymentStatus{
+	Replicas:          2,
+	UpdatedReplicas:   1,
+	AvailableReplicas: 1,
+	Conditions: []apps.DeploymentCondition{
+		{Type: apps.DeploymentProgressing, Status: core.ConditionTrue, Reason: "ReplicaSetUpdated"},
+	},
+}
+
+var progressDeadlineExceededStatus = apps.DeploymentStatus{
+	Replicas:          2,
+	UpdatedReplicas:   1,
+	AvailableReplicas: 1,
+	Conditions: []apps.DeploymentCondition{
+		{Type: apps.DeploymentProgressing, Status: core.ConditionFalse, Reason: DeploymentReasonProgressDeadlineExceeded},
+	},
+}
+
+func newImageDeployment(image string, deploymentStatus apps.DeploymentStatus) *apps.Deployment {
+	deployment := &apps.Deployment{}
+	deployment.Spec.Template.Spec.Containers = []core.Container{
+		{Name: factory.REGISTRY_CONTAINER_NAME, Image: image},
+	}
+	deployment.Status = deploymentStatus
+	return deployment
+}
+
+func TestImageRollbackCF(t *testing.T) {
+	cases := []struct {
+		name string
+		// Configuration
+		autoRollback             bool
+		readinessDeadlineSeconds int32
+		// Persisted state
+		lastKnownGoodImage string
+		failedImage        string
+		since              time.Duration
+		// Observed Deployment
+		image  string
+		status apps.DeploymentStatus
+		// Expected result
+		rollbackNeeded             bool
+		expectedLastKnownGoodImage string
+		expectedRequeue            bool
+	}{
+		{
+			name:         "first rollout",
+			autoRollback: true, image: goodImage, status: rolledOutStatus,
+			expectedLastKnownGoodImage: goodImage,
+		},
+		{
+			name:         "new image rolled out",
+			autoRollback: true, lastKnownGoodImage: goodImage, image: newImage, status: rolledOutStatus,
+			expectedLastKnownGoodImage: newImage,
+		},
+		{
+			name:         "new image is progressing",
+			autoRollback: true, lastKnownGoodImage: goodImage, image: newImage, status: progressingStatus,
+			since:                      5 * time.Minute,
+			expectedLastKnownGoodImage: goodImage, expectedRequeue: true,
+		},
+		{
+			name:         "readiness deadline exceeded",
+			autoRollback: true, lastKnownGoodImage: goodImage, image: newImage, status: progressingStatus,
+			since:          11 * time.Minute,
+			rollbackNeeded: true, expectedLastKnownGoodImage: goodImage,
+		},
+		{
+			name:         "custom readiness deadline exceeded",
+			autoRollback: true, readinessDeadlineSeconds: 60, lastKnownGoodImage: goodImage, image: newImage, status: progressingStatus,
+			since:          2 * time.Minute,
+			rollbackNeeded: true, expectedLastKnownGoodImage: goodImage,
+		},
+		{
+			name:         "progress deadline exceeded before the readiness deadline",
+			autoRollback: true, lastKnownGoodImage: goodImage, image: newImage, status: progressDeadlineExceededStatus,
+			rollbackNeeded: true, expectedLastKnownGoodImage: goodImage,
+		},
+		{
+			name:         "rollback is disabled",
+			autoRollback: false, lastKnownGoodImage: goodImage, image: newImage, status: progressDeadlineExceededStatus,
+			since:                      11 * time.Minute,
+			expectedLastKnownGoodImage: goodImage,
+		},
+		{
+			name:         "image has already been rolled back",
+			autoRollback: true, lastKnownGoodImage: goodImage, failedImage: newImage, image: newImage, status: progressDeadlineExceededStatus,
+			expectedLastKnownGoodImage: goodImage, expectedRequeue: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &ar.ApicurioRegistry{}
+			spec.Spec.Deployment.Upgrade.AutoRollback = tc.autoRollback
+			spec.Spec.Deployment.Upgrade.ReadinessDeadlineSeconds = tc.readinessDeadlineSeconds
+			ctx := newLoopContextWithClientsMock(t, spec)
+			services := services2.NewLoopServicesMock(ctx)
+			ctx.GetResourceCache().Set(resources.RC_KEY_STATUS, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistryStatus{
+				Upgrade: ar.ApicurioRegistryStatusUpgrade{
+					LastKnownGoodImage: tc.lastKnownGoodImage,
+					FailedImage:        tc.failedImage,
+				},
+			}))
+			// The image has been observed before the Operator has been restarted
+			if tc.since > 0 {
+				ctx.GetState().Set(state.STATE_KEY_IMAGE_ROLLBACK_CURRENT_IMAGE, imageRollbackCurrentImage{
+					Image: tc.image,
+					Since: time.Now().Add(-tc.since),
+				})
+			}
+			ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT,
+				resources.NewResourceCacheEntry(ctx.GetAppName(), newImageDeployment(tc.image, tc.status)))
+			this := NewImageRollbackCF(ctx, services).(*ImageRollbackCF)
+
+			this.Sense()
+			c.AssertEquals(t, tc.rollbackNeeded, this.rollbackNeeded)
+			c.AssertEquals(t, tc.expectedLastKnownGoodImage, services.GetStatus().GetConfig(status.CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE))
+			requeue, _ := ctx.GetRequeueDelay()
+			c.AssertEquals(t, tc.expectedRequeue, requeue)
+			if this.Compare() {
+				this.Respond()
+			}
+			if tc.rollbackNeeded || tc.failedImage != "" {
+				c.AssertEquals(t, tc.image, services.GetStatus().GetConfig(status.CFG_STA_UPGRADE_FAILED_IMAGE))
+				c.AssertEquals(t, true, services.GetConditionManager().GetUpgradeRolledBackCondition().IsActive())
+			} else {
+				c.AssertEquals(t, "", services.GetStatus().GetConfig(status.CFG_STA_UPGRADE_FAILED_IMAGE))
+			}
+		})
+	}
+}
+
+func TestImageRollbackFailedImage(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Deployment.Image = newImage
+	spec.Spec.Deployment.Upgrade.AutoRollback = true
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	deploymentEntry := resources.NewResourceCacheEntry(ctx.GetAppName(), newImageDeployment(goodImage, rolledOutStatus))
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, deploymentEntry)
+	imageCF := NewImageCF(ctx, services)
+	rollbackCF := NewImageRollbackCF(ctx, services)
+	image := func() string {
+		return deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Spec.Containers[0].Image
+	}
+	lastKnownGoodImage := func() string {
+		return services.GetStatus().GetConfig(status.CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE)
+	}
+	// The Deployment is read from the cluster with the given status, and the control functions run until they are stable.
+	// The generation is increased when the image is updated, and is observed by Kubernetes only after the rollout has started.
+	generation := int64(1)
+	reconcile := func(deploymentStatus apps.DeploymentStatus, observed bool) {
+		deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+			deployment := value.(*apps.Deployment).DeepCopy()
+			deployment.Generation = generation
+			deployment.Status = deploymentStatus
+			if observed {
+				deployment.Status.ObservedGeneration = generation
+			}
+			return deployment
+		})
+		previous := image()
+		runUntilStable(t, ctx, rollbackCF, imageCF)
+		if image() != previous {
+			generation++
+		}
+	}
+
+	// The new image is applied, it is not a known good image until it has been rolled out
+	reconcile(rolledOutStatus, true)
+	c.AssertEquals(t, newImage, image())
+	c.AssertEquals(t, goodImage, lastKnownGoodImage())
+	reconcile(progressingStatus, false)
+	c.AssertEquals(t, newImage, image())
+	c.AssertEquals(t, goodImage, lastKnownGoodImage())
+
+	// The rollout fails, and the last known good image is restored
+	reconcile(progressDeadlineExceededStatus, true)
+	c.AssertEquals(t, newImage, services.GetStatus().GetConfig(status.CFG_STA_UPGRADE_FAILED_IMAGE))
+	c.AssertEquals(t, goodImage, image())
+	c.AssertEquals(t, goodImage, lastKnownGoodImage())
+
+	// The failed image is not applied again
+	reconcile(rolledOutStatus, true)
+	c.AssertEquals(t, goodImage, image())
+	c.AssertEquals(t, goodImage, lastKnownGoodImage())
+	imageCF.Sense()
+	c.AssertEquals(t, false, imageCF.Compare())
+
+	// A different image is applied, and becomes the last known good image after it has been rolled out
+	spec.Spec.Deployment.Image = "quay.io/apicurio/apicurio-registry-sql:2.6.3.Final"
+	reconcile(rolledOutStatus, true)
+	c.AssertEquals(t, spec.Spec.Deployment.Image, image())
+	c.AssertEquals(t, goodImage, lastKnownGoodImage())
+	reconcile(rolledOutStatus, true)
+	c.AssertEquals(t, spec.Spec.Deployment.Image, lastKnownGoodImage())
+	c.AssertEquals(t, "", services.GetStatus().GetConfig(status.CFG_STA_UPGRADE_FAILED_IMAGE))
+}
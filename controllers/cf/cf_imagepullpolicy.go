@@ -1,9 +1,9 @@
 package cf
 
 import (
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"go.uber.org/zap"
-	"os"
 
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
@@ -15,7 +15,7 @@ import (
 
 var _ loop.ControlFunction = &ImagePullPolicyCF{}
 
-const ENV_OPERATOR_REGISTRY_IMAGE_PULL_POLICY = "REGISTRY_IMAGE_PULL_POLICY"
+const ENV_OPERATOR_REGISTRY_IMAGE_PULL_POLICY = common.ENV_REGISTRY_IMAGE_PULL_POLICY
 
 type ImagePullPolicyCF struct {
 	ctx                     context.LoopContext
@@ -59,8 +59,10 @@ func (this *ImagePullPolicyCF) Sense() {
 		}
 
 		// Observation #3
-		// Get the target pod imagePullPolicy from the REGISTRY_IMAGE_PULL_POLICY env variable
-		envImagePullPolicy := os.Getenv(ENV_OPERATOR_REGISTRY_IMAGE_PULL_POLICY)
+		// Get the target pod imagePullPolicy from the Operator configuration,
+		// which defaults to the REGISTRY_IMAGE_PULL_POLICY env variable
+		envImagePullPolicy := this.ctx.GetOperatorConfig().Get().RegistryImagePullPolicy
+		this.targetImagePullPolicy = ""
 		switch envImagePullPolicy {
 		case string(core.PullAlways):
 			this.targetImagePullPolicy = core.PullAlways
@@ -71,8 +73,9 @@ func (this *ImagePullPolicyCF) Sense() {
 		}
 
 		if envImagePullPolicy != "" && this.targetImagePullPolicy == "" {
-			this.log.Warnw(envImagePullPolicy + " is not a valid value for " + ENV_OPERATOR_REGISTRY_IMAGE_PULL_POLICY + ". " +
-				ENV_OPERATOR_REGISTRY_IMAGE_PULL_POLICY + " can have one of the following values: Always, IfNotPresent, Never.")
+			this.log.Warnw(envImagePullPolicy + " is not a valid value for " + ENV_OPERATOR_REGISTRY_IMAGE_PULL_POLICY +
+				" or " + common.OPERATOR_CONFIG_REGISTRY_IMAGE_PULL_POLICY + ". " +
+				"It can have one of the following values: Always, IfNotPresent, Never.")
 		}
 	}
 }
@@ -1,7 +1,7 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
@@ -13,11 +13,11 @@ import (
 	"go.uber.org/zap"
 	core "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
-	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ loop.ControlFunction = &IngressCF{}
+var _ loop.CleanupDiscovery = &IngressCF{}
 
 type IngressCF struct {
 	ctx              context.LoopContext
@@ -62,8 +62,9 @@ func (this *IngressCF) Sense() {
 	// terminate execution if ingress is disabled
 	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
 		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		// On OpenShift, Routes are managed directly, see RouteOcpCF
 		this.disableIngress = spec.Deployment.ManagedResources.DisableIngress ||
-			spec.Deployment.Host == ""
+			spec.Deployment.Hosts.Primary == "" || this.ctx.GetSupportedFeatures().IsOCP
 		// Do cleanup in respond
 	}
 
@@ -170,14 +171,34 @@ func (this *IngressCF) Respond() {
 
 func (this *IngressCF) Cleanup() bool {
 	// Ingress should not have any deletion dependencies
-	if ingressEntry, ingressExists := this.svcResourceCache.Get(resources.RC_KEY_INGRESS); ingressExists {
-		if err := this.svcClients.Kube().DeleteIngress(ingressEntry.GetValue().(*networking.Ingress)); err != nil && !api_errors.IsNotFound(err) {
-			this.log.Errorw("could not delete Ingress", "error", err)
-			return false
-		} else {
-			this.svcResourceCache.Remove(resources.RC_KEY_INGRESS)
-			this.ctx.GetLog().Info("Ingress has been deleted")
+	return cleanupCachedResource(this.ctx, this.log, resources.RC_KEY_INGRESS, ar.ManagedResourceKindIngress,
+		func(value interface{}) error {
+			_, err := this.svcClients.Kube().UpdateIngress(this.ctx.GetAppNamespace(), value.(*networking.Ingress))
+			return err
+		},
+		func(value interface{}) error {
+			return this.svcClients.Kube().DeleteIngress(value.(*networking.Ingress))
+		})
+}
+
+func (this *IngressCF) DiscoverForCleanup() {
+	if _, exists := this.svcResourceCache.Get(resources.RC_KEY_INGRESS); exists {
+		return
+	}
+	ingresses, err := this.svcClients.Kube().GetIngresses(
+		this.ctx.GetAppNamespace(),
+		meta.ListOptions{
+			LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+		})
+	if err != nil {
+		this.log.Errorw("could not list Ingresses during cleanup", "error", err)
+		return
+	}
+	for i := range ingresses.Items {
+		ingress := &ingresses.Items[i]
+		if ingress.GetDeletionTimestamp() == nil && IsOwnedByApp(this.ctx, ingress) {
+			this.svcResourceCache.Set(resources.RC_KEY_INGRESS, resources.NewResourceCacheEntry(common.Name(ingress.Name), ingress))
+			return
 		}
 	}
-	return true
 }
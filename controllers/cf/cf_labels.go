@@ -1,71 +1,96 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	ocp_route "github.com/openshift/api/route/v1"
+	monitoring "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"go.uber.org/zap"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	policy_v1 "k8s.io/api/policy/v1"
 	policy_v1beta1 "k8s.io/api/policy/v1beta1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ loop.ControlFunction = &LabelsCF{}
 
 type LabelsCF struct {
 	ctx              context.LoopContext
+	log              *zap.SugaredLogger
 	svcResourceCache resources.ResourceCache
+	svcClients       *client.Clients
 	svcKubeFactory   *factory.KubeFactory
 
-	caLabels map[string]string
+	caLabels      map[string]string
+	caAnnotations map[string]string
 
 	deploymentEntry               resources.ResourceCacheEntry
 	deploymentIsCached            bool
 	deploymentLabels              map[string]string
+	deploymentAnnotations         map[string]string
 	deploymentPodLabels           map[string]string
 	additionalDeploymentPodLabels map[string]string
 	targetDeploymentPodLabels     map[string]string
 	updateDeployment              bool
 	updateDeploymentPod           bool
 
+	// Service annotations are managed by ServiceConfigCF
 	serviceEntry    resources.ResourceCacheEntry
 	serviceIsCached bool
 	serviceLabels   map[string]string
 	updateService   bool
 
-	ingressEntry    resources.ResourceCacheEntry
-	ingressIsCached bool
-	ingressLabels   map[string]string
-	updateIngress   bool
-
-	pdbV1beta1Entry    resources.ResourceCacheEntry
-	pdbV1beta1IsCached bool
-	pdbV1beta1Labels   map[string]string
-	updatePdbV1beta1   bool
-
-	pdbV1Entry    resources.ResourceCacheEntry
-	pdbV1IsCached bool
-	pdbV1Labels   map[string]string
-	updatePdbV1   bool
-
-	networkPolicyEntry    resources.ResourceCacheEntry
-	networkPolicyIsCached bool
-	networkPolicyLabels   map[string]string
-	updateNetworkPolicy   bool
+	ingressEntry       resources.ResourceCacheEntry
+	ingressIsCached    bool
+	ingressLabels      map[string]string
+	ingressAnnotations map[string]string
+	updateIngress      bool
+
+	pdbV1beta1Entry       resources.ResourceCacheEntry
+	pdbV1beta1IsCached    bool
+	pdbV1beta1Labels      map[string]string
+	pdbV1beta1Annotations map[string]string
+	updatePdbV1beta1      bool
+
+	pdbV1Entry       resources.ResourceCacheEntry
+	pdbV1IsCached    bool
+	pdbV1Labels      map[string]string
+	pdbV1Annotations map[string]string
+	updatePdbV1      bool
+
+	networkPolicyEntry       resources.ResourceCacheEntry
+	networkPolicyIsCached    bool
+	networkPolicyLabels      map[string]string
+	networkPolicyAnnotations map[string]string
+	updateNetworkPolicy      bool
+
+	// Routes and ServiceMonitor are not in the resource cache
+	routes         []ocp_route.Route
+	routesToUpdate []*ocp_route.Route
+
+	serviceMonitor       *monitoring.ServiceMonitor
+	updateServiceMonitor bool
 }
 
-// Update labels on some managed resources
+// Update labels and annotations on the managed resources
 func NewLabelsCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
-	return &LabelsCF{
+	res := &LabelsCF{
 		ctx:              ctx,
 		svcResourceCache: ctx.GetResourceCache(),
+		svcClients:       ctx.GetClients(),
 		svcKubeFactory:   services.GetKubeFactory(),
 	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
 }
 
 func (this *LabelsCF) Describe() string {
@@ -78,6 +103,7 @@ func (this *LabelsCF) Sense() {
 	this.deploymentEntry, this.deploymentIsCached = this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT)
 	if this.deploymentIsCached {
 		this.deploymentLabels = this.deploymentEntry.GetValue().(*apps.Deployment).Labels
+		this.deploymentAnnotations = this.deploymentEntry.GetValue().(*apps.Deployment).Annotations
 		this.deploymentPodLabels = this.deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Labels
 	}
 	// Get any additional Deployment Pod labels from the spec
@@ -95,35 +121,80 @@ func (this *LabelsCF) Sense() {
 	this.ingressEntry, this.ingressIsCached = this.svcResourceCache.Get(resources.RC_KEY_INGRESS)
 	if this.ingressIsCached {
 		this.ingressLabels = this.ingressEntry.GetValue().(*networking.Ingress).Labels
+		this.ingressAnnotations = this.ingressEntry.GetValue().(*networking.Ingress).Annotations
 	}
 	// Observation #4
 	// PodDisruptionBudget
 	this.pdbV1beta1Entry, this.pdbV1beta1IsCached = this.svcResourceCache.Get(resources.RC_KEY_POD_DISRUPTION_BUDGET_V1BETA1)
 	if this.pdbV1beta1IsCached {
 		this.pdbV1beta1Labels = this.pdbV1beta1Entry.GetValue().(*policy_v1beta1.PodDisruptionBudget).Labels
+		this.pdbV1beta1Annotations = this.pdbV1beta1Entry.GetValue().(*policy_v1beta1.PodDisruptionBudget).Annotations
 	}
 	this.pdbV1Entry, this.pdbV1IsCached = this.svcResourceCache.Get(resources.RC_KEY_POD_DISRUPTION_BUDGET_V1)
 	if this.pdbV1IsCached {
 		this.pdbV1Labels = this.pdbV1Entry.GetValue().(*policy_v1.PodDisruptionBudget).Labels
+		this.pdbV1Annotations = this.pdbV1Entry.GetValue().(*policy_v1.PodDisruptionBudget).Annotations
 	}
 	// Observation #5
 	// NetworkPolicy
 	this.networkPolicyEntry, this.networkPolicyIsCached = this.svcResourceCache.Get(resources.RC_KEY_NETWORK_POLICY)
 	if this.networkPolicyIsCached {
 		this.networkPolicyLabels = this.networkPolicyEntry.GetValue().(*networking.NetworkPolicy).Labels
+		this.networkPolicyAnnotations = this.networkPolicyEntry.GetValue().(*networking.NetworkPolicy).Annotations
+	}
+	// Improve speed by avoiding unnecessary API requests,
+	// the Routes and ServiceMonitor are kept up to date by Response #7 and #8 during the following attempts
+	if this.ctx.GetAttempts() > 0 {
+		return
+	}
+	// Observation #6
+	// Routes, skipping those created by OpenShift for an Ingress
+	this.routes = make([]ocp_route.Route, 0)
+	if this.ctx.GetSupportedFeatures().IsOCP {
+		routes, err := this.svcClients.OCP().GetRoutes(this.ctx.GetAppNamespace(), &meta.ListOptions{
+			LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+		})
+		if err != nil {
+			this.log.Errorw("could not list Routes", "error", err)
+		} else {
+			for _, route := range routes.Items {
+				if route.GetObjectMeta().GetDeletionTimestamp() == nil && !isOwnedByIngress(&route) {
+					this.routes = append(this.routes, route)
+				}
+			}
+		}
+	}
+	// Observation #7
+	// ServiceMonitor
+	this.serviceMonitor = nil
+	if this.ctx.GetSupportedFeatures().SupportsMonitoring {
+		serviceMonitor, err := this.svcClients.Monitoring().GetServiceMonitor(this.ctx.GetAppNamespace(), this.ctx.GetAppName())
+		if err == nil {
+			this.serviceMonitor = serviceMonitor
+		} else if !api_errors.IsNotFound(err) {
+			this.log.Errorw("could not get ServiceMonitor", "error", err)
+		}
 	}
 }
 
 func (this *LabelsCF) Compare() bool {
 	this.caLabels = this.GetCommonApplicationLabels()
-	this.updateDeployment = this.deploymentIsCached && !common.LabelsEqual(this.deploymentLabels, this.caLabels)
+	this.caAnnotations = this.svcKubeFactory.GetAnnotations()
+	this.updateDeployment = this.deploymentIsCached && !this.isMetadataEqual(this.deploymentLabels, this.deploymentAnnotations)
 	this.targetDeploymentPodLabels = this.GetTargetDeploymentPodLabels()
 	this.updateDeploymentPod = this.deploymentIsCached && !common.LabelsEqual(this.deploymentPodLabels, this.targetDeploymentPodLabels)
 	this.updateService = this.serviceIsCached && !common.LabelsEqual(this.serviceLabels, this.caLabels)
-	this.updateIngress = this.ingressIsCached && !common.LabelsEqual(this.ingressLabels, this.caLabels)
-	this.updatePdbV1beta1 = this.pdbV1beta1IsCached && !common.LabelsEqual(this.pdbV1beta1Labels, this.caLabels)
-	this.updatePdbV1 = this.pdbV1IsCached && !common.LabelsEqual(this.pdbV1Labels, this.caLabels)
-	this.updateNetworkPolicy = this.networkPolicyIsCached && !common.LabelsEqual(this.networkPolicyLabels, this.caLabels)
+	this.updateIngress = this.ingressIsCached && !this.isMetadataEqual(this.ingressLabels, this.ingressAnnotations)
+	this.updatePdbV1beta1 = this.pdbV1beta1IsCached && !this.isMetadataEqual(this.pdbV1beta1Labels, this.pdbV1beta1Annotations)
+	this.updatePdbV1 = this.pdbV1IsCached && !this.isMetadataEqual(this.pdbV1Labels, this.pdbV1Annotations)
+	this.updateNetworkPolicy = this.networkPolicyIsCached && !this.isMetadataEqual(this.networkPolicyLabels, this.networkPolicyAnnotations)
+	this.routesToUpdate = make([]*ocp_route.Route, 0)
+	for i := range this.routes {
+		if !this.isMetadataEqual(this.routes[i].Labels, this.routes[i].Annotations) {
+			this.routesToUpdate = append(this.routesToUpdate, &this.routes[i])
+		}
+	}
+	this.updateServiceMonitor = this.serviceMonitor != nil && !this.isMetadataEqual(this.serviceMonitor.Labels, this.serviceMonitor.Annotations)
 
 	return this.updateDeployment ||
 		this.updateDeploymentPod ||
@@ -131,7 +202,9 @@ func (this *LabelsCF) Compare() bool {
 		this.updateIngress ||
 		this.updatePdbV1beta1 ||
 		this.updatePdbV1 ||
-		this.updateNetworkPolicy
+		this.updateNetworkPolicy ||
+		len(this.routesToUpdate) > 0 ||
+		this.updateServiceMonitor
 }
 
 func (this *LabelsCF) Respond() {
@@ -141,6 +214,7 @@ func (this *LabelsCF) Respond() {
 		this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
 			deployment := value.(*apps.Deployment).DeepCopy()
 			common.LabelsUpdate(&deployment.Labels, this.caLabels)
+			common.LabelsUpdate(&deployment.Annotations, this.caAnnotations)
 			return deployment
 		})
 	}
@@ -168,6 +242,7 @@ func (this *LabelsCF) Respond() {
 		this.ingressEntry.ApplyPatch(func(value interface{}) interface{} {
 			ingress := value.(*networking.Ingress).DeepCopy()
 			common.LabelsUpdate(&ingress.Labels, this.caLabels)
+			common.LabelsUpdate(&ingress.Annotations, this.caAnnotations)
 			return ingress
 		})
 	}
@@ -177,6 +252,7 @@ func (this *LabelsCF) Respond() {
 		this.pdbV1beta1Entry.ApplyPatch(func(value interface{}) interface{} {
 			pdb := value.(*policy_v1beta1.PodDisruptionBudget).DeepCopy()
 			common.LabelsUpdate(&pdb.Labels, this.caLabels)
+			common.LabelsUpdate(&pdb.Annotations, this.caAnnotations)
 			return pdb
 		})
 	}
@@ -184,18 +260,50 @@ func (this *LabelsCF) Respond() {
 		this.pdbV1Entry.ApplyPatch(func(value interface{}) interface{} {
 			pdb := value.(*policy_v1.PodDisruptionBudget).DeepCopy()
 			common.LabelsUpdate(&pdb.Labels, this.caLabels)
+			common.LabelsUpdate(&pdb.Annotations, this.caAnnotations)
 			return pdb
 		})
 	}
-	// Response #4
+	// Response #6
 	// NetworkPolicy
 	if this.updateNetworkPolicy {
 		this.networkPolicyEntry.ApplyPatch(func(value interface{}) interface{} {
 			policy := value.(*networking.NetworkPolicy).DeepCopy()
 			common.LabelsUpdate(&policy.Labels, this.caLabels)
+			common.LabelsUpdate(&policy.Annotations, this.caAnnotations)
 			return policy
 		})
 	}
+	// Response #7
+	// Routes
+	for _, route := range this.routesToUpdate {
+		target := route.DeepCopy()
+		common.LabelsUpdate(&target.Labels, this.caLabels)
+		common.LabelsUpdate(&target.Annotations, this.caAnnotations)
+		updated, err := this.svcClients.OCP().UpdateRoute(this.ctx.GetAppNamespace(), target)
+		if err != nil {
+			this.log.Errorw("could not update Route", "name", target.Name, "error", err)
+			this.ctx.SetRequeueDelaySoon()
+			// Retried in the next run, when the Routes are listed again
+			updated = target
+		}
+		*route = *updated
+	}
+	// Response #8
+	// ServiceMonitor
+	if this.updateServiceMonitor {
+		serviceMonitor := this.serviceMonitor.DeepCopy()
+		common.LabelsUpdate(&serviceMonitor.Labels, this.caLabels)
+		common.LabelsUpdate(&serviceMonitor.Annotations, this.caAnnotations)
+		updated, err := this.svcClients.Monitoring().UpdateServiceMonitor(this.ctx.GetAppNamespace(), serviceMonitor)
+		if err != nil {
+			this.log.Errorw("could not update ServiceMonitor", "error", err)
+			this.ctx.SetRequeueDelaySoon()
+			// Retried in the next run, when the ServiceMonitor is read again
+			updated = serviceMonitor
+		}
+		this.serviceMonitor = updated
+	}
 }
 
 func (this *LabelsCF) Cleanup() bool {
@@ -209,6 +317,11 @@ func (this *LabelsCF) GetCommonApplicationLabels() map[string]string {
 	return this.svcKubeFactory.GetLabels()
 }
 
+// Labels and annotations that are removed from the spec are not removed from the resources
+func (this *LabelsCF) isMetadataEqual(labels map[string]string, annotations map[string]string) bool {
+	return common.LabelsEqual(labels, this.caLabels) && common.LabelsEqual(annotations, this.caAnnotations)
+}
+
 func (this *LabelsCF) GetTargetDeploymentPodLabels() map[string]string {
 	targetDeploymentPodLabels := make(map[string]string)
 	common.LabelsUpdate(&targetDeploymentPodLabels, this.GetCommonApplicationLabels())
@@ -0,0 +1,79 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	ocp_route "github.com/openshift/api/route/v1"
+	monitoring "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestLabelsCFRoutesAndServiceMonitor(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	ctx := newLoopContextWithClientsMock(t, spec)
+	ctx.SetSupportedFeatures(&c.SupportedFeatures{IsOCP: true, SupportsMonitoring: true})
+	services := services2.NewLoopServicesMock(ctx)
+	clients := ctx.GetClients()
+	namespace := ctx.GetAppNamespace()
+	this := NewLabelsCF(ctx, services)
+	labels := services.GetKubeFactory().GetLabels()
+
+	newRoute := func(name string) {
+		_, err := clients.OCP().CreateRoute(spec, namespace, &ocp_route.Route{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      name,
+				Namespace: namespace.Str(),
+				Labels:    map[string]string{"app": ctx.GetAppName().Str()},
+			},
+		})
+		c.AssertEquals(t, nil, err)
+	}
+	getRouteLabels := func(name string) map[string]string {
+		routes, err := clients.OCP().GetRoutes(namespace, &meta.ListOptions{})
+		c.AssertEquals(t, nil, err)
+		for _, route := range routes.Items {
+			if route.Name == name {
+				return route.Labels
+			}
+		}
+		return nil
+	}
+	newRoute("mock-route")
+	_, err := clients.Monitoring().CreateServiceMonitor(spec, namespace, &monitoring.ServiceMonitor{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      ctx.GetAppName().Str(),
+			Namespace: namespace.Str(),
+		},
+	})
+	c.AssertEquals(t, nil, err)
+
+	// The Route and ServiceMonitor are read on the first attempt, and updated
+	ctx.SetAttempts(0)
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, labels, getRouteLabels("mock-route"))
+	serviceMonitor, err := clients.Monitoring().GetServiceMonitor(namespace, ctx.GetAppName())
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, labels, serviceMonitor.Labels)
+
+	// The updated resources are kept, so the following attempts are stable without further requests
+	ctx.SetAttempts(1)
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The Routes are not listed again until the next run
+	newRoute("mock-route-2")
+	ctx.SetAttempts(2)
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+	c.AssertEquals(t, map[string]string{"app": ctx.GetAppName().Str()}, getRouteLabels("mock-route-2"))
+
+	ctx.SetAttempts(0)
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, labels, getRouteLabels("mock-route-2"))
+}
@@ -1,7 +1,7 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
@@ -12,11 +12,11 @@ import (
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
 	"go.uber.org/zap"
 	networking "k8s.io/api/networking/v1"
-	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ loop.ControlFunction = &NetworkPolicyCF{}
+var _ loop.CleanupDiscovery = &NetworkPolicyCF{}
 
 type NetworkPolicyCF struct {
 	ctx               context.LoopContext
@@ -154,14 +154,34 @@ func (this *NetworkPolicyCF) Respond() {
 
 func (this *NetworkPolicyCF) Cleanup() bool {
 	// Network Policy should not have any deletion dependencies
-	if networkPolicyEntry, networkPolicyExists := this.svcResourceCache.Get(resources.RC_KEY_NETWORK_POLICY); networkPolicyExists {
-		if err := this.svcClients.Kube().DeleteNetworkPolicy(networkPolicyEntry.GetValue().(*networking.NetworkPolicy)); err != nil && !api_errors.IsNotFound(err) {
-			this.log.Errorw("could not delete NetworkPolicy", "error", err)
-			return false
-		} else {
-			this.svcResourceCache.Remove(resources.RC_KEY_NETWORK_POLICY)
-			this.ctx.GetLog().Info("NetworkPolicy has been deleted")
+	return cleanupCachedResource(this.ctx, this.log, resources.RC_KEY_NETWORK_POLICY, ar.ManagedResourceKindNetworkPolicy,
+		func(value interface{}) error {
+			_, err := this.svcClients.Kube().UpdateNetworkPolicy(this.ctx.GetAppNamespace(), value.(*networking.NetworkPolicy))
+			return err
+		},
+		func(value interface{}) error {
+			return this.svcClients.Kube().DeleteNetworkPolicy(value.(*networking.NetworkPolicy))
+		})
+}
+
+func (this *NetworkPolicyCF) DiscoverForCleanup() {
+	if _, exists := this.svcResourceCache.Get(resources.RC_KEY_NETWORK_POLICY); exists {
+		return
+	}
+	networkPolicies, err := this.svcClients.Kube().GetNetworkPolicies(
+		this.ctx.GetAppNamespace(),
+		meta.ListOptions{
+			LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+		})
+	if err != nil {
+		this.log.Errorw("could not list NetworkPolicies during cleanup", "error", err)
+		return
+	}
+	for i := range networkPolicies.Items {
+		networkPolicy := &networkPolicies.Items[i]
+		if networkPolicy.GetDeletionTimestamp() == nil && IsOwnedByApp(this.ctx, networkPolicy) {
+			this.svcResourceCache.Set(resources.RC_KEY_NETWORK_POLICY, resources.NewResourceCacheEntry(common.Name(networkPolicy.Name), networkPolicy))
+			return
 		}
 	}
-	return true
 }
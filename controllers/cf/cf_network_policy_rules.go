@@ -0,0 +1,114 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"reflect"
+)
+
+var _ loop.ControlFunction = &NetworkPolicyRulesCF{}
+
+// This CF restricts the NetworkPolicy created by NetworkPolicyCF.
+// The ingress rules for the Apicurio Registry ports are managed by HttpsCF, this CF only sets the allowed peers.
+type NetworkPolicyRulesCF struct {
+	ctx                 context.LoopContext
+	log                 *zap.SugaredLogger
+	svcResourceCache    resources.ResourceCache
+	networkPolicyEntry  resources.ResourceCacheEntry
+	targetFrom          []networking.NetworkPolicyPeer
+	targetEgress        []networking.NetworkPolicyEgressRule
+	targetPolicyTypes   []networking.PolicyType
+	ingressFromUpToDate bool
+	egressUpToDate      bool
+}
+
+func NewNetworkPolicyRulesCF(ctx context.LoopContext) loop.ControlFunction {
+	res := &NetworkPolicyRulesCF{
+		ctx:              ctx,
+		svcResourceCache: ctx.GetResourceCache(),
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *NetworkPolicyRulesCF) Describe() string {
+	return "NetworkPolicyRulesCF"
+}
+
+func (this *NetworkPolicyRulesCF) Sense() {
+	// Observation #1
+	// Read the config values and compute the target rules
+	this.targetFrom = nil
+	this.targetEgress = nil
+	this.targetPolicyTypes = []networking.PolicyType{networking.PolicyTypeIngress}
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		if len(spec.Deployment.NetworkPolicy.Ingress.From) > 0 {
+			this.targetFrom = spec.Deployment.NetworkPolicy.Ingress.From
+		}
+		if spec.Deployment.NetworkPolicy.Egress.Enabled {
+			this.targetEgress = NewConfigEgressRules(&spec)
+			for _, rule := range spec.Deployment.NetworkPolicy.Egress.Rules {
+				rule := *rule.DeepCopy()
+				// Set the default value, so the rule does not appear to be changed after it is read back
+				for i := range rule.Ports {
+					if rule.Ports[i].Protocol == nil {
+						tcp := core.ProtocolTCP
+						rule.Ports[i].Protocol = &tcp
+					}
+				}
+				this.targetEgress = append(this.targetEgress, rule)
+			}
+			this.targetPolicyTypes = append(this.targetPolicyTypes, networking.PolicyTypeEgress)
+		}
+	}
+
+	// Observation #2
+	// Compare with the NetworkPolicy
+	this.networkPolicyEntry = nil
+	this.ingressFromUpToDate = true
+	this.egressUpToDate = true
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_NETWORK_POLICY); exists {
+		this.networkPolicyEntry = entry
+		policy := entry.GetValue().(*networking.NetworkPolicy)
+		for _, rule := range policy.Spec.Ingress {
+			if !reflect.DeepEqual(rule.From, this.targetFrom) {
+				this.ingressFromUpToDate = false
+			}
+		}
+		this.egressUpToDate = reflect.DeepEqual(policy.Spec.Egress, this.targetEgress) &&
+			reflect.DeepEqual(policy.Spec.PolicyTypes, this.targetPolicyTypes)
+	}
+}
+
+func (this *NetworkPolicyRulesCF) Compare() bool {
+	// Condition #1
+	// NetworkPolicy exists
+	// Condition #2
+	// Ingress peers or egress rules are not up to date
+	return this.networkPolicyEntry != nil && (!this.ingressFromUpToDate || !this.egressUpToDate)
+}
+
+func (this *NetworkPolicyRulesCF) Respond() {
+	// Response #1
+	// Patch the NetworkPolicy
+	this.networkPolicyEntry.ApplyPatch(func(value interface{}) interface{} {
+		policy := value.(*networking.NetworkPolicy).DeepCopy()
+		for i := range policy.Spec.Ingress {
+			policy.Spec.Ingress[i].From = this.targetFrom
+		}
+		policy.Spec.Egress = this.targetEgress
+		policy.Spec.PolicyTypes = this.targetPolicyTypes
+		return policy
+	})
+}
+
+func (this *NetworkPolicyRulesCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
@@ -0,0 +1,268 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
+	"go.uber.org/zap"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"reflect"
+)
+
+var _ loop.ControlFunction = &PodDisruptionBudgetCF{}
+var _ loop.CleanupDiscovery = &PodDisruptionBudgetCF{}
+
+// Operations that depend on the PodDisruptionBudget API version
+type podDisruptionBudgetApi interface {
+	// Version as reported by SupportedFeatures.PreferredPDBVersion
+	Version() string
+	CacheKey() string
+	// List the PodDisruptionBudgets that are not being deleted
+	List(options meta.ListOptions) ([]meta.Object, error)
+	Create() meta.Object
+	// Updates the metadata, used to remove the owner reference
+	Update(value interface{}) error
+	Delete(value interface{}) error
+	GetSpec(value interface{}) podDisruptionBudgetSpec
+	// Returns a patched copy of the value
+	SetSpec(value interface{}, spec podDisruptionBudgetSpec) interface{}
+}
+
+// Fields of the PodDisruptionBudget spec managed by the Operator, which are the same in all API versions
+type podDisruptionBudgetSpec struct {
+	MinAvailable               *intstr.IntOrString
+	MaxUnavailable             *intstr.IntOrString
+	UnhealthyPodEvictionPolicy string
+}
+
+type PodDisruptionBudgetCF struct {
+	ctx                     context.LoopContext
+	log                     *zap.SugaredLogger
+	api                     podDisruptionBudgetApi
+	svcResourceCache        resources.ResourceCache
+	svcClients              *client.Clients
+	svcKubeFactory          *factory.KubeFactory
+	svcStatus               *status.Status
+	services                services.LoopServices
+	isCached                bool
+	podDisruptionBudgets    []meta.Object
+	podDisruptionBudgetName string
+	isPreferred             bool
+	disabled                bool
+	targetSpec              podDisruptionBudgetSpec
+	specUpToDate            bool
+	// The last unhealthy pod eviction policy set by the Operator, see isPodDisruptionBudgetSpecUpToDate
+	appliedEvictionPolicy string
+}
+
+func newPodDisruptionBudgetCF(ctx context.LoopContext, services services.LoopServices, api podDisruptionBudgetApi) loop.ControlFunction {
+	res := &PodDisruptionBudgetCF{
+		ctx:                     ctx,
+		api:                     api,
+		svcResourceCache:        ctx.GetResourceCache(),
+		svcClients:              ctx.GetClients(),
+		svcKubeFactory:          services.GetKubeFactory(),
+		svcStatus:               services.GetStatus(),
+		services:                services,
+		isCached:                false,
+		podDisruptionBudgets:    make([]meta.Object, 0),
+		podDisruptionBudgetName: resources.RC_NOT_CREATED_NAME_EMPTY,
+		isPreferred:             false,
+		specUpToDate:            true,
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *PodDisruptionBudgetCF) Describe() string {
+	if this.api.Version() == "v1beta1" {
+		return "PodDisruptionBudgetV1beta1CF"
+	}
+	return "PodDisruptionBudgetV1CF"
+}
+
+func (this *PodDisruptionBudgetCF) Sense() {
+	this.isPreferred = this.ctx.GetSupportedFeatures().PreferredPDBVersion == this.api.Version()
+
+	// Observation #1
+	// Get cached PodDisruptionBudget
+	pdbEntry, pdbExists := this.svcResourceCache.Get(this.api.CacheKey())
+	if pdbExists {
+		this.podDisruptionBudgetName = pdbEntry.GetName().Str()
+	} else {
+		this.podDisruptionBudgetName = resources.RC_NOT_CREATED_NAME_EMPTY
+	}
+	this.isCached = pdbExists
+
+	// Observation #2
+	// Get PodDisruptionBudget(s) we *should* track
+	this.podDisruptionBudgets = make([]meta.Object, 0)
+	podDisruptionBudgets, err := this.api.List(meta.ListOptions{
+		LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+	})
+	if err == nil {
+		this.podDisruptionBudgets = podDisruptionBudgets
+	}
+
+	// Observation #3
+	// Read the config values
+	this.disabled = false
+	this.targetSpec = podDisruptionBudgetSpec{}
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := entry.GetValue().(*ar.ApicurioRegistry).Spec
+		this.disabled = this.disabled || spec.Deployment.ManagedResources.DisablePodDisruptionBudget
+		// A PodDisruptionBudget for a single replica would block node drains
+		this.disabled = this.disabled || spec.Deployment.Replicas <= 1
+
+		pdbSpec := spec.Deployment.PodDisruptionBudget
+		if pdbSpec.MinAvailable != nil && pdbSpec.MaxUnavailable != nil {
+			this.log.Errorw("only one of minAvailable and maxUnavailable can be set")
+			this.services.GetConditionManager().GetConfigurationErrorCondition().
+				TransitionInvalid(pdbSpec.MinAvailable.String(), "spec.deployment.podDisruptionBudget.minAvailable")
+			this.disabled = true
+		}
+		this.targetSpec = podDisruptionBudgetSpec{
+			MinAvailable:               pdbSpec.MinAvailable,
+			MaxUnavailable:             pdbSpec.MaxUnavailable,
+			UnhealthyPodEvictionPolicy: pdbSpec.UnhealthyPodEvictionPolicy,
+		}
+		if this.targetSpec.MinAvailable == nil && this.targetSpec.MaxUnavailable == nil {
+			maxUnavailable := intstr.FromInt(1)
+			this.targetSpec.MaxUnavailable = &maxUnavailable
+		}
+	}
+
+	// Observation #4
+	// Is the spec of the cached PodDisruptionBudget up to date?
+	this.specUpToDate = true
+	if pdbExists && !this.disabled {
+		this.specUpToDate = isPodDisruptionBudgetSpecUpToDate(this.api.GetSpec(pdbEntry.GetValue()), this.targetSpec, this.appliedEvictionPolicy)
+	}
+
+	if this.disabled {
+		this.log.Debugw("PodDisruptionBudget is disabled")
+	} else {
+		this.log.Debugw("PodDisruptionBudget is enabled")
+	}
+
+	// Update the status
+	if this.isPreferred {
+		this.svcStatus.SetConfig(status.CFG_STA_POD_DISRUPTION_BUDGET_NAME, this.podDisruptionBudgetName)
+	}
+}
+
+func (this *PodDisruptionBudgetCF) Compare() bool {
+	// Condition #1
+	// PodDisruptionBudget is preferred, while cached and at the same time it is disabled (or vice versa)
+	// Condition #2
+	// If this version is not preferred, we will try to remove it if it exists,
+	// so the other CF can create the preferred version instead
+	// Condition #3
+	// The spec of the cached PodDisruptionBudget is not up to date
+	return (this.isPreferred && this.isCached == this.disabled) ||
+		(!this.isPreferred && len(this.podDisruptionBudgets) > 0) ||
+		(this.isPreferred && this.isCached && !this.specUpToDate)
+}
+
+func (this *PodDisruptionBudgetCF) Respond() {
+	// Response #1
+	// We already know about a PodDisruptionBudget (name), and it is in the list
+	if this.podDisruptionBudgetName != resources.RC_NOT_CREATED_NAME_EMPTY {
+		contains := false
+		for _, val := range this.podDisruptionBudgets {
+			if val.GetName() == this.podDisruptionBudgetName {
+				contains = true
+				this.svcResourceCache.Set(this.api.CacheKey(), resources.NewResourceCacheEntry(common.Name(val.GetName()), val))
+				break
+			}
+		}
+		if !contains {
+			this.podDisruptionBudgetName = resources.RC_NOT_CREATED_NAME_EMPTY
+		}
+	}
+	// Response #2
+	// Can follow #1, but there must be a single PodDisruptionBudget available
+	if this.podDisruptionBudgetName == resources.RC_NOT_CREATED_NAME_EMPTY && len(this.podDisruptionBudgets) == 1 {
+		podDisruptionBudget := this.podDisruptionBudgets[0]
+		this.podDisruptionBudgetName = podDisruptionBudget.GetName()
+		this.svcResourceCache.Set(this.api.CacheKey(), resources.NewResourceCacheEntry(common.Name(podDisruptionBudget.GetName()), podDisruptionBudget))
+	}
+
+	// If this version is not preferred, try to remove it and return
+	if !this.isPreferred {
+		this.svcResourceCache.Remove(this.api.CacheKey())
+		for _, v := range this.podDisruptionBudgets {
+			if err := this.api.Delete(v); err != nil && !api_errors.IsNotFound(err) {
+				this.log.Errorw("could not delete PodDisruptionBudget", "name", v.GetName(), "error", err)
+			}
+		}
+		return
+	}
+
+	// Response #3 (and #4)
+	// If there is no service PodDisruptionBudget (or there are more than 1),
+	// create a new one IF not disabled
+	if !this.disabled && this.podDisruptionBudgetName == resources.RC_NOT_CREATED_NAME_EMPTY && len(this.podDisruptionBudgets) != 1 {
+		podDisruptionBudget := this.api.Create()
+		// leave the creation itself to patcher+creator so other CFs can update
+		this.svcResourceCache.Set(this.api.CacheKey(), resources.NewResourceCacheEntry(resources.RC_NOT_CREATED_NAME_EMPTY, podDisruptionBudget))
+	}
+
+	// Response #5
+	// Update the spec
+	if !this.disabled {
+		if pdbEntry, pdbExists := this.svcResourceCache.Get(this.api.CacheKey()); pdbExists {
+			pdbEntry.ApplyPatch(func(value interface{}) interface{} {
+				return this.api.SetSpec(value, this.targetSpec)
+			})
+			this.appliedEvictionPolicy = this.targetSpec.UnhealthyPodEvictionPolicy
+		}
+	}
+
+	// Delete an existing PDB if disabled
+	// TODO Unify with deletion above
+	if this.disabled {
+		this.Cleanup()
+	}
+}
+
+func (this *PodDisruptionBudgetCF) Cleanup() bool {
+	// PDB should not have any deletion dependencies
+	return cleanupCachedResource(this.ctx, this.log, this.api.CacheKey(), ar.ManagedResourceKindPodDisruptionBudget,
+		this.api.Update, this.api.Delete)
+}
+
+func (this *PodDisruptionBudgetCF) DiscoverForCleanup() {
+	if _, exists := this.svcResourceCache.Get(this.api.CacheKey()); exists {
+		return
+	}
+	podDisruptionBudgets, err := this.api.List(meta.ListOptions{
+		LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+	})
+	if err != nil {
+		this.log.Errorw("could not list PodDisruptionBudgets during cleanup", "error", err)
+		return
+	}
+	if podDisruptionBudget := findOwnedByApp(this.ctx, podDisruptionBudgets); podDisruptionBudget != nil {
+		this.svcResourceCache.Set(this.api.CacheKey(),
+			resources.NewResourceCacheEntry(common.Name(podDisruptionBudget.GetName()), podDisruptionBudget))
+	}
+}
+
+// The unhealthyPodEvictionPolicy field is dropped by the API server if it is not supported (before Kubernetes 1.27),
+// so it is only compared if the PodDisruptionBudget has it, or if the Operator has not set it yet.
+// Otherwise, the PodDisruptionBudget would be updated on every reconciliation.
+func isPodDisruptionBudgetSpecUpToDate(found podDisruptionBudgetSpec, target podDisruptionBudgetSpec, appliedEvictionPolicy string) bool {
+	if found.UnhealthyPodEvictionPolicy == "" && target.UnhealthyPodEvictionPolicy == appliedEvictionPolicy {
+		found.UnhealthyPodEvictionPolicy = target.UnhealthyPodEvictionPolicy
+	}
+	return reflect.DeepEqual(found, target)
+}
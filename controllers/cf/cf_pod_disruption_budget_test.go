@@ -0,0 +1,38 @@
+package cf
+
+import (
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	policy_v1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"testing"
+)
+
+func TestPodDisruptionBudgetSpecUpToDate(t *testing.T) {
+	maxUnavailable := intstr.FromInt(1)
+	minAvailable := intstr.FromString("50%")
+	target := podDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable, UnhealthyPodEvictionPolicy: "AlwaysAllow"}
+
+	// The policy has not been set yet
+	c.AssertEquals(t, false, isPodDisruptionBudgetSpecUpToDate(podDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}, target, ""))
+	// The policy has been set, but the API server does not support it
+	c.AssertEquals(t, true, isPodDisruptionBudgetSpecUpToDate(podDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}, target, "AlwaysAllow"))
+	// The policy has been set and read back
+	c.AssertEquals(t, true, isPodDisruptionBudgetSpecUpToDate(target, target, "AlwaysAllow"))
+	// The policy has been changed
+	c.AssertEquals(t, false, isPodDisruptionBudgetSpecUpToDate(podDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}, target, "IfHealthyBudget"))
+	c.AssertEquals(t, false, isPodDisruptionBudgetSpecUpToDate(podDisruptionBudgetSpec{
+		MaxUnavailable:             &maxUnavailable,
+		UnhealthyPodEvictionPolicy: "IfHealthyBudget",
+	}, target, "AlwaysAllow"))
+	// The policy has been removed
+	c.AssertEquals(t, false, isPodDisruptionBudgetSpecUpToDate(target, podDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}, ""))
+	// Other fields are always compared
+	c.AssertEquals(t, false, isPodDisruptionBudgetSpecUpToDate(podDisruptionBudgetSpec{MinAvailable: &minAvailable}, target, "AlwaysAllow"))
+
+	// Round trip
+	api := &podDisruptionBudgetV1beta1Api{}
+	pdb := api.SetSpec(&policy_v1beta1.PodDisruptionBudget{}, target)
+	c.AssertEquals(t, true, isPodDisruptionBudgetSpecUpToDate(api.GetSpec(pdb), target, ""))
+	pdb.(*policy_v1beta1.PodDisruptionBudget).Spec.UnhealthyPodEvictionPolicy = nil
+	c.AssertEquals(t, false, isPodDisruptionBudgetSpecUpToDate(api.GetSpec(pdb), target, ""))
+}
@@ -3,16 +3,18 @@ package cf
 import (
 	"encoding/json"
 	"errors"
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
 	f "github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
 	"go.uber.org/zap"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"reflect"
 )
 
@@ -30,6 +32,9 @@ type PodTemplateSpecCF struct {
 	previousDeploymentPodSpec *core.PodTemplateSpec
 	deploymentPodSpec         *core.PodTemplateSpec
 
+	previousDefaultResources *core.ResourceRequirements
+	defaultResources         *core.ResourceRequirements
+
 	valid                 bool
 	targetPodTemplateSpec *core.PodTemplateSpec
 
@@ -42,9 +47,11 @@ type PodTemplateSpecCF struct {
 // since we don't know which changes are from spec PTS and which from the CFs.
 // To work around this, we will reconcile if:
 // - The PTS in the spec has changed, or
-// - The PTS in the Deployment has changed.
+// - The PTS in the Deployment has changed, or
+// - The default resources from the Operator configuration have changed.
 // If we are done, there will be no change in the Deployment between execution of this CF in subsequent reconciliations,
 // so we don't have to update the PTS again. This may waste a few cycles, but I don't think we can do better than that.
+// The previous values are persisted, so the PTS is not applied again after the Operator has been restarted.
 func NewPodTemplateSpecCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
 	res := &PodTemplateSpecCF{
 		ctx:                        ctx,
@@ -67,6 +74,19 @@ func (this *PodTemplateSpecCF) Sense() {
 		"this.lastActedReconcileSequence", this.lastActedReconcileSequence,
 	)
 
+	this.previousBasePodTemplateSpec = nil
+	if previous := (&ar.ApicurioRegistryPodTemplateSpec{}); this.ctx.GetState().Get(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_BASE, previous) {
+		this.previousBasePodTemplateSpec = previous
+	}
+	this.previousDeploymentPodSpec = nil
+	if previous := (&core.PodTemplateSpec{}); this.ctx.GetState().Get(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEPLOYMENT, previous) {
+		this.previousDeploymentPodSpec = previous
+	}
+	this.previousDefaultResources = nil
+	if previous := (&core.ResourceRequirements{}); this.ctx.GetState().Get(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEFAULT_RESOURCES, previous) {
+		this.previousDefaultResources = previous
+	}
+
 	if this.lastActedReconcileSequence+1 == this.ctx.GetReconcileSequence() {
 		this.log.Debugln("Sense", "We have acted in the previous loop, record the previous PTS from the Deployment, and reschedule")
 		// We have acted in the previous loop, record the previous PTS from the Deployment, and reschedule
@@ -74,6 +94,7 @@ func (this *PodTemplateSpecCF) Sense() {
 			this.log.Debugln("Sense", "Setting this.previousDeploymentPodSpec")
 			this.previousDeploymentPodSpec = &deploymentEntry.GetValue().(*apps.Deployment).Spec.Template
 			this.previousDeploymentPodSpec = this.previousDeploymentPodSpec.DeepCopy() // Defensive copy
+			this.ctx.GetState().Set(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEPLOYMENT, this.previousDeploymentPodSpec)
 			this.ctx.SetRequeueNow()
 			return
 		}
@@ -83,25 +104,33 @@ func (this *PodTemplateSpecCF) Sense() {
 
 	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
 
-		this.basePodTemplateSpec = &entry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.PodTemplateSpecPreview
+		this.basePodTemplateSpec = &entry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.PodTemplateSpec
 		this.basePodTemplateSpec = this.basePodTemplateSpec.DeepCopy() // Defensive copy so we won't update the spec
 
 		if deploymentEntry, deploymentExists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT); deploymentExists {
 			this.deploymentPodSpec = &deploymentEntry.GetValue().(*apps.Deployment).Spec.Template
 			this.deploymentPodSpec = this.deploymentPodSpec.DeepCopy() // Defensive copy
 			factoryPodSpec := this.services.GetKubeFactory().CreateDeployment().Spec.Template
+			// The factory applies the default resources from the Operator configuration
+			this.defaultResources = common.GetContainerByName(factoryPodSpec.Spec.Containers, f.REGISTRY_CONTAINER_NAME).Resources.DeepCopy()
+			if this.previousDefaultResources == nil && this.previousBasePodTemplateSpec != nil {
+				// The default resources have not been recorded by a previous version of the Operator,
+				// do not update the Deployment only because of that
+				this.previousDefaultResources = this.defaultResources
+				this.ctx.GetState().Set(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEFAULT_RESOURCES, this.previousDefaultResources)
+			}
 			targetPodSpec, err := SanitizeBasePodSpec(this.log, this.basePodTemplateSpec, this.deploymentPodSpec, &factoryPodSpec)
 			if err == nil {
 				this.targetPodTemplateSpec, err = ConvertToPodTemplateSpec(targetPodSpec)
 				if err == nil {
 					this.valid = true
 				} else {
-					this.log.Errorw("an error has occurred when processing spec.deployment.podTemplateSpecPreview field", "error", err)
+					this.log.Errorw("an error has occurred when processing spec.deployment.podTemplateSpec field", "error", err)
 				}
 			} else {
-				this.log.Errorw("an error has occurred when processing spec.deployment.podTemplateSpecPreview field", "error", err)
+				this.log.Errorw("an error has occurred when processing spec.deployment.podTemplateSpec field", "error", err)
 				this.services.GetConditionManager().GetConfigurationErrorCondition().
-					TransitionInvalid(err.Error(), "spec.deployment.podTemplateSpecPreview")
+					TransitionInvalid(err.Error(), "spec.deployment.podTemplateSpec")
 				// No need to transition to not-ready
 				this.ctx.SetRequeueDelaySec(10)
 			}
@@ -140,8 +169,10 @@ func (this *PodTemplateSpecCF) Compare() bool {
 
 	this.log.Debugw("Compare", "!reflect.DeepEqual(this.deploymentPodSpec, this.previousDeploymentPodSpec)", !reflect.DeepEqual(this.deploymentPodSpec, this.previousDeploymentPodSpec))
 
+	// Resource quantities must be compared semantically, their internal representation can differ
 	return this.valid &&
-		(!reflect.DeepEqual(this.basePodTemplateSpec, this.previousBasePodTemplateSpec) || !reflect.DeepEqual(this.deploymentPodSpec, this.previousDeploymentPodSpec))
+		(!reflect.DeepEqual(this.basePodTemplateSpec, this.previousBasePodTemplateSpec) || !reflect.DeepEqual(this.deploymentPodSpec, this.previousDeploymentPodSpec) ||
+			!equality.Semantic.DeepEqual(this.defaultResources, this.previousDefaultResources))
 }
 
 func (this *PodTemplateSpecCF) Respond() {
@@ -150,6 +181,9 @@ func (this *PodTemplateSpecCF) Respond() {
 		entry.ApplyPatch(func(value interface{}) interface{} {
 			deployment := value.(*apps.Deployment).DeepCopy()
 			this.previousBasePodTemplateSpec = this.basePodTemplateSpec
+			this.ctx.GetState().Set(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_BASE, this.previousBasePodTemplateSpec)
+			this.previousDefaultResources = this.defaultResources
+			this.ctx.GetState().Set(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEFAULT_RESOURCES, this.previousDefaultResources)
 			deployment.Spec.Template = *this.targetPodTemplateSpec
 			this.lastActedReconcileSequence = this.ctx.GetReconcileSequence()
 			this.log.Debugln("Respond", "responded")
@@ -215,7 +249,7 @@ func SanitizeBasePodSpec(log *zap.SugaredLogger, base *ar.ApicurioRegistryPodTem
 	baseContainer := common.GetContainerByName(base.Spec.Containers, f.REGISTRY_CONTAINER_NAME)
 	if len(base.Spec.Containers) > 0 && baseContainer == nil {
 		log.Warnw("container named " + f.REGISTRY_CONTAINER_NAME + " is not defined " +
-			"in spec.deployment.podTemplateSpecPreview.spec.containers. Make sure this is intended.")
+			"in spec.deployment.podTemplateSpec.spec.containers. Make sure this is intended.")
 	}
 	currentContainer := common.GetContainerByName(current.Spec.Containers, f.REGISTRY_CONTAINER_NAME)
 	if currentContainer == nil {
@@ -0,0 +1,82 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"testing"
+)
+
+func TestPodTemplateSpecCFDefaultResources(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	deploymentEntry := resources.NewResourceCacheEntry(ctx.GetAppName(), services.GetKubeFactory().CreateDeployment())
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, deploymentEntry)
+	this := NewPodTemplateSpecCF(ctx, services)
+	memoryLimit := func() string {
+		return deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Spec.Containers[0].Resources.Limits.Memory().String()
+	}
+	// Reconciles until the CF has recorded the Deployment after acting
+	reconcile := func(expectedChange bool) {
+		this.Sense()
+		c.AssertEquals(t, expectedChange, this.Compare())
+		if expectedChange {
+			this.Respond()
+			ctx.Finalize()
+			this.Sense()
+			c.AssertEquals(t, false, this.Compare())
+		}
+		ctx.Finalize()
+	}
+
+	reconcile(true)
+	c.AssertEquals(t, "1300Mi", memoryLimit())
+	reconcile(false)
+
+	// The default resources are changed in the Operator configuration
+	ctx.GetOperatorConfig().Get().DefaultResources = &core.ResourceRequirements{
+		Limits: core.ResourceList{core.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	reconcile(true)
+	c.AssertEquals(t, "2Gi", memoryLimit())
+	reconcile(false)
+
+	// The resources in the spec take precedence
+	spec.Spec.Deployment.PodTemplateSpec.Spec.Containers = []core.Container{{
+		Name: factory.REGISTRY_CONTAINER_NAME,
+		Resources: core.ResourceRequirements{
+			Limits: core.ResourceList{core.ResourceMemory: resource.MustParse("3Gi")},
+		},
+	}}
+	reconcile(true)
+	c.AssertEquals(t, "3Gi", memoryLimit())
+	ctx.GetOperatorConfig().Get().DefaultResources = nil
+	reconcile(true)
+	c.AssertEquals(t, "3Gi", memoryLimit())
+	reconcile(false)
+}
+
+func TestPodTemplateSpecCFDefaultResourcesNotRecorded(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	deployment := services.GetKubeFactory().CreateDeployment()
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, resources.NewResourceCacheEntry(ctx.GetAppName(), deployment))
+	// The state has been recorded by a previous version of the Operator
+	ctx.GetState().Set(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_BASE, &spec.Spec.Deployment.PodTemplateSpec)
+	ctx.GetState().Set(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEPLOYMENT, &deployment.Spec.Template)
+	this := NewPodTemplateSpecCF(ctx, services)
+
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+	previous := &core.ResourceRequirements{}
+	c.AssertEquals(t, true, ctx.GetState().Get(state.STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEFAULT_RESOURCES, previous))
+	c.AssertEquals(t, "1300Mi", previous.Limits.Memory().String())
+}
@@ -0,0 +1,69 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+)
+
+const (
+	ENV_REGISTRY_AUTH_ANONYMOUS_READ_ACCESS_ENABLED = "REGISTRY_AUTH_ANONYMOUS_READ_ACCESS_ENABLED"
+	ENV_REGISTRY_AUTH_AUTHENTICATED_READS_ENABLED   = "REGISTRY_AUTH_AUTHENTICATED_READS_ENABLED"
+	ENV_REGISTRY_AUTH_OBAC_ENABLED                  = "REGISTRY_AUTH_OBAC_ENABLED"
+	ENV_REGISTRY_ROLE_BASED_AUTHZ_ENABLED           = "ROLE_BASED_AUTHZ_ENABLED"
+	ENV_REGISTRY_ROLE_BASED_AUTHZ_SOURCE            = "ROLE_BASED_AUTHZ_SOURCE"
+)
+
+// This CF maps spec.configuration.registry.authorization to the env. variables.
+func NewRegistryAuthorizationCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	return newRegistryConfigCF(ctx, services, "RegistryAuthorizationCF", registryAuthorizationEnv)
+}
+
+func registryAuthorizationEnv(spec *ar.ApicurioRegistrySpec, services services.LoopServices) map[string]string {
+	res := make(map[string]string)
+	authorization := spec.Configuration.Registry.Authorization
+	if authorization.AnonymousReadAccess {
+		res[ENV_REGISTRY_AUTH_ANONYMOUS_READ_ACCESS_ENABLED] = "true"
+	}
+	if authorization.AuthenticatedReadAccess {
+		res[ENV_REGISTRY_AUTH_AUTHENTICATED_READS_ENABLED] = "true"
+	}
+	if authorization.OwnerOnly {
+		res[ENV_REGISTRY_AUTH_OBAC_ENABLED] = "true"
+	}
+	if authorization.RoleBased {
+		res[ENV_REGISTRY_ROLE_BASED_AUTHZ_ENABLED] = "true"
+		switch authorization.RoleSource {
+		case "":
+		case ar.RoleSourceToken, ar.RoleSourceApplication:
+			res[ENV_REGISTRY_ROLE_BASED_AUTHZ_SOURCE] = string(authorization.RoleSource)
+		default:
+			services.GetConditionManager().GetConfigurationErrorCondition().
+				TransitionInvalid(string(authorization.RoleSource), "spec.configuration.registry.authorization.roleSource")
+		}
+	} else if authorization.RoleSource != "" {
+		services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(string(authorization.RoleSource), "spec.configuration.registry.authorization.roleSource")
+	}
+	// Authorization is not applied without authentication
+	if len(res) > 0 && !IsAuthenticationEnabled(spec) {
+		services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid("authentication is not enabled", "spec.configuration.registry.authorization")
+	}
+	return res
+}
+
+// Authentication is enabled using spec.configuration.security.keycloak (see KeycloakCF), or using an env. variable
+func IsAuthenticationEnabled(spec *ar.ApicurioRegistrySpec) bool {
+	keycloak := spec.Configuration.Security.Keycloak
+	if keycloak.Url != "" && keycloak.Realm != "" {
+		return true
+	}
+	for _, v := range spec.Configuration.Env {
+		if v.Name == ENV_REGISTRY_AUTH_ENABLED && v.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	"sort"
+)
+
+var _ loop.ControlFunction = &RegistryConfigCF{}
+
+// Computes the target env. variables from the spec, and reports invalid values
+type registryConfigEnvFunc func(spec *ar.ApicurioRegistrySpec, services services.LoopServices) map[string]string
+
+// This CF maps a part of spec.configuration.registry to the env. variables.
+// The variables are set with PRIORITY_OPERATOR, but a variable that is defined in spec.configuration.env
+// is not set by this CF, so the users can override the typed configuration deliberately.
+type RegistryConfigCF struct {
+	ctx              context.LoopContext
+	log              *zap.SugaredLogger
+	services         services.LoopServices
+	svcResourceCache resources.ResourceCache
+	svcEnvCache      env.EnvCache
+	name             string
+	targetEnvFunc    registryConfigEnvFunc
+	targetEnv        map[string]string
+	// To know which were deleted, we need to remember the previously set ones
+	previousTargetEnv map[string]string
+	update            bool
+	remove            []string
+}
+
+func newRegistryConfigCF(ctx context.LoopContext, services services.LoopServices, name string, targetEnvFunc registryConfigEnvFunc) loop.ControlFunction {
+	res := &RegistryConfigCF{
+		ctx:               ctx,
+		services:          services,
+		svcResourceCache:  ctx.GetResourceCache(),
+		svcEnvCache:       ctx.GetEnvCache(),
+		name:              name,
+		targetEnvFunc:     targetEnvFunc,
+		targetEnv:         make(map[string]string),
+		previousTargetEnv: make(map[string]string),
+		update:            false,
+		remove:            make([]string, 0),
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *RegistryConfigCF) Describe() string {
+	return this.name
+}
+
+func (this *RegistryConfigCF) Sense() {
+	this.targetEnv = make(map[string]string)
+
+	// Observation #1
+	// Read the config values
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		this.targetEnv = this.targetEnvFunc(&spec, this.services)
+
+		// Observation #2
+		// Variables defined in the spec take precedence
+		for _, v := range spec.Configuration.Env {
+			if _, exists := this.targetEnv[v.Name]; exists {
+				this.log.Debugw("env. variable is overridden in spec.configuration.env", "name", v.Name)
+				delete(this.targetEnv, v.Name)
+			}
+		}
+	}
+
+	// Observation #3
+	// Compare with the env values
+	this.update = false
+	for name, value := range this.targetEnv {
+		if entry, exists := this.svcEnvCache.Get(name); !exists || entry.GetValue().Value != value ||
+			entry.GetPriority() != env.PRIORITY_OPERATOR {
+			this.update = true
+		}
+	}
+	this.remove = make([]string, 0)
+	for name := range this.previousTargetEnv {
+		if _, target := this.targetEnv[name]; !target {
+			// Do not remove the variable if it has been set by the user
+			if entry, exists := this.svcEnvCache.Get(name); exists && entry.GetPriority() == env.PRIORITY_OPERATOR {
+				this.remove = append(this.remove, name)
+			}
+		}
+	}
+}
+
+func (this *RegistryConfigCF) Compare() bool {
+	// Condition #1
+	// The env. variables differ or some should be removed
+	return this.update || len(this.remove) > 0
+}
+
+func (this *RegistryConfigCF) Respond() {
+	// Response #1
+	// Remove first
+	for _, name := range this.remove {
+		this.svcEnvCache.DeleteByName(name)
+	}
+
+	// Response #2
+	// Set the values
+	names := make([]string, 0, len(this.targetEnv))
+	for name := range this.targetEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(name, this.targetEnv[name]).
+			SetPriority(env.PRIORITY_OPERATOR).
+			Build())
+	}
+
+	this.previousTargetEnv = this.targetEnv
+}
+
+func (this *RegistryConfigCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
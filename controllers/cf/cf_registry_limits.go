@@ -0,0 +1,50 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"strconv"
+)
+
+const (
+	ENV_REGISTRY_LIMITS_CONFIG_MAX_TOTAL_SCHEMAS         = "REGISTRY_LIMITS_CONFIG_MAX_TOTAL_SCHEMAS"
+	ENV_REGISTRY_LIMITS_CONFIG_MAX_SCHEMA_SIZE_BYTES     = "REGISTRY_LIMITS_CONFIG_MAX_SCHEMA_SIZE_BYTES"
+	ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACTS             = "REGISTRY_LIMITS_CONFIG_MAX_ARTIFACTS"
+	ENV_REGISTRY_LIMITS_CONFIG_MAX_VERSIONS_PER_ARTIFACT = "REGISTRY_LIMITS_CONFIG_MAX_VERSIONS_PER_ARTIFACT"
+	ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACT_PROPERTIES   = "REGISTRY_LIMITS_CONFIG_MAX_ARTIFACT_PROPERTIES"
+	ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACT_LABELS       = "REGISTRY_LIMITS_CONFIG_MAX_ARTIFACT_LABELS"
+	ENV_REGISTRY_LIMITS_CONFIG_MAX_REQUESTS_PER_SECOND   = "REGISTRY_LIMITS_CONFIG_MAX_REQUESTS_PER_SECOND"
+)
+
+// This CF maps spec.configuration.registry.limits to the env. variables.
+func NewRegistryLimitsCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	return newRegistryConfigCF(ctx, services, "RegistryLimitsCF", registryLimitsEnv)
+}
+
+func registryLimitsEnv(spec *ar.ApicurioRegistrySpec, services services.LoopServices) map[string]string {
+	res := make(map[string]string)
+	limits := spec.Configuration.Registry.Limits
+	for _, limit := range []struct {
+		name  string
+		path  string
+		value int64
+	}{
+		{ENV_REGISTRY_LIMITS_CONFIG_MAX_TOTAL_SCHEMAS, "maxTotalSchemas", limits.MaxTotalSchemas},
+		{ENV_REGISTRY_LIMITS_CONFIG_MAX_SCHEMA_SIZE_BYTES, "maxSchemaSizeBytes", limits.MaxSchemaSizeBytes},
+		{ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACTS, "maxArtifacts", limits.MaxArtifacts},
+		{ENV_REGISTRY_LIMITS_CONFIG_MAX_VERSIONS_PER_ARTIFACT, "maxVersionsPerArtifact", limits.MaxVersionsPerArtifact},
+		{ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACT_PROPERTIES, "maxArtifactProperties", limits.MaxArtifactProperties},
+		{ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACT_LABELS, "maxArtifactLabels", limits.MaxArtifactLabels},
+		{ENV_REGISTRY_LIMITS_CONFIG_MAX_REQUESTS_PER_SECOND, "maxRequestsPerSecond", limits.MaxRequestsPerSecond},
+	} {
+		if limit.value > 0 {
+			res[limit.name] = strconv.FormatInt(limit.value, 10)
+		} else if limit.value < 0 {
+			services.GetConditionManager().GetConfigurationErrorCondition().
+				TransitionInvalid(strconv.FormatInt(limit.value, 10), "spec.configuration.registry.limits."+limit.path)
+		}
+	}
+	return res
+}
@@ -0,0 +1,52 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+)
+
+const (
+	ENV_REGISTRY_RULES_GLOBAL_VALIDITY      = "REGISTRY_RULES_GLOBAL_VALIDITY"
+	ENV_REGISTRY_RULES_GLOBAL_COMPATIBILITY = "REGISTRY_RULES_GLOBAL_COMPATIBILITY"
+	ENV_REGISTRY_RULES_GLOBAL_INTEGRITY     = "REGISTRY_RULES_GLOBAL_INTEGRITY"
+)
+
+// This CF maps spec.configuration.registry.rules to the env. variables.
+func NewRegistryRulesCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	return newRegistryConfigCF(ctx, services, "RegistryRulesCF", registryRulesEnv)
+}
+
+func registryRulesEnv(spec *ar.ApicurioRegistrySpec, services services.LoopServices) map[string]string {
+	res := make(map[string]string)
+	rules := spec.Configuration.Registry.Rules
+	switch rules.Validity {
+	case "":
+	case ar.ValidityRuleNone, ar.ValidityRuleSyntaxOnly, ar.ValidityRuleFull:
+		res[ENV_REGISTRY_RULES_GLOBAL_VALIDITY] = string(rules.Validity)
+	default:
+		services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(string(rules.Validity), "spec.configuration.registry.rules.validity")
+	}
+	switch rules.Compatibility {
+	case "":
+	case ar.CompatibilityRuleNone, ar.CompatibilityRuleBackward, ar.CompatibilityRuleBackwardTransitive,
+		ar.CompatibilityRuleForward, ar.CompatibilityRuleForwardTransitive,
+		ar.CompatibilityRuleFull, ar.CompatibilityRuleFullTransitive:
+		res[ENV_REGISTRY_RULES_GLOBAL_COMPATIBILITY] = string(rules.Compatibility)
+	default:
+		services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(string(rules.Compatibility), "spec.configuration.registry.rules.compatibility")
+	}
+	switch rules.Integrity {
+	case "":
+	case ar.IntegrityRuleNone, ar.IntegrityRuleRefsExist, ar.IntegrityRuleAllRefsMapped,
+		ar.IntegrityRuleNoDuplicates, ar.IntegrityRuleFull:
+		res[ENV_REGISTRY_RULES_GLOBAL_INTEGRITY] = string(rules.Integrity)
+	default:
+		services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(string(rules.Integrity), "spec.configuration.registry.rules.integrity")
+	}
+	return res
+}
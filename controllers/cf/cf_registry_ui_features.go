@@ -0,0 +1,24 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+)
+
+const ENV_UI_SETTINGS = "REGISTRY_UI_FEATURES_SETTINGS"
+
+// This CF maps spec.configuration.registry.ui.features to the env. variables.
+// The read-only mode is configured by UICF.
+func NewRegistryUIFeaturesCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	return newRegistryConfigCF(ctx, services, "RegistryUIFeaturesCF", registryUIFeaturesEnv)
+}
+
+func registryUIFeaturesEnv(spec *ar.ApicurioRegistrySpec, _ services.LoopServices) map[string]string {
+	res := make(map[string]string)
+	if spec.Configuration.Registry.UI.Features.Settings {
+		res[ENV_UI_SETTINGS] = "true"
+	}
+	return res
+}
@@ -0,0 +1,332 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
+	ocp_route "github.com/openshift/api/route/v1"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+)
+
+var _ loop.ControlFunction = &RouteOcpCF{}
+
+// This CF manages the OpenShift Routes, one for each host.
+// It replaces IngressCF and HostCF on OpenShift, so the Route TLS can be configured,
+// which is not possible with the Routes created by OpenShift for an Ingress.
+type RouteOcpCF struct {
+	ctx              context.LoopContext
+	log              *zap.SugaredLogger
+	svcResourceCache resources.ResourceCache
+	svcClients       *client.Clients
+	svcStatus        *status.Status
+	svcOCPFactory    *factory.OCPFactory
+	services         services.LoopServices
+	disabled         bool
+	valid            bool
+	serviceName      string
+	targetHosts      []string
+	targetPort       int
+	targetTLS        *ocp_route.TLSConfig
+	routes           []ocp_route.Route
+	toCreate         []*ocp_route.Route
+	toUpdate         []*ocp_route.Route
+	toDelete         []*ocp_route.Route
+}
+
+func NewRouteOcpCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &RouteOcpCF{
+		ctx:              ctx,
+		svcResourceCache: ctx.GetResourceCache(),
+		svcClients:       ctx.GetClients(),
+		svcStatus:        services.GetStatus(),
+		svcOCPFactory:    services.GetOCPFactory(),
+		services:         services,
+		disabled:         false,
+		valid:            true,
+		serviceName:      resources.RC_NOT_CREATED_NAME_EMPTY,
+		targetHosts:      make([]string, 0),
+		targetPort:       HttpPort,
+		targetTLS:        nil,
+		routes:           make([]ocp_route.Route, 0),
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *RouteOcpCF) Describe() string {
+	return "RouteOcpCF"
+}
+
+func (this *RouteOcpCF) Sense() {
+	this.disabled = false
+	this.valid = true
+	this.targetHosts = make([]string, 0)
+	this.targetPort = HttpPort
+	this.targetTLS = nil
+
+	// Observation #1
+	// Read the config values
+	routeSpec := ar.ApicurioRegistrySpecDeploymentRoute{}
+	termination := ar.ApicurioRegistryRouteTermination("")
+	portConfigured := true
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		this.disabled = spec.Deployment.ManagedResources.DisableIngress || spec.Deployment.Hosts.Primary == ""
+		if spec.Deployment.Hosts.Primary != "" {
+			this.targetHosts = append(this.targetHosts, spec.Deployment.Hosts.Primary)
+			for _, host := range spec.Deployment.Hosts.Additional {
+				if _, found := common.FindString(this.targetHosts, host); host != "" && !found {
+					this.targetHosts = append(this.targetHosts, host)
+				}
+			}
+		}
+		routeSpec = spec.Deployment.Route
+		termination = GetRouteTermination(&spec)
+		this.targetPort = GetRouteTargetPort(termination)
+		portConfigured = IsRouteTargetPortConfigured(&spec, this.targetPort)
+	}
+
+	// Observation #2
+	// Is there a Service already? It must have been created (has a name) and have the target port.
+	// If the port is missing, the existing Routes are kept.
+	this.serviceName = resources.RC_NOT_CREATED_NAME_EMPTY
+	if serviceEntry, serviceExists := this.svcResourceCache.Get(resources.RC_KEY_SERVICE); serviceExists {
+		this.serviceName = serviceEntry.GetName().Str()
+		foundPort := false
+		for _, port := range serviceEntry.GetValue().(*core.Service).Spec.Ports {
+			if int(port.Port) == this.targetPort {
+				foundPort = true
+			}
+		}
+		if !foundPort && !this.disabled {
+			if portConfigured {
+				// Not a configuration error, HttpsCF has not added the port yet
+				this.log.Debugw("waiting for the Route target port to be available in the Service", "port", this.targetPort)
+				this.ctx.SetRequeueDelaySoon()
+			} else {
+				this.log.Errorw("Route termination requires a Service port that is not enabled, "+
+					"check the HTTPS configuration in spec.configuration.security.https", "termination", termination, "port", this.targetPort)
+				this.services.GetConditionManager().GetConfigurationErrorCondition().
+					TransitionInvalid(string(termination), "spec.deployment.route.termination")
+			}
+			this.valid = false
+		}
+	}
+
+	// Observation #3
+	// Build the TLS config
+	if this.valid && !this.disabled && termination != "" {
+		this.targetTLS = &ocp_route.TLSConfig{
+			Termination:                   ocp_route.TLSTerminationType(termination),
+			InsecureEdgeTerminationPolicy: ocp_route.InsecureEdgeTerminationPolicyType(routeSpec.InsecureEdgeTerminationPolicy),
+		}
+		if termination != ar.RouteTerminationPassthrough && routeSpec.CertificateSecretName != "" {
+			if secret, ok := this.getSecret(routeSpec.CertificateSecretName, "spec.deployment.route.certificateSecretName", "tls.crt", "tls.key"); ok {
+				this.targetTLS.Certificate = string(secret.Data["tls.crt"])
+				this.targetTLS.Key = string(secret.Data["tls.key"])
+				this.targetTLS.CACertificate = string(secret.Data["ca.crt"])
+			}
+		}
+		if termination == ar.RouteTerminationReencrypt && routeSpec.DestinationCaCertificateSecretName != "" {
+			if secret, ok := this.getSecret(routeSpec.DestinationCaCertificateSecretName, "spec.deployment.route.destinationCaCertificateSecretName", "ca.crt"); ok {
+				this.targetTLS.DestinationCACertificate = string(secret.Data["ca.crt"])
+			}
+		}
+	}
+
+	// Observation #4
+	// Get the Routes we manage, skipping those created by OpenShift for an Ingress
+	this.routes = make([]ocp_route.Route, 0)
+	routes, err := this.svcClients.OCP().GetRoutes(this.ctx.GetAppNamespace(), &meta.ListOptions{
+		LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+	})
+	if err != nil {
+		this.log.Errorw("could not list Routes", "error", err)
+		this.valid = false
+		this.ctx.SetRequeueDelaySec(10)
+	} else {
+		for _, route := range routes.Items {
+			if route.GetObjectMeta().GetDeletionTimestamp() == nil && !isOwnedByIngress(&route) {
+				this.routes = append(this.routes, route)
+			}
+		}
+	}
+
+	// Observation #5
+	// Compute the changes
+	this.toCreate = make([]*ocp_route.Route, 0)
+	this.toUpdate = make([]*ocp_route.Route, 0)
+	this.toDelete = make([]*ocp_route.Route, 0)
+	primaryRouteName := ""
+	primaryHost := ""
+	if this.valid && this.serviceName != resources.RC_NOT_CREATED_NAME_EMPTY {
+		if this.disabled {
+			this.targetHosts = make([]string, 0)
+		}
+		for _, host := range this.targetHosts {
+			target := this.svcOCPFactory.CreateRoute(host, this.serviceName, this.targetPort, this.targetTLS)
+			var existing *ocp_route.Route
+			for i := range this.routes {
+				if this.routes[i].Spec.Host == host {
+					existing = &this.routes[i]
+				}
+			}
+			if existing == nil {
+				this.toCreate = append(this.toCreate, target)
+			} else {
+				if primaryRouteName == "" {
+					primaryRouteName = existing.Name
+					primaryHost = host
+				}
+				if !reflect.DeepEqual(existing.Spec.To, target.Spec.To) || !reflect.DeepEqual(existing.Spec.Port, target.Spec.Port) ||
+					!reflect.DeepEqual(existing.Spec.TLS, target.Spec.TLS) {
+					updated := existing.DeepCopy()
+					updated.Spec.To = target.Spec.To
+					updated.Spec.Port = target.Spec.Port
+					updated.Spec.TLS = target.Spec.TLS
+					this.toUpdate = append(this.toUpdate, updated)
+				}
+			}
+		}
+		for i := range this.routes {
+			if _, found := common.FindString(this.targetHosts, this.routes[i].Spec.Host); !found {
+				this.toDelete = append(this.toDelete, &this.routes[i])
+			}
+		}
+	}
+
+	// Update the status
+	this.svcStatus.SetConfig(status.CFG_STA_ROUTE_NAME, primaryRouteName)
+	this.svcStatus.SetConfig(status.CFG_STA_ROUTE, primaryHost)
+}
+
+func (this *RouteOcpCF) Compare() bool {
+	// Condition #1
+	// The Routes are not the same as the target Routes
+	// Condition #2
+	// Act only once per loop, so a failing request does not prevent stabilization
+	return this.valid && (len(this.toCreate) > 0 || len(this.toUpdate) > 0 || len(this.toDelete) > 0) &&
+		this.ctx.GetAttempts() == 0
+}
+
+func (this *RouteOcpCF) Respond() {
+	specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC)
+	if !exists {
+		return
+	}
+	owner := specEntry.GetValue().(*ar.ApicurioRegistry)
+
+	// Response #1
+	// Create the missing Routes
+	for _, route := range this.toCreate {
+		if _, err := this.svcClients.OCP().CreateRoute(owner, this.ctx.GetAppNamespace(), route); err != nil {
+			this.log.Errorw("could not create Route", "host", route.Spec.Host, "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+		}
+	}
+
+	// Response #2
+	// Update the outdated Routes
+	for _, route := range this.toUpdate {
+		if _, err := this.svcClients.OCP().UpdateRoute(this.ctx.GetAppNamespace(), route); err != nil {
+			this.log.Errorw("could not update Route", "name", route.Name, "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+		}
+	}
+
+	// Response #3
+	// Delete the Routes that are no longer needed
+	for _, route := range this.toDelete {
+		if err := this.svcClients.OCP().DeleteRoute(route); err != nil && !api_errors.IsNotFound(err) {
+			this.log.Errorw("could not delete Route", "name", route.Name, "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+		}
+	}
+
+	// Routes are reloaded by the OCP patcher, to make sure HostInitRouteOcpCF can read the status
+	this.ctx.SetRequeueDelaySoon()
+}
+
+func (this *RouteOcpCF) Cleanup() bool {
+	// Routes are owned by the ApicurioRegistry resource and deleted by the garbage collector,
+	// unless they are retained
+	if !IsRetainedOnDeletion(this.ctx, ar.ManagedResourceKindRoute) {
+		return true
+	}
+	routes, err := this.svcClients.OCP().GetRoutes(this.ctx.GetAppNamespace(), &meta.ListOptions{
+		LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+	})
+	if err != nil {
+		this.log.Errorw("could not list Routes during cleanup", "error", err)
+		return false
+	}
+	for i := range routes.Items {
+		route := routes.Items[i].DeepCopy()
+		if RemoveAppOwnerReference(this.ctx, route) {
+			if _, err := this.svcClients.OCP().UpdateRoute(this.ctx.GetAppNamespace(), route); err != nil && !api_errors.IsNotFound(err) {
+				this.log.Errorw("could not remove the owner reference from Route during cleanup", "name", route.Name, "error", err)
+				return false
+			}
+			this.ctx.GetLog().Sugar().Infow("resource has been retained", "kind", ar.ManagedResourceKindRoute, "name", route.Name)
+		}
+	}
+	return true
+}
+
+// Returns false if the Secret is missing or does not have the required fields, in which case the error is reported
+func (this *RouteOcpCF) getSecret(name string, optionPath string, fields ...string) (*core.Secret, bool) {
+	secret, err := this.svcClients.Kube().GetSecret(this.ctx.GetAppNamespace(), common.Name(name), &meta.GetOptions{})
+	if err == nil {
+		for _, field := range fields {
+			if !common.SecretHasField(secret, field) {
+				err = api_errors.NewBadRequest("Secret does not have the " + field + " field")
+			}
+		}
+	}
+	if err != nil {
+		this.log.Errorw("Route Secret referenced in Apicurio Registry CR is missing or invalid",
+			"secretName", name, "error", err)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().TransitionInvalid(name, optionPath)
+		this.ctx.SetRequeueDelaySec(10)
+		this.valid = false
+		return nil, false
+	}
+	return secret, true
+}
+
+// The passthrough and reencrypt terminations require the HTTPS port
+func GetRouteTargetPort(termination ar.ApicurioRegistryRouteTermination) int {
+	if termination == ar.RouteTerminationPassthrough || termination == ar.RouteTerminationReencrypt {
+		return HttpsPort
+	}
+	return HttpPort
+}
+
+// Returns false if the spec does not enable the Service port, see HttpsCF
+func IsRouteTargetPortConfigured(spec *ar.ApicurioRegistrySpec, port int) bool {
+	https := spec.Configuration.Security.Https
+	if port == HttpsPort {
+		return https.SecretName != "" || https.Certificate.Mode == ar.HttpsCertificateModeSelfSigned ||
+			https.Certificate.Mode == ar.HttpsCertificateModeCertManager || https.Certificate.Mode == ar.HttpsCertificateModeOpenShift
+	}
+	return !https.DisableHttp
+}
+
+func isOwnedByIngress(route *ocp_route.Route) bool {
+	for _, owner := range route.OwnerReferences {
+		if owner.Kind == "Ingress" {
+			return true
+		}
+	}
+	return false
+}
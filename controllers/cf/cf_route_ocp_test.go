@@ -0,0 +1,137 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	ocp_route "github.com/openshift/api/route/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestGetRouteTargetPort(t *testing.T) {
+	c.AssertEquals(t, HttpPort, GetRouteTargetPort(""))
+	c.AssertEquals(t, HttpPort, GetRouteTargetPort(ar.RouteTerminationEdge))
+	c.AssertEquals(t, HttpsPort, GetRouteTargetPort(ar.RouteTerminationPassthrough))
+	c.AssertEquals(t, HttpsPort, GetRouteTargetPort(ar.RouteTerminationReencrypt))
+
+	spec := &ar.ApicurioRegistrySpec{}
+	c.AssertEquals(t, true, IsRouteTargetPortConfigured(spec, HttpPort))
+	c.AssertEquals(t, false, IsRouteTargetPortConfigured(spec, HttpsPort))
+	spec.Configuration.Security.Https.Certificate.Mode = ar.HttpsCertificateModeSelfSigned
+	spec.Configuration.Security.Https.DisableHttp = true
+	c.AssertEquals(t, false, IsRouteTargetPortConfigured(spec, HttpPort))
+	c.AssertEquals(t, true, IsRouteTargetPortConfigured(spec, HttpsPort))
+}
+
+func TestRouteOcpCF(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Deployment.Hosts.Primary = "registry.example.com"
+	spec.Spec.Deployment.Hosts.Additional = []string{"registry.example.org"}
+	spec.Spec.Configuration.Security.Https.SecretName = "https-secret"
+	spec.Spec.Deployment.Route = ar.ApicurioRegistrySpecDeploymentRoute{
+		Termination:                        ar.RouteTerminationReencrypt,
+		InsecureEdgeTerminationPolicy:      "Redirect",
+		CertificateSecretName:              "route-certificate",
+		DestinationCaCertificateSecretName: "route-destination-ca",
+	}
+	ctx := newLoopContextWithClientsMock(t, spec)
+	ctx.SetSupportedFeatures(&c.SupportedFeatures{IsOCP: true})
+	services := services2.NewLoopServicesMock(ctx)
+	kubeClient := ctx.GetClients().Kube()
+	for _, secret := range []*core.Secret{
+		{
+			ObjectMeta: meta.ObjectMeta{Name: "route-certificate", Namespace: ctx.GetAppNamespace().Str()},
+			Data: map[string][]byte{
+				"tls.crt": []byte("certificate"),
+				"tls.key": []byte("key"),
+				"ca.crt":  []byte("ca"),
+			},
+		},
+		{
+			ObjectMeta: meta.ObjectMeta{Name: "route-destination-ca", Namespace: ctx.GetAppNamespace().Str()},
+			Data: map[string][]byte{
+				"ca.crt": []byte("destination-ca"),
+			},
+		},
+	} {
+		_, err := kubeClient.CreateSecret(spec, ctx.GetAppNamespace(), secret)
+		c.AssertEquals(t, nil, err)
+	}
+	service := services.GetKubeFactory().CreateService()
+	service.Name = ctx.GetAppName().Str() + "-service"
+	service.Spec.Ports = []core.ServicePort{
+		{Name: "http", Port: HttpPort},
+		{Name: "https", Port: HttpsPort},
+	}
+	ctx.GetResourceCache().Set(resources.RC_KEY_SERVICE, resources.NewResourceCacheEntry(c.Name(service.Name), service))
+	this := NewRouteOcpCF(ctx, services)
+
+	reconcile := func() []ocp_route.Route {
+		this.Sense()
+		if this.Compare() {
+			this.Respond()
+		}
+		routes, err := ctx.GetClients().OCP().GetRoutes(ctx.GetAppNamespace(), &meta.ListOptions{})
+		c.AssertEquals(t, nil, err)
+		return routes.Items
+	}
+
+	// One Route for each host, the certificates are read from the Secrets
+	routes := reconcile()
+	c.AssertEquals(t, 2, len(routes))
+	for _, route := range routes {
+		c.AssertEquals(t, service.Name, route.Spec.To.Name)
+		c.AssertEquals(t, HttpsPort, route.Spec.Port.TargetPort.IntValue())
+		c.AssertEquals(t, ocp_route.TLSTerminationReencrypt, route.Spec.TLS.Termination)
+		c.AssertEquals(t, ocp_route.InsecureEdgeTerminationPolicyRedirect, route.Spec.TLS.InsecureEdgeTerminationPolicy)
+		c.AssertEquals(t, "certificate", route.Spec.TLS.Certificate)
+		c.AssertEquals(t, "key", route.Spec.TLS.Key)
+		c.AssertEquals(t, "ca", route.Spec.TLS.CACertificate)
+		c.AssertEquals(t, "destination-ca", route.Spec.TLS.DestinationCACertificate)
+		c.AssertEquals(t, true, IsControlledByApp(ctx, &route))
+	}
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The edge termination uses the HTTP port, and does not use the destination CA
+	spec.Spec.Deployment.Route.Termination = ar.RouteTerminationEdge
+	routes = reconcile()
+	c.AssertEquals(t, 2, len(routes))
+	c.AssertEquals(t, HttpPort, routes[0].Spec.Port.TargetPort.IntValue())
+	c.AssertEquals(t, ocp_route.TLSTerminationEdge, routes[0].Spec.TLS.Termination)
+	c.AssertEquals(t, "certificate", routes[0].Spec.TLS.Certificate)
+	c.AssertEquals(t, "", routes[0].Spec.TLS.DestinationCACertificate)
+
+	// The passthrough termination does not use the certificates
+	spec.Spec.Deployment.Route.Termination = ar.RouteTerminationPassthrough
+	routes = reconcile()
+	c.AssertEquals(t, HttpsPort, routes[0].Spec.Port.TargetPort.IntValue())
+	c.AssertEquals(t, ocp_route.TLSTerminationPassthrough, routes[0].Spec.TLS.Termination)
+	c.AssertEquals(t, "", routes[0].Spec.TLS.Certificate)
+
+	// Not secured
+	spec.Spec.Deployment.Route.Termination = ""
+	routes = reconcile()
+	c.AssertEquals(t, HttpPort, routes[0].Spec.Port.TargetPort.IntValue())
+	c.AssertEquals(t, (*ocp_route.TLSConfig)(nil), routes[0].Spec.TLS)
+	c.AssertEquals(t, false, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+
+	// HTTPS is not enabled, the Routes are kept and the error is reported
+	spec.Spec.Configuration.Security.Https.SecretName = ""
+	spec.Spec.Deployment.Route.Termination = ar.RouteTerminationReencrypt
+	service.Spec.Ports = service.Spec.Ports[:1]
+	routes = reconcile()
+	c.AssertEquals(t, 2, len(routes))
+	c.AssertEquals(t, HttpPort, routes[0].Spec.Port.TargetPort.IntValue())
+	c.AssertEquals(t, true, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+
+	// The Route of the removed host is deleted
+	spec.Spec.Deployment.Route.Termination = ""
+	spec.Spec.Deployment.Hosts.Additional = nil
+	routes = reconcile()
+	c.AssertEquals(t, 1, len(routes))
+	c.AssertEquals(t, "registry.example.com", routes[0].Spec.Host)
+}
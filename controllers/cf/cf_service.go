@@ -1,6 +1,7 @@
 package cf
 
 import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
@@ -16,6 +17,7 @@ import (
 )
 
 var _ loop.ControlFunction = &ServiceCF{}
+var _ loop.CleanupDiscovery = &ServiceCF{}
 
 type ServiceCF struct {
 	ctx              context.LoopContext
@@ -130,14 +132,34 @@ func (this *ServiceCF) Cleanup() bool {
 		// Delete the ingress and SM first
 		return false
 	}
-	if serviceEntry, serviceExists := this.svcResourceCache.Get(resources.RC_KEY_SERVICE); serviceExists {
-		if err := this.svcClients.Kube().DeleteService(serviceEntry.GetValue().(*core.Service)); err != nil && !api_errors.IsNotFound(err) {
-			this.log.Errorw("could not delete service during cleanup", "error", err)
-			return false
-		} else {
-			this.svcResourceCache.Remove(resources.RC_KEY_SERVICE)
-			this.ctx.GetLog().Info("Service has been deleted.")
+	return cleanupCachedResource(this.ctx, this.log, resources.RC_KEY_SERVICE, ar.ManagedResourceKindService,
+		func(value interface{}) error {
+			_, err := this.svcClients.Kube().UpdateService(this.ctx.GetAppNamespace(), value.(*core.Service))
+			return err
+		},
+		func(value interface{}) error {
+			return this.svcClients.Kube().DeleteService(value.(*core.Service))
+		})
+}
+
+func (this *ServiceCF) DiscoverForCleanup() {
+	if _, exists := this.svcResourceCache.Get(resources.RC_KEY_SERVICE); exists {
+		return
+	}
+	services, err := this.svcClients.Kube().GetServices(
+		this.ctx.GetAppNamespace(),
+		meta.ListOptions{
+			LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+		})
+	if err != nil {
+		this.log.Errorw("could not list Services during cleanup", "error", err)
+		return
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if service.GetDeletionTimestamp() == nil && IsOwnedByApp(this.ctx, service) {
+			this.svcResourceCache.Set(resources.RC_KEY_SERVICE, resources.NewResourceCacheEntry(common.Name(service.Name), service))
+			return
 		}
 	}
-	return true
 }
@@ -0,0 +1,258 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"reflect"
+	"sort"
+)
+
+var _ loop.ControlFunction = &ServiceConfigCF{}
+
+// This CF configures the Service type, annotations and additional ports.
+// The `http` and `https` ports are managed by HttpsCF.
+// All Service annotations are managed here, so the values from the Operator configuration,
+// spec.deployment.managedResourcesMetadata and spec.deployment.service do not conflict.
+// The serving certificate annotation is managed by HttpsCertificateCF.
+type ServiceConfigCF struct {
+	ctx                        context.LoopContext
+	log                        *zap.SugaredLogger
+	svcResourceCache           resources.ResourceCache
+	svcKubeFactory             *factory.KubeFactory
+	targetType                 core.ServiceType
+	targetAnnotations          map[string]string
+	targetAnnotationKeys       []string
+	previousAnnotationKeys     []string
+	removedAnnotationKeys      []string
+	targetSourceRanges         []string
+	targetPolicy               core.ServiceExternalTrafficPolicy
+	targetPorts                []core.ServicePort
+	targetRules                []networking.NetworkPolicyIngressRule
+	serviceEntry               resources.ResourceCacheEntry
+	serviceUpToDate            bool
+	networkPolicyEntry         resources.ResourceCacheEntry
+	networkPolicyRulesUpToDate bool
+}
+
+func NewServiceConfigCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &ServiceConfigCF{
+		ctx:              ctx,
+		svcResourceCache: ctx.GetResourceCache(),
+		svcKubeFactory:   services.GetKubeFactory(),
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *ServiceConfigCF) Describe() string {
+	return "ServiceConfigCF"
+}
+
+func (this *ServiceConfigCF) Sense() {
+	// Observation #1
+	// Read the config values and compute the target values
+	serviceSpec := ar.ApicurioRegistrySpecDeploymentService{}
+	var from []networking.NetworkPolicyPeer
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		serviceSpec = spec.Deployment.Service
+		// Same as NetworkPolicyRulesCF
+		if len(spec.Deployment.NetworkPolicy.Ingress.From) > 0 {
+			from = spec.Deployment.NetworkPolicy.Ingress.From
+		}
+	}
+	this.targetType = serviceSpec.Type
+	if this.targetType == "" {
+		this.targetType = core.ServiceTypeClusterIP
+	}
+	this.targetAnnotations = this.svcKubeFactory.GetServiceAnnotations()
+	delete(this.targetAnnotations, OpenShiftServingCertSecretAnnotation)
+	this.targetAnnotationKeys = make([]string, 0, len(this.targetAnnotations))
+	for key := range this.targetAnnotations {
+		this.targetAnnotationKeys = append(this.targetAnnotationKeys, key)
+	}
+	sort.Strings(this.targetAnnotationKeys)
+	// Annotations applied previously, that have been removed from the spec or the Operator configuration
+	this.previousAnnotationKeys = make([]string, 0)
+	this.ctx.GetState().Get(state.STATE_KEY_SERVICE_CONFIG_PREVIOUS_ANNOTATIONS, &this.previousAnnotationKeys)
+	this.removedAnnotationKeys = make([]string, 0)
+	for _, key := range this.previousAnnotationKeys {
+		if _, exists := this.targetAnnotations[key]; !exists && key != OpenShiftServingCertSecretAnnotation {
+			this.removedAnnotationKeys = append(this.removedAnnotationKeys, key)
+		}
+	}
+	this.targetSourceRanges = nil
+	if this.targetType == core.ServiceTypeLoadBalancer && len(serviceSpec.LoadBalancerSourceRanges) > 0 {
+		this.targetSourceRanges = serviceSpec.LoadBalancerSourceRanges
+	}
+	this.targetPolicy = ""
+	if this.targetType != core.ServiceTypeClusterIP {
+		this.targetPolicy = serviceSpec.ExternalTrafficPolicy
+	}
+	var invalid []string
+	this.targetPorts, invalid = NewServiceAdditionalPorts(&serviceSpec)
+	if len(invalid) > 0 {
+		this.log.Warnw("some additional Service ports in spec.deployment.service.ports are invalid and are ignored. "+
+			"The names must be unique, and the ports must not use the name or number of the http and https ports.", "ports", invalid)
+	}
+	this.targetRules = NewServiceIngressRules(this.targetPorts, from)
+
+	// Observation #2
+	// Compare with the Service
+	this.serviceEntry = nil
+	this.serviceUpToDate = true
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_SERVICE); exists {
+		this.serviceEntry = entry
+		this.serviceUpToDate = this.isServiceUpToDate(entry.GetValue().(*core.Service))
+	}
+
+	// Observation #3
+	// Compare with the NetworkPolicy
+	this.networkPolicyEntry = nil
+	this.networkPolicyRulesUpToDate = true
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_NETWORK_POLICY); exists {
+		this.networkPolicyEntry = entry
+		existing := make([]networking.NetworkPolicyIngressRule, 0)
+		for _, rule := range entry.GetValue().(*networking.NetworkPolicy).Spec.Ingress {
+			if !isHttpIngressRule(&rule) {
+				existing = append(existing, rule)
+			}
+		}
+		this.networkPolicyRulesUpToDate = reflect.DeepEqual(existing, this.targetRules)
+	}
+}
+
+func (this *ServiceConfigCF) Compare() bool {
+	// Condition #1
+	// Service exists and is not up to date
+	// Condition #2
+	// NetworkPolicy exists and does not allow the additional ports
+	// Condition #3
+	// Service exists and the applied annotations have not been recorded
+	return (this.serviceEntry != nil && !this.serviceUpToDate) ||
+		(this.networkPolicyEntry != nil && !this.networkPolicyRulesUpToDate) ||
+		(this.serviceEntry != nil && !reflect.DeepEqual(this.previousAnnotationKeys, this.targetAnnotationKeys))
+}
+
+func (this *ServiceConfigCF) Respond() {
+	// Response #1
+	// Patch the Service
+	if this.serviceEntry != nil && !this.serviceUpToDate {
+		this.serviceEntry.ApplyPatch(func(value interface{}) interface{} {
+			service := value.(*core.Service).DeepCopy()
+			service.Spec.Type = this.targetType
+			common.LabelsUpdate(&service.Annotations, this.targetAnnotations)
+			for _, key := range this.removedAnnotationKeys {
+				delete(service.Annotations, key)
+			}
+			service.Spec.LoadBalancerSourceRanges = this.targetSourceRanges
+			if this.targetPolicy != "" || this.targetType == core.ServiceTypeClusterIP {
+				service.Spec.ExternalTrafficPolicy = this.targetPolicy
+			}
+			// Clear the values that are not valid for the Service type
+			if this.targetType != core.ServiceTypeLoadBalancer || service.Spec.ExternalTrafficPolicy != core.ServiceExternalTrafficPolicyLocal {
+				service.Spec.HealthCheckNodePort = 0
+			}
+			if this.targetType != core.ServiceTypeLoadBalancer {
+				service.Spec.AllocateLoadBalancerNodePorts = nil
+			}
+			ports := make([]core.ServicePort, 0, len(service.Spec.Ports)+len(this.targetPorts))
+			nodePorts := make(map[string]int32)
+			for _, port := range service.Spec.Ports {
+				nodePorts[port.Name] = port.NodePort
+				if isHttpServicePort(&port) {
+					if this.targetType == core.ServiceTypeClusterIP {
+						port.NodePort = 0
+					}
+					ports = append(ports, port)
+				}
+			}
+			for _, port := range this.targetPorts {
+				// Keep the node port allocated by Kubernetes
+				if port.NodePort == 0 && this.targetType != core.ServiceTypeClusterIP {
+					port.NodePort = nodePorts[port.Name]
+				}
+				ports = append(ports, port)
+			}
+			service.Spec.Ports = ports
+			return service
+		})
+	}
+	// Response #2
+	// Patch the NetworkPolicy
+	if this.networkPolicyEntry != nil && !this.networkPolicyRulesUpToDate {
+		this.networkPolicyEntry.ApplyPatch(func(value interface{}) interface{} {
+			policy := value.(*networking.NetworkPolicy).DeepCopy()
+			rules := make([]networking.NetworkPolicyIngressRule, 0, len(policy.Spec.Ingress)+len(this.targetRules))
+			for _, rule := range policy.Spec.Ingress {
+				if isHttpIngressRule(&rule) {
+					rules = append(rules, rule)
+				}
+			}
+			policy.Spec.Ingress = append(rules, this.targetRules...)
+			return policy
+		})
+	}
+	// Response #3
+	// Record the applied annotations, so they can be removed later
+	if this.serviceEntry != nil {
+		this.ctx.GetState().Set(state.STATE_KEY_SERVICE_CONFIG_PREVIOUS_ANNOTATIONS, this.targetAnnotationKeys)
+	}
+}
+
+func (this *ServiceConfigCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
+
+// ---
+
+func (this *ServiceConfigCF) isServiceUpToDate(service *core.Service) bool {
+	existingType := service.Spec.Type
+	if existingType == "" {
+		existingType = core.ServiceTypeClusterIP
+	}
+	if existingType != this.targetType ||
+		!common.LabelsEqual(service.Annotations, this.targetAnnotations) ||
+		!(len(service.Spec.LoadBalancerSourceRanges) == 0 && len(this.targetSourceRanges) == 0 ||
+			reflect.DeepEqual(service.Spec.LoadBalancerSourceRanges, this.targetSourceRanges)) {
+		return false
+	}
+	for _, key := range this.removedAnnotationKeys {
+		if _, exists := service.Annotations[key]; exists {
+			return false
+		}
+	}
+	// If the policy is not set, the cluster default is used
+	if (this.targetPolicy != "" || this.targetType == core.ServiceTypeClusterIP) &&
+		service.Spec.ExternalTrafficPolicy != this.targetPolicy {
+		return false
+	}
+	existingPorts := make([]core.ServicePort, 0)
+	for _, port := range service.Spec.Ports {
+		if this.targetType == core.ServiceTypeClusterIP && port.NodePort != 0 {
+			return false
+		}
+		if !isHttpServicePort(&port) {
+			existingPorts = append(existingPorts, port)
+		}
+	}
+	if len(existingPorts) != len(this.targetPorts) {
+		return false
+	}
+	for i := range existingPorts {
+		if !isServicePortEqual(&existingPorts[i], &this.targetPorts[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"testing"
+)
+
+func newServiceConfigTestResources(t *testing.T, spec *ar.ApicurioRegistry) (*context.LoopContextMock, *services2.LoopServicesMock,
+	resources.ResourceCacheEntry, resources.ResourceCacheEntry) {
+	ctx := newLoopContextWithClientsMock(t, spec)
+	services := services2.NewLoopServicesMock(ctx)
+	service := services.GetKubeFactory().CreateService()
+	service.Spec.Ports = []core.ServicePort{{Name: "http", Port: HttpPort, Protocol: core.ProtocolTCP, TargetPort: intstr.FromInt(HttpPort)}}
+	serviceEntry := resources.NewResourceCacheEntry(c.Name(service.Name), service)
+	ctx.GetResourceCache().Set(resources.RC_KEY_SERVICE, serviceEntry)
+	policy := services.GetKubeFactory().CreateNetworkPolicy(service.Name)
+	policy.Spec.Ingress = []networking.NetworkPolicyIngressRule{newHttpIngressRule()}
+	policyEntry := resources.NewResourceCacheEntry(c.Name(policy.Name), policy)
+	ctx.GetResourceCache().Set(resources.RC_KEY_NETWORK_POLICY, policyEntry)
+	return ctx, services, serviceEntry, policyEntry
+}
+
+func newHttpIngressRule() networking.NetworkPolicyIngressRule {
+	port := intstr.FromInt(HttpPort)
+	return networking.NetworkPolicyIngressRule{Ports: []networking.NetworkPolicyPort{{Port: &port}}}
+}
+
+// Runs the control functions until none of them reports a change, and returns the number of attempts
+func runUntilStable(t *testing.T, ctx *context.LoopContextMock, cfs ...loop.ControlFunction) int {
+	for attempt := 0; attempt < 5; attempt++ {
+		ctx.SetAttempts(attempt)
+		changed := false
+		for _, cf := range cfs {
+			cf.Sense()
+			if cf.Compare() {
+				cf.Respond()
+				changed = true
+			}
+		}
+		if !changed {
+			return attempt
+		}
+	}
+	t.Fatal("the control functions have not become stable")
+	return 0
+}
+
+func TestServiceConfigCFType(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Deployment.Service.Type = core.ServiceTypeNodePort
+	spec.Spec.Deployment.Service.Ports = []ar.ApicurioRegistrySpecDeploymentServicePort{{Name: "metrics", Port: 9000}}
+	spec.Spec.Deployment.Service.ExternalTrafficPolicy = core.ServiceExternalTrafficPolicyLocal
+	ctx, services, serviceEntry, policyEntry := newServiceConfigTestResources(t, spec)
+	this := NewServiceConfigCF(ctx, services)
+	service := func() *core.Service {
+		return serviceEntry.GetValue().(*core.Service)
+	}
+
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, core.ServiceTypeNodePort, service().Spec.Type)
+	c.AssertEquals(t, core.ServiceExternalTrafficPolicyLocal, service().Spec.ExternalTrafficPolicy)
+	c.AssertEquals(t, []string{"http", "metrics"}, []string{service().Spec.Ports[0].Name, service().Spec.Ports[1].Name})
+	// The HTTP rule is kept, and the additional port is allowed
+	rules := policyEntry.GetValue().(*networking.NetworkPolicy).Spec.Ingress
+	c.AssertEquals(t, 2, len(rules))
+	c.AssertEquals(t, newHttpIngressRule(), rules[0])
+	c.AssertEquals(t, 9000, rules[1].Ports[0].Port.IntValue())
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The node ports allocated by Kubernetes are kept when the type changes
+	service().Spec.Ports[0].NodePort = 30080
+	service().Spec.Ports[1].NodePort = 30900
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+	spec.Spec.Deployment.Service.Type = core.ServiceTypeLoadBalancer
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, core.ServiceTypeLoadBalancer, service().Spec.Type)
+	c.AssertEquals(t, int32(30080), service().Spec.Ports[0].NodePort)
+	c.AssertEquals(t, int32(30900), service().Spec.Ports[1].NodePort)
+
+	// The node ports and the policy are removed for the ClusterIP type
+	spec.Spec.Deployment.Service.Type = ""
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, core.ServiceTypeClusterIP, service().Spec.Type)
+	c.AssertEquals(t, core.ServiceExternalTrafficPolicy(""), service().Spec.ExternalTrafficPolicy)
+	c.AssertEquals(t, int32(0), service().Spec.Ports[0].NodePort)
+	c.AssertEquals(t, int32(0), service().Spec.Ports[1].NodePort)
+
+	// The additional port and its rule are removed
+	spec.Spec.Deployment.Service.Ports = nil
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, 1, len(service().Spec.Ports))
+	c.AssertEquals(t, []networking.NetworkPolicyIngressRule{newHttpIngressRule()}, policyEntry.GetValue().(*networking.NetworkPolicy).Spec.Ingress)
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+}
+
+func TestServiceConfigCFAnnotations(t *testing.T) {
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Deployment.ManagedResourcesMetadata.Annotations = map[string]string{
+		"example.com/conflict": "managed",
+		"example.com/managed":  "managed",
+	}
+	spec.Spec.Deployment.Service.Annotations = map[string]string{
+		"example.com/conflict": "service",
+		"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+		// Managed by HttpsCertificateCF
+		OpenShiftServingCertSecretAnnotation: "other",
+	}
+	ctx, services, serviceEntry, _ := newServiceConfigTestResources(t, spec)
+	service := serviceEntry.GetValue().(*core.Service)
+	service.Annotations[OpenShiftServingCertSecretAnnotation] = "mock-https-tls"
+	serviceConfigCF := NewServiceConfigCF(ctx, services)
+	labelsCF := NewLabelsCF(ctx, services)
+
+	// The control functions do not overwrite each other's values
+	c.AssertEquals(t, 1, runUntilStable(t, ctx, serviceConfigCF, labelsCF))
+	service = serviceEntry.GetValue().(*core.Service)
+	c.AssertEquals(t, "service", service.Annotations["example.com/conflict"])
+	c.AssertEquals(t, "managed", service.Annotations["example.com/managed"])
+	c.AssertEquals(t, "true", service.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+	c.AssertEquals(t, "mock-https-tls", service.Annotations[OpenShiftServingCertSecretAnnotation])
+
+	// The removed annotations are removed from the Service, unless they are still set elsewhere
+	delete(spec.Spec.Deployment.Service.Annotations, "example.com/conflict")
+	delete(spec.Spec.Deployment.Service.Annotations, "service.beta.kubernetes.io/aws-load-balancer-internal")
+	delete(spec.Spec.Deployment.ManagedResourcesMetadata.Annotations, "example.com/managed")
+	c.AssertEquals(t, 1, runUntilStable(t, ctx, serviceConfigCF, labelsCF))
+	service = serviceEntry.GetValue().(*core.Service)
+	c.AssertEquals(t, "managed", service.Annotations["example.com/conflict"])
+	_, exists := service.Annotations["example.com/managed"]
+	c.AssertEquals(t, false, exists)
+	_, exists = service.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"]
+	c.AssertEquals(t, false, exists)
+	c.AssertEquals(t, "mock-https-tls", service.Annotations[OpenShiftServingCertSecretAnnotation])
+}
@@ -1,12 +1,14 @@
 package cf
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	"reflect"
 )
 
 var _ loop.ControlFunction = &SqlCF{}
@@ -19,13 +21,13 @@ type SqlCF struct {
 	ctx              context.LoopContext
 	svcResourceCache resources.ResourceCache
 	svcEnvCache      env.EnvCache
-	persistence      string
+	persistence      ar.ApicurioRegistryPersistence
 	valid            bool
 	url              string
 	envUrl           env.EnvCacheEntry
 	user             string
 	envUser          env.EnvCacheEntry
-	password         string
+	password         *core.EnvVar
 	envPassword      env.EnvCacheEntry
 	log              *zap.SugaredLogger
 }
@@ -41,7 +43,7 @@ func NewSqlCF(ctx context.LoopContext) loop.ControlFunction {
 		envUrl:           nil,
 		user:             "",
 		envUser:          nil,
-		password:         "",
+		password:         nil,
 		envPassword:      nil,
 		log:              ctx.GetLog().Sugar(),
 	}
@@ -59,14 +61,22 @@ func (this *SqlCF) Sense() {
 		this.persistence = spec.Configuration.Persistence
 		this.url = spec.Configuration.Sql.DataSource.Url
 		this.user = spec.Configuration.Sql.DataSource.UserName
-		this.password = spec.Configuration.Sql.DataSource.Password // Leave empty as default
-		// TODO Use secrets!
+		this.password = &core.EnvVar{
+			Name: ENV_REGISTRY_DATASOURCE_PASSWORD,
+		}
+		if secretKeyRef := spec.Configuration.Sql.DataSource.Password.SecretKeyRef; secretKeyRef != nil {
+			this.password.ValueFrom = &core.EnvVarSource{
+				SecretKeyRef: secretKeyRef,
+			}
+		} else {
+			this.password.Value = spec.Configuration.Sql.DataSource.Password.Value // Leave empty as default
+		}
 	}
 
 	// Observation #2
 	// Is the correct persistence type selected?
 	// Validate the config values
-	this.valid = this.persistence == "sql" && (this.url != "" || this.envUrl != nil) && (this.user != "" || this.envUser != nil)
+	this.valid = this.persistence == ar.PersistenceSql && (this.url != "" || this.envUrl != nil) && (this.user != "" || this.envUser != nil)
 
 	// Observation #3
 	// Read the env values
@@ -105,7 +115,7 @@ func (this *SqlCF) Compare() bool {
 	if this.envPassword == nil {
 		updatePassword = true // Password can be empty
 	} else {
-		updatePassword = this.password != this.envPassword.GetValue().Value && (this.password != "" || this.envPassword.GetPriority() == env.PRIORITY_OPERATOR || this.envPassword.GetPriority() == env.PRIORITY_MIN)
+		updatePassword = !reflect.DeepEqual(this.password, this.envPassword.GetValue()) && (!isEmptyPassword(this.password) || this.envPassword.GetPriority() == env.PRIORITY_OPERATOR || this.envPassword.GetPriority() == env.PRIORITY_MIN)
 	}
 	return this.valid && (updateUrl || updateUser || updatePassword)
 }
@@ -139,12 +149,12 @@ func (this *SqlCF) Respond() {
 		}
 	}
 
-	if this.password != "" {
+	if !isEmptyPassword(this.password) {
 		// Not empty, we just set the variable, overriding spec.configuration.env
-		this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_DATASOURCE_PASSWORD, this.password).Build())
+		this.svcEnvCache.Set(env.NewEnvCacheEntryBuilder(this.password).Build())
 	} else {
 		// Set empty password, but make it overridable
-		this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_DATASOURCE_PASSWORD, this.password).SetPriority(env.PRIORITY_MIN).Build())
+		this.svcEnvCache.Set(env.NewEnvCacheEntryBuilder(this.password).SetPriority(env.PRIORITY_MIN).Build())
 	}
 }
 
@@ -152,3 +162,7 @@ func (this *SqlCF) Cleanup() bool {
 	// No cleanup
 	return true
 }
+
+func isEmptyPassword(password *core.EnvVar) bool {
+	return password == nil || (password.Value == "" && password.ValueFrom == nil)
+}
@@ -0,0 +1,118 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"reflect"
+)
+
+var _ loop.ControlFunction = &StrategyCF{}
+
+type StrategyCF struct {
+	ctx              context.LoopContext
+	log              *zap.SugaredLogger
+	services         services.LoopServices
+	svcResourceCache resources.ResourceCache
+	deploymentEntry  resources.ResourceCacheEntry
+	existingStrategy apps.DeploymentStrategy
+	targetStrategy   apps.DeploymentStrategy
+}
+
+func NewStrategyCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &StrategyCF{
+		ctx:              ctx,
+		services:         services,
+		svcResourceCache: ctx.GetResourceCache(),
+		deploymentEntry:  nil,
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *StrategyCF) Describe() string {
+	return "StrategyCF"
+}
+
+func (this *StrategyCF) Sense() {
+	// Observation #1
+	// Get the cached Deployment
+	this.deploymentEntry = nil
+	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT); exists {
+		this.deploymentEntry = entry
+		this.existingStrategy = entry.GetValue().(*apps.Deployment).Spec.Strategy
+	}
+
+	// Observation #2
+	// Get the target strategy
+	this.targetStrategy = apps.DeploymentStrategy{}
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+		if spec.Deployment.Strategy != nil {
+			this.targetStrategy = *spec.Deployment.Strategy.DeepCopy()
+		} else {
+			switch spec.Configuration.Persistence {
+			case "", ar.PersistenceMem:
+				// The data is not shared, so the old and new pods should not run at the same time
+				this.targetStrategy.Type = apps.RecreateDeploymentStrategyType
+			}
+		}
+	}
+	if this.targetStrategy.Type == apps.RecreateDeploymentStrategyType && this.targetStrategy.RollingUpdate != nil {
+		this.log.Errorw("rollingUpdate can not be set when the strategy type is Recreate")
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(string(this.targetStrategy.Type), "spec.deployment.strategy.type")
+		this.targetStrategy.RollingUpdate = nil
+	}
+	setDeploymentStrategyDefaults(&this.targetStrategy)
+}
+
+func (this *StrategyCF) Compare() bool {
+	// Condition #1
+	// Deployment exists
+	// Condition #2
+	// Existing strategy is different from target strategy
+	return this.deploymentEntry != nil &&
+		!reflect.DeepEqual(this.existingStrategy, this.targetStrategy)
+}
+
+func (this *StrategyCF) Respond() {
+	// Response #1
+	// Patch the resource
+	this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+		deployment := value.(*apps.Deployment).DeepCopy()
+		deployment.Spec.Strategy = this.targetStrategy
+		return deployment
+	})
+}
+
+func (this *StrategyCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
+
+// Set the same default values as the API server,
+// so the strategy does not appear to be changed after the Deployment is read back
+func setDeploymentStrategyDefaults(strategy *apps.DeploymentStrategy) {
+	if strategy.Type == "" {
+		strategy.Type = apps.RollingUpdateDeploymentStrategyType
+	}
+	if strategy.Type == apps.RollingUpdateDeploymentStrategyType {
+		if strategy.RollingUpdate == nil {
+			strategy.RollingUpdate = &apps.RollingUpdateDeployment{}
+		}
+		if strategy.RollingUpdate.MaxUnavailable == nil {
+			maxUnavailable := intstr.FromString("25%")
+			strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+		}
+		if strategy.RollingUpdate.MaxSurge == nil {
+			maxSurge := intstr.FromString("25%")
+			strategy.RollingUpdate.MaxSurge = &maxSurge
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"testing"
+)
+
+func TestSetDeploymentStrategyDefaults(t *testing.T) {
+	strategy := apps.DeploymentStrategy{}
+	setDeploymentStrategyDefaults(&strategy)
+	c.AssertEquals(t, apps.RollingUpdateDeploymentStrategyType, strategy.Type)
+	c.AssertEquals(t, intstr.FromString("25%"), *strategy.RollingUpdate.MaxUnavailable)
+	c.AssertEquals(t, intstr.FromString("25%"), *strategy.RollingUpdate.MaxSurge)
+
+	maxSurge := intstr.FromInt(1)
+	strategy = apps.DeploymentStrategy{
+		RollingUpdate: &apps.RollingUpdateDeployment{MaxSurge: &maxSurge},
+	}
+	setDeploymentStrategyDefaults(&strategy)
+	c.AssertEquals(t, intstr.FromString("25%"), *strategy.RollingUpdate.MaxUnavailable)
+	c.AssertEquals(t, maxSurge, *strategy.RollingUpdate.MaxSurge)
+
+	strategy = apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType}
+	setDeploymentStrategyDefaults(&strategy)
+	c.AssertEquals(t, (*apps.RollingUpdateDeployment)(nil), strategy.RollingUpdate)
+}
+
+func TestStrategyCF(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	services := services2.NewLoopServicesMock(ctx)
+	spec := &ar.ApicurioRegistry{}
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), spec))
+	// Strategy as defaulted by the API server
+	deployment := &apps.Deployment{}
+	setDeploymentStrategyDefaults(&deployment.Spec.Strategy)
+	deploymentEntry := resources.NewResourceCacheEntry(ctx.GetAppName(), deployment)
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, deploymentEntry)
+	this := NewStrategyCF(ctx, services)
+
+	// The in-memory storage uses Recreate by default
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType},
+		deploymentEntry.GetValue().(*apps.Deployment).Spec.Strategy)
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// Other storages use the API server defaults, so the Deployment is not updated after it is read back
+	spec.Spec.Configuration.Persistence = ar.PersistenceSql
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, deployment.Spec.Strategy, deploymentEntry.GetValue().(*apps.Deployment).Spec.Strategy)
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, resources.NewResourceCacheEntry(ctx.GetAppName(), deployment.DeepCopy()))
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// Partial configuration
+	maxUnavailable := intstr.FromInt(0)
+	spec.Spec.Deployment.Strategy = &apps.DeploymentStrategy{
+		RollingUpdate: &apps.RollingUpdateDeployment{MaxUnavailable: &maxUnavailable},
+	}
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	deploymentEntry, _ = ctx.GetResourceCache().Get(resources.RC_KEY_DEPLOYMENT)
+	strategy := deploymentEntry.GetValue().(*apps.Deployment).Spec.Strategy
+	c.AssertEquals(t, apps.RollingUpdateDeploymentStrategyType, strategy.Type)
+	c.AssertEquals(t, maxUnavailable, *strategy.RollingUpdate.MaxUnavailable)
+	c.AssertEquals(t, intstr.FromString("25%"), *strategy.RollingUpdate.MaxSurge)
+	// The spec is not modified
+	c.AssertEquals(t, (*intstr.IntOrString)(nil), spec.Spec.Deployment.Strategy.RollingUpdate.MaxSurge)
+	c.AssertEquals(t, false, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+
+	// Recreate with rollingUpdate is rejected, and rollingUpdate is ignored
+	spec.Spec.Deployment.Strategy.Type = apps.RecreateDeploymentStrategyType
+	this.Sense()
+	c.AssertEquals(t, true, services.GetConditionManager().GetConfigurationErrorCondition().IsActive())
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType},
+		deploymentEntry.GetValue().(*apps.Deployment).Spec.Strategy)
+}
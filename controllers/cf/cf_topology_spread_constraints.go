@@ -0,0 +1,88 @@
+package cf
+
+import (
+	"reflect"
+
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ loop.ControlFunction = &TopologySpreadConstraintsCF{}
+
+// The topology spread constraints from spec.deployment.podTemplateSpec are applied by PodTemplateSpecCF,
+// this CF merges them with the constraints generated by the high availability preset.
+type TopologySpreadConstraintsCF struct {
+	ctx                   context.LoopContext
+	svcResourceCache      resources.ResourceCache
+	svcKubeFactory        *factory.KubeFactory
+	deploymentEntry       resources.ResourceCacheEntry
+	deploymentEntryExists bool
+	existingConstraints   []corev1.TopologySpreadConstraint
+	targetConstraints     []corev1.TopologySpreadConstraint
+}
+
+func NewTopologySpreadConstraintsCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	return &TopologySpreadConstraintsCF{
+		ctx:                   ctx,
+		svcResourceCache:      ctx.GetResourceCache(),
+		svcKubeFactory:        services.GetKubeFactory(),
+		deploymentEntry:       nil,
+		deploymentEntryExists: false,
+		existingConstraints:   nil,
+		targetConstraints:     nil,
+	}
+}
+
+func (this *TopologySpreadConstraintsCF) Describe() string {
+	return "TopologySpreadConstraintsCF"
+}
+
+func (this *TopologySpreadConstraintsCF) Sense() {
+	// Observation #1
+	// Get the cached deployment
+	this.deploymentEntry, this.deploymentEntryExists = this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT)
+
+	if this.deploymentEntryExists {
+		// Observation #2
+		// Get the existing constraints
+		this.existingConstraints = this.deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Spec.TopologySpreadConstraints
+
+		// Observation #3
+		// Get the target constraints
+		if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+			spec := specEntry.GetValue().(*ar.ApicurioRegistry).Spec
+			this.targetConstraints = NewHighAvailabilityTopologySpreadConstraints(&spec, this.svcKubeFactory.GetSelectorLabels())
+		}
+	}
+}
+
+func (this *TopologySpreadConstraintsCF) Compare() bool {
+	// Condition #1
+	// Deployment exists
+	// Condition #2
+	// Existing constraints are different from target constraints
+	return this.deploymentEntryExists &&
+		!reflect.DeepEqual(this.existingConstraints, this.targetConstraints)
+}
+
+func (this *TopologySpreadConstraintsCF) Respond() {
+	// Response #1
+	// Patch the resource
+	this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+		deployment := value.(*apps.Deployment).DeepCopy()
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = this.targetConstraints
+		return deployment
+	})
+}
+
+func (this *TopologySpreadConstraintsCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
 	"go.uber.org/zap"
 	apps "k8s.io/api/apps/v1"
 )
@@ -41,6 +42,8 @@ func (this *UpgradeCF) Sense() {
 	// Observation #1
 	// Get the cached Deployment (if it exists and/or the value)
 	this.containerNameUpgradeNeeded = false
+	this.containerNameUpgradeDone = false
+	this.ctx.GetState().Get(state.STATE_KEY_UPGRADE_CONTAINER_NAME_DONE, &this.containerNameUpgradeDone)
 	if entry, exists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT); exists {
 		containers := entry.GetValue().(*apps.Deployment).Spec.Template.Spec.Containers
 		oldContainer := common.GetContainerByName(containers, this.ctx.GetAppName().Str())
@@ -73,6 +76,7 @@ func (this *UpgradeCF) Respond() {
 				return deployment
 			})
 			this.containerNameUpgradeDone = true
+			this.ctx.GetState().Set(state.STATE_KEY_UPGRADE_CONTAINER_NAME_DONE, true)
 			this.log.Infow("upgrade successful: renamed container name")
 		}
 	}
@@ -0,0 +1,113 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Helpers for the Cleanup() of the control functions,
+// which is executed when the ApicurioRegistry resource is being deleted.
+
+// Returns true if the resources of the kind are kept when the ApicurioRegistry resource is deleted,
+// see spec.deployment.managedResources.retainOnDeletion
+func IsRetainedOnDeletion(ctx context.LoopContext, kind ar.ApicurioRegistryManagedResourceKind) bool {
+	if specEntry, exists := ctx.GetResourceCache().Get(resources.RC_KEY_SPEC); exists {
+		for _, retained := range specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.ManagedResources.RetainOnDeletion {
+			if retained == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns true if the resource is owned by the ApicurioRegistry resource
+func IsOwnedByApp(ctx context.LoopContext, object meta.Object) bool {
+	if specEntry, exists := ctx.GetResourceCache().Get(resources.RC_KEY_SPEC); exists {
+		uid := specEntry.GetValue().(*ar.ApicurioRegistry).UID
+		for _, ref := range object.GetOwnerReferences() {
+			if ref.UID == uid {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns true if the resource is controlled by the ApicurioRegistry resource,
+// i.e. it has been created by this Operator for this ApicurioRegistry
+func IsControlledByApp(ctx context.LoopContext, object meta.Object) bool {
+	if specEntry, exists := ctx.GetResourceCache().Get(resources.RC_KEY_SPEC); exists {
+		if ref := meta.GetControllerOf(object); ref != nil {
+			return ref.UID == specEntry.GetValue().(*ar.ApicurioRegistry).UID
+		}
+	}
+	return false
+}
+
+// Removes the owner reference to the ApicurioRegistry resource, so the resource is not deleted by the garbage collector.
+// Returns false if there was no such owner reference, and the resource does not have to be updated.
+func RemoveAppOwnerReference(ctx context.LoopContext, object meta.Object) bool {
+	specEntry, exists := ctx.GetResourceCache().Get(resources.RC_KEY_SPEC)
+	if !exists {
+		return false
+	}
+	uid := specEntry.GetValue().(*ar.ApicurioRegistry).UID
+	refs := make([]meta.OwnerReference, 0)
+	for _, ref := range object.GetOwnerReferences() {
+		if ref.UID != uid {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == len(object.GetOwnerReferences()) {
+		return false
+	}
+	object.SetOwnerReferences(refs)
+	return true
+}
+
+// Deletes the cached resource, or removes its owner reference if the kind is retained on deletion.
+// The update function receives a copy of the cached value.
+// Returns false if the request has failed, so the cleanup is retried.
+func cleanupCachedResource(ctx context.LoopContext, log *zap.SugaredLogger, key string, kind ar.ApicurioRegistryManagedResourceKind,
+	update func(value interface{}) error, delete func(value interface{}) error) bool {
+
+	entry, exists := ctx.GetResourceCache().Get(key)
+	if !exists {
+		return true
+	}
+	if IsRetainedOnDeletion(ctx, kind) {
+		value := entry.GetValue().(runtime.Object).DeepCopyObject()
+		if RemoveAppOwnerReference(ctx, value.(meta.Object)) {
+			if err := update(value); err != nil && !api_errors.IsNotFound(err) {
+				log.Errorw("could not remove the owner reference during cleanup", "kind", kind, "error", err)
+				return false
+			}
+		}
+		ctx.GetResourceCache().Remove(key)
+		ctx.GetLog().Sugar().Infow("resource has been retained", "kind", kind, "name", entry.GetName())
+		return true
+	}
+	if err := delete(entry.GetValue()); err != nil && !api_errors.IsNotFound(err) {
+		log.Errorw("could not delete resource during cleanup", "kind", kind, "error", err)
+		return false
+	}
+	ctx.GetResourceCache().Remove(key)
+	ctx.GetLog().Sugar().Infow("resource has been deleted", "kind", kind, "name", entry.GetName())
+	return true
+}
+
+// Returns the first resource that is owned by the ApicurioRegistry resource, or nil
+func findOwnedByApp(ctx context.LoopContext, objects []meta.Object) meta.Object {
+	for _, object := range objects {
+		if IsOwnedByApp(ctx, object) {
+			return object
+		}
+	}
+	return nil
+}
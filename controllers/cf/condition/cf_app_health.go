@@ -84,7 +84,8 @@ func (this *AppHealthCF) Sense() {
 
 		this.requestReadinessOk = false
 		this.requestLivenessOk = false
-		if this.targetType == core.ServiceTypeClusterIP && this.targetIP != "" {
+		if hasClusterIP(this.targetType, this.targetIP, this.log) {
+			updateClientCertificate(this.ctx, this.services, &this.httpClient, this.log)
 			url := scheme + this.targetIP + ":" + port + "/health/ready"
 			res, err := this.httpClient.Get(url)
 			if err == nil {
@@ -88,7 +88,8 @@ func (this *InitializingCF) Sense() {
 		if this.ctx.GetTestingSupport().IsEnabled() {
 			this.requestOk = this.ctx.GetTestingSupport().GetMockCanMakeHTTPRequestToOperand(this.ctx.GetAppNamespace().Str())
 		} else {
-			if this.targetType == core.ServiceTypeClusterIP && this.targetIP != "" {
+			if hasClusterIP(this.targetType, this.targetIP, this.log) {
+				updateClientCertificate(this.ctx, this.services, &this.httpClient, this.log)
 				// NOTE: The client will follow redirects, but I have found that there is a strange issue with a cyclic redirect:
 				// http://172.30.162.200:8080 -> http://172.30.162.200:8080/ui -> http://172.30.162.200:8080/ui
 				// that ends with the client returning status 404. Therefore, we are using /apis instead.
@@ -0,0 +1,109 @@
+package condition
+
+import (
+	"github.com/Apicurio/apicurio-registry-operator/controllers/cf"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	apps "k8s.io/api/apps/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const RevisionAnnotation = "deployment.kubernetes.io/revision"
+
+var _ loop.ControlFunction = &RolloutCF{}
+
+// This CF reports a Deployment rollout that has exceeded its progress deadline.
+type RolloutCF struct {
+	ctx            context.LoopContext
+	log            *zap.SugaredLogger
+	services       services.LoopServices
+	rolloutFailed  bool
+	replicaSetName string
+}
+
+func NewRolloutCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &RolloutCF{
+		ctx:           ctx,
+		services:      services,
+		rolloutFailed: false,
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *RolloutCF) Describe() string {
+	return "RolloutCF"
+}
+
+func (this *RolloutCF) Sense() {
+	// Improve speed by avoiding unnecessary API requests
+	if this.ctx.GetAttempts() > 0 {
+		return
+	}
+
+	// Observation #1
+	// Has the Deployment exceeded the progress deadline?
+	this.rolloutFailed = false
+	this.replicaSetName = ""
+	deploymentEntry, exists := this.ctx.GetResourceCache().Get(resources.RC_KEY_DEPLOYMENT)
+	if !exists {
+		return
+	}
+	deployment := deploymentEntry.GetValue().(*apps.Deployment)
+	this.rolloutFailed = cf.IsDeploymentProgressDeadlineExceeded(deployment)
+
+	// Observation #2
+	// Find the ReplicaSet of the current revision, which has failed to progress
+	if this.rolloutFailed {
+		replicaSets, err := this.ctx.GetClients().Kube().GetReplicaSets(this.ctx.GetAppNamespace(), meta.ListOptions{
+			LabelSelector: "app=" + this.ctx.GetAppName().Str(),
+		})
+		if err == nil {
+			for _, replicaSet := range replicaSets.Items {
+				if isOwnedBy(&replicaSet, deployment) &&
+					replicaSet.Annotations[RevisionAnnotation] == deployment.Annotations[RevisionAnnotation] {
+					this.replicaSetName = replicaSet.Name
+				}
+			}
+		} else {
+			this.log.Warnw("could not list ReplicaSets", "error", err)
+		}
+		if this.replicaSetName == "" {
+			this.replicaSetName = "<unknown>"
+		}
+	}
+}
+
+func (this *RolloutCF) Compare() bool {
+	// Condition #1
+	// Rollout has failed
+	// Prevent loop from getting stable by only executing once
+	return this.rolloutFailed && this.ctx.GetAttempts() == 0
+}
+
+func (this *RolloutCF) Respond() {
+	// Response #1
+	// Report the failed rollout
+	this.log.Warnw("Deployment rollout has exceeded its progress deadline", "replicaSet", this.replicaSetName)
+	this.services.GetConditionManager().GetRolloutFailedCondition().TransitionProgressDeadlineExceeded(this.replicaSetName)
+	this.services.GetConditionManager().GetReadyCondition().TransitionError()
+	// The Deployment status changes trigger a reconciliation, but check periodically as well
+	this.ctx.SetRequeueDelaySec(60)
+}
+
+func (this *RolloutCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
+
+func isOwnedBy(object meta.Object, owner meta.Object) bool {
+	for _, ref := range object.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
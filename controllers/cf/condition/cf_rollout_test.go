@@ -0,0 +1,85 @@
+package condition
+
+import (
+	"github.com/Apicurio/apicurio-registry-operator/controllers/cf"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
+	"testing"
+)
+
+func newReplicaSet(name string, revision string, ownerUID string) *apps.ReplicaSet {
+	return &apps.ReplicaSet{
+		ObjectMeta: meta.ObjectMeta{
+			Name:            name,
+			Namespace:       "mock",
+			Labels:          map[string]string{"app": "mock"},
+			Annotations:     map[string]string{RevisionAnnotation: revision},
+			OwnerReferences: []meta.OwnerReference{{UID: types.UID(ownerUID)}},
+		},
+	}
+}
+
+func TestRolloutCF(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	ctx.SetClients(client.NewClientsMock(ctx.GetLog(), runtime.NewScheme(),
+		newReplicaSet("mock-1", "1", "deployment-uid"),
+		newReplicaSet("mock-2", "2", "deployment-uid"),
+		newReplicaSet("other-2", "2", "other-uid"),
+	))
+	services := services2.NewLoopServicesMock(ctx)
+	deployment := &apps.Deployment{
+		ObjectMeta: meta.ObjectMeta{
+			UID:         "deployment-uid",
+			Annotations: map[string]string{RevisionAnnotation: "2"},
+		},
+		Status: apps.DeploymentStatus{
+			Conditions: []apps.DeploymentCondition{
+				{Type: apps.DeploymentAvailable, Status: core.ConditionTrue},
+				{Type: apps.DeploymentProgressing, Status: core.ConditionTrue, Reason: "ReplicaSetUpdated"},
+			},
+		},
+	}
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, resources.NewResourceCacheEntry(ctx.GetAppName(), deployment))
+	this := NewRolloutCF(ctx, services)
+	rolloutFailed := services.GetConditionManager().GetRolloutFailedCondition()
+
+	// The rollout is progressing
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The rollout has failed, the ReplicaSet of the current revision is reported
+	deployment.Status.Conditions[1] = apps.DeploymentCondition{
+		Type:   apps.DeploymentProgressing,
+		Status: core.ConditionFalse,
+		Reason: cf.DeploymentReasonProgressDeadlineExceeded,
+	}
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, true, rolloutFailed.IsActive())
+	c.AssertEquals(t, true, strings.Contains(rolloutFailed.GetData().Message, "ReplicaSet mock-2 "))
+	requeue, _ := ctx.GetRequeueDelay()
+	c.AssertEquals(t, true, requeue)
+
+	// Only once per loop
+	ctx.SetAttempts(1)
+	this.Sense()
+	c.AssertEquals(t, false, this.Compare())
+
+	// The ReplicaSet of the current revision is not found
+	ctx.SetAttempts(0)
+	deployment.Annotations[RevisionAnnotation] = "3"
+	this.Sense()
+	c.AssertEquals(t, true, this.Compare())
+	this.Respond()
+	c.AssertEquals(t, true, strings.Contains(rolloutFailed.GetData().Message, "ReplicaSet <unknown> "))
+}
@@ -0,0 +1,39 @@
+package condition
+
+import (
+	"crypto/tls"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/cf"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"go.uber.org/zap"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"reflect"
+)
+
+// If HTTPS client authentication is enabled, configure the HTTP client to present the Operator client certificate,
+// see HttpsClientAuthCF
+func updateClientCertificate(ctx context.LoopContext, services services.LoopServices, httpClient *http.Client, log *zap.SugaredLogger) {
+	var certificates []tls.Certificate = nil
+	if _, exists := ctx.GetEnvCache().Get(cf.ENV_QUARKUS_HTTP_SSL_CLIENT_AUTH); exists {
+		secretName := services.GetCertificateFactory().GetHttpsOperatorClientSecretName()
+		secret, err := ctx.GetClients().Kube().GetSecret(ctx.GetAppNamespace(), c.Name(secretName), &meta.GetOptions{})
+		if err == nil {
+			certificate, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+			if err == nil {
+				certificates = []tls.Certificate{certificate}
+			} else {
+				log.Warnw("could not load the Operator client certificate", "secretName", secretName, "error", err)
+			}
+		} else {
+			log.Warnw("could not get the Operator client certificate Secret", "secretName", secretName, "error", err)
+		}
+	}
+	transport := httpClient.Transport.(*http.Transport)
+	if !reflect.DeepEqual(transport.TLSClientConfig.Certificates, certificates) {
+		transport.TLSClientConfig.Certificates = certificates
+		// Do not reuse connections with the previous certificate
+		httpClient.CloseIdleConnections()
+	}
+}
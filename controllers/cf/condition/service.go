@@ -0,0 +1,20 @@
+package condition
+
+import (
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+)
+
+// The Apicurio Registry pods are reached through the cluster IP of the Service,
+// which is allocated for the ClusterIP, NodePort and LoadBalancer types.
+// Returns false if the Service has not been created yet, or if it is headless.
+func hasClusterIP(serviceType core.ServiceType, clusterIP string, log *zap.SugaredLogger) bool {
+	if clusterIP == "" {
+		return false
+	}
+	if serviceType == core.ServiceTypeExternalName || clusterIP == core.ClusterIPNone {
+		log.Warnw("the Service does not have a cluster IP, the health check is skipped", "type", serviceType)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+package condition
+
+import (
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	core "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestHasClusterIP(t *testing.T) {
+	log := c.GetRootLogger(true).Sugar()
+	c.AssertEquals(t, true, hasClusterIP(core.ServiceTypeClusterIP, "10.0.0.1", log))
+	c.AssertEquals(t, true, hasClusterIP(core.ServiceTypeNodePort, "10.0.0.1", log))
+	c.AssertEquals(t, true, hasClusterIP(core.ServiceTypeLoadBalancer, "10.0.0.1", log))
+	// The default type
+	c.AssertEquals(t, true, hasClusterIP("", "10.0.0.1", log))
+	// The Service has not been created yet
+	c.AssertEquals(t, false, hasClusterIP(core.ServiceTypeClusterIP, "", log))
+	// Headless
+	c.AssertEquals(t, false, hasClusterIP(core.ServiceTypeClusterIP, core.ClusterIPNone, log))
+	c.AssertEquals(t, false, hasClusterIP(core.ServiceTypeExternalName, "", log))
+}
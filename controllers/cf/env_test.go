@@ -1,7 +1,7 @@
 package cf
 
 import (
-	v1 "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	loop_impl "github.com/Apicurio/apicurio-registry-operator/controllers/loop/impl"
@@ -21,9 +21,9 @@ func TestEnvCF(t *testing.T) {
 	loop := loop_impl.NewControlLoopImpl(ctx, services)
 	loop.AddControlFunction(NewEnvCF(ctx))
 
-	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &v1.ApicurioRegistry{
-		Spec: v1.ApicurioRegistrySpec{
-			Configuration: v1.ApicurioRegistrySpecConfiguration{
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+		Spec: ar.ApicurioRegistrySpec{
+			Configuration: ar.ApicurioRegistrySpecConfiguration{
 				Env: []corev1.EnvVar{
 					{
 						Name:  "VAR_1_NAME",
@@ -58,9 +58,9 @@ func TestEnvCF(t *testing.T) {
 		},
 	}, ctx.GetEnvCache().GetSorted())
 	// Reordering
-	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &v1.ApicurioRegistry{
-		Spec: v1.ApicurioRegistrySpec{
-			Configuration: v1.ApicurioRegistrySpecConfiguration{
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+		Spec: ar.ApicurioRegistrySpec{
+			Configuration: ar.ApicurioRegistrySpecConfiguration{
 				Env: []corev1.EnvVar{
 					{
 						Name:  "VAR_3_NAME",
@@ -95,9 +95,9 @@ func TestEnvCF(t *testing.T) {
 		},
 	}, ctx.GetEnvCache().GetSorted())
 	// Removing
-	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &v1.ApicurioRegistry{
-		Spec: v1.ApicurioRegistrySpec{
-			Configuration: v1.ApicurioRegistrySpecConfiguration{
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+		Spec: ar.ApicurioRegistrySpec{
+			Configuration: ar.ApicurioRegistrySpecConfiguration{
 				Env: []corev1.EnvVar{
 					{
 						Name:  "VAR_3_NAME",
@@ -146,9 +146,9 @@ func TestEnvOrdering(t *testing.T) {
 			},
 		},
 	}))
-	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &v1.ApicurioRegistry{
-		Spec: v1.ApicurioRegistrySpec{
-			Configuration: v1.ApicurioRegistrySpecConfiguration{
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+		Spec: ar.ApicurioRegistrySpec{
+			Configuration: ar.ApicurioRegistrySpecConfiguration{
 				Env: []corev1.EnvVar{
 					{
 						Name:  "SPEC_VAR_1_NAME",
@@ -164,9 +164,9 @@ func TestEnvOrdering(t *testing.T) {
 	}))
 	loop.Run()
 	ctx.Finalize()
-	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &v1.ApicurioRegistry{
-		Spec: v1.ApicurioRegistrySpec{
-			Configuration: v1.ApicurioRegistrySpecConfiguration{
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+		Spec: ar.ApicurioRegistrySpec{
+			Configuration: ar.ApicurioRegistrySpecConfiguration{
 				Env: []corev1.EnvVar{
 					{
 						Name:  "SPEC_VAR_2_NAME",
@@ -204,9 +204,9 @@ func TestEnvPriority(t *testing.T) {
 
 	// In reverse priority
 	// Spec Sourced
-	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &v1.ApicurioRegistry{
-		Spec: v1.ApicurioRegistrySpec{
-			Configuration: v1.ApicurioRegistrySpecConfiguration{
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+		Spec: ar.ApicurioRegistrySpec{
+			Configuration: ar.ApicurioRegistrySpecConfiguration{
 				Env: []corev1.EnvVar{
 					{
 						Name:  "VAR_1_NAME",
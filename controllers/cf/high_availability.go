@@ -0,0 +1,85 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Topology keys used by the high availability presets, ordered by preference
+func highAvailabilityTopologyKeys(spec *ar.ApicurioRegistrySpec) []string {
+	// A single replica can not be spread
+	if spec.Deployment.Replicas <= 1 {
+		return nil
+	}
+	switch spec.Deployment.HighAvailability.Preset {
+	case ar.HighAvailabilityPresetNode:
+		return []string{core.LabelHostname}
+	case ar.HighAvailabilityPresetZone:
+		return []string{core.LabelTopologyZone, core.LabelHostname}
+	}
+	return nil
+}
+
+// Build the target affinity from spec.deployment.affinity and the high availability preset.
+// The preset adds preferred pod anti-affinity terms, unless the user already uses the topology key.
+func NewHighAvailabilityAffinity(spec *ar.ApicurioRegistrySpec, selectorLabels map[string]string) *core.Affinity {
+	res := spec.Deployment.Affinity.DeepCopy()
+	used := make(map[string]bool)
+	if res != nil && res.PodAntiAffinity != nil {
+		for _, term := range res.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			used[term.TopologyKey] = true
+		}
+		for _, term := range res.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			used[term.PodAffinityTerm.TopologyKey] = true
+		}
+	}
+	weight := int32(100)
+	for _, key := range highAvailabilityTopologyKeys(spec) {
+		if !used[key] {
+			if res == nil {
+				res = &core.Affinity{}
+			}
+			if res.PodAntiAffinity == nil {
+				res.PodAntiAffinity = &core.PodAntiAffinity{}
+			}
+			res.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+				res.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, core.WeightedPodAffinityTerm{
+					Weight: weight,
+					PodAffinityTerm: core.PodAffinityTerm{
+						LabelSelector: &meta.LabelSelector{MatchLabels: selectorLabels},
+						TopologyKey:   key,
+					},
+				})
+		}
+		// Less preferred keys have a lower weight
+		weight = weight / 2
+	}
+	return res
+}
+
+// Build the target topology spread constraints from spec.deployment.podTemplateSpec and the high availability preset.
+// The preset adds constraints that do not block scheduling, unless the user already uses the topology key.
+func NewHighAvailabilityTopologySpreadConstraints(spec *ar.ApicurioRegistrySpec, selectorLabels map[string]string) []core.TopologySpreadConstraint {
+	res := make([]core.TopologySpreadConstraint, 0)
+	used := make(map[string]bool)
+	for _, constraint := range spec.Deployment.PodTemplateSpec.Spec.TopologySpreadConstraints {
+		res = append(res, *constraint.DeepCopy())
+		used[constraint.TopologyKey] = true
+	}
+	for _, key := range highAvailabilityTopologyKeys(spec) {
+		if !used[key] {
+			res = append(res, core.TopologySpreadConstraint{
+				MaxSkew:           1,
+				TopologyKey:       key,
+				WhenUnsatisfiable: core.ScheduleAnyway,
+				LabelSelector:     &meta.LabelSelector{MatchLabels: selectorLabels},
+			})
+		}
+	}
+	// Empty value is not stored in the Deployment
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	core "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestHighAvailability(t *testing.T) {
+	labels := map[string]string{"app": "test"}
+	spec := &ar.ApicurioRegistrySpec{
+		Deployment: ar.ApicurioRegistrySpecDeployment{
+			Replicas: 1,
+			HighAvailability: ar.ApicurioRegistrySpecDeploymentHighAvailability{
+				Preset: ar.HighAvailabilityPresetZone,
+			},
+		},
+	}
+	c.AssertEquals(t, (*core.Affinity)(nil), NewHighAvailabilityAffinity(spec, labels))
+	c.AssertEquals(t, []core.TopologySpreadConstraint(nil), NewHighAvailabilityTopologySpreadConstraints(spec, labels))
+
+	spec.Deployment.Replicas = 3
+	spec.Deployment.Affinity = &core.Affinity{
+		PodAntiAffinity: &core.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{
+				{TopologyKey: core.LabelHostname},
+			},
+		},
+	}
+	spec.Deployment.PodTemplateSpec.Spec.TopologySpreadConstraints = []core.TopologySpreadConstraint{
+		{MaxSkew: 2, TopologyKey: core.LabelTopologyZone, WhenUnsatisfiable: core.DoNotSchedule},
+	}
+	affinity := NewHighAvailabilityAffinity(spec, labels)
+	c.AssertEquals(t, 1, len(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution))
+	c.AssertEquals(t, 1, len(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution))
+	c.AssertEquals(t, core.LabelTopologyZone, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey)
+	c.AssertEquals(t, labels, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.LabelSelector.MatchLabels)
+	// The spec is not modified
+	c.AssertEquals(t, 0, len(spec.Deployment.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution))
+
+	constraints := NewHighAvailabilityTopologySpreadConstraints(spec, labels)
+	c.AssertEquals(t, 2, len(constraints))
+	c.AssertEquals(t, int32(2), constraints[0].MaxSkew)
+	c.AssertEquals(t, core.LabelHostname, constraints[1].TopologyKey)
+	c.AssertEquals(t, core.ScheduleAnyway, constraints[1].WhenUnsatisfiable)
+
+	spec.Deployment.HighAvailability.Preset = ar.HighAvailabilityPresetNode
+	spec.Deployment.Affinity = nil
+	affinity = NewHighAvailabilityAffinity(spec, labels)
+	c.AssertEquals(t, 1, len(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution))
+	c.AssertEquals(t, int32(100), affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight)
+	c.AssertEquals(t, core.LabelHostname, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey)
+}
@@ -0,0 +1,48 @@
+package cf
+
+import (
+	"regexp"
+	"strings"
+)
+
+var imageVersionRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+// Split the image into the repository and the tag (or digest, including the separator)
+func splitImage(image string) (string, string) {
+	nameStart := strings.LastIndex(image, "/") + 1
+	if i := strings.Index(image[nameStart:], "@"); i >= 0 {
+		return image[:nameStart+i], image[nameStart+i:]
+	}
+	if i := strings.Index(image[nameStart:], ":"); i >= 0 {
+		return image[:nameStart+i], image[nameStart+i:]
+	}
+	return image, ""
+}
+
+// Replace the tag (or digest) of the image, for example
+// quay.io/apicurio/apicurio-registry-sql:2.6.x-snapshot -> quay.io/apicurio/apicurio-registry-sql:2.5.11.Final
+func replaceImageTag(image string, tag string) string {
+	repository, _ := splitImage(image)
+	return repository + ":" + tag
+}
+
+// Returns true if both images are in the same repository, and their tags have the same major and minor version
+func isPatchUpgrade(fromImage string, toImage string) bool {
+	fromRepository, fromTag := splitImage(fromImage)
+	toRepository, toTag := splitImage(toImage)
+	if fromRepository != toRepository {
+		return false
+	}
+	fromVersion := imageVersionRegex.FindStringSubmatch(strings.TrimPrefix(fromTag, ":"))
+	toVersion := imageVersionRegex.FindStringSubmatch(strings.TrimPrefix(toTag, ":"))
+	return fromVersion != nil && toVersion != nil &&
+		fromVersion[1] == toVersion[1] && fromVersion[2] == toVersion[2]
+}
+
+// Returns true if both images are in the same repository, so changing one to the other is an upgrade (or downgrade).
+// Otherwise, for example when the persistence changes, the image is replaced regardless of the upgrade policy.
+func isSameImageRepository(image1 string, image2 string) bool {
+	repository1, _ := splitImage(image1)
+	repository2, _ := splitImage(image2)
+	return repository1 == repository2
+}
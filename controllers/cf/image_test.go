@@ -0,0 +1,29 @@
+package cf
+
+import (
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"testing"
+)
+
+func TestImageUpgrade(t *testing.T) {
+	c.AssertEquals(t, "quay.io/apicurio/apicurio-registry-sql:2.5.11.Final",
+		replaceImageTag("quay.io/apicurio/apicurio-registry-sql:2.6.x-snapshot", "2.5.11.Final"))
+	c.AssertEquals(t, "localhost:5000/apicurio-registry-sql:2.5.11.Final",
+		replaceImageTag("localhost:5000/apicurio-registry-sql", "2.5.11.Final"))
+	c.AssertEquals(t, "quay.io/apicurio/apicurio-registry-sql:2.5.11.Final",
+		replaceImageTag("quay.io/apicurio/apicurio-registry-sql@sha256:0123456789abcdef", "2.5.11.Final"))
+
+	c.AssertEquals(t, true, isPatchUpgrade(
+		"quay.io/apicurio/apicurio-registry-sql:2.6.1.Final", "quay.io/apicurio/apicurio-registry-sql:2.6.2.Final"))
+	c.AssertEquals(t, false, isPatchUpgrade(
+		"quay.io/apicurio/apicurio-registry-sql:2.5.11.Final", "quay.io/apicurio/apicurio-registry-sql:2.6.2.Final"))
+	c.AssertEquals(t, false, isPatchUpgrade(
+		"quay.io/apicurio/apicurio-registry-sql:latest", "quay.io/apicurio/apicurio-registry-sql:2.6.2.Final"))
+	c.AssertEquals(t, false, isPatchUpgrade(
+		"quay.io/apicurio/apicurio-registry-mem:2.6.1.Final", "quay.io/apicurio/apicurio-registry-sql:2.6.2.Final"))
+
+	c.AssertEquals(t, true, isSameImageRepository(
+		"localhost:5000/apicurio-registry-sql:2.6.1.Final", "localhost:5000/apicurio-registry-sql"))
+	c.AssertEquals(t, false, isSameImageRepository(
+		"quay.io/apicurio/apicurio-registry-mem:2.6.1.Final", "quay.io/apicurio/apicurio-registry-sql:2.6.1.Final"))
+}
@@ -1,11 +1,13 @@
 package kafkasql
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"strings"
 )
 
 var _ loop.ControlFunction = &KafkasqlCF{}
@@ -19,19 +21,23 @@ type KafkasqlCF struct {
 	ctx                 context.LoopContext
 	svcResourceCache    resources.ResourceCache
 	svcEnvCache         env.EnvCache
-	persistence         string
+	services            services.LoopServices
+	persistence         ar.ApicurioRegistryPersistence
 	bootstrapServers    string
+	securityModes       []string
 	valid               bool
 	envBootstrapServers string
 }
 
-func NewKafkasqlCF(ctx context.LoopContext) loop.ControlFunction {
+func NewKafkasqlCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
 	return &KafkasqlCF{
 		ctx:                 ctx,
 		svcResourceCache:    ctx.GetResourceCache(),
 		svcEnvCache:         ctx.GetEnvCache(),
+		services:            services,
 		persistence:         "",
 		bootstrapServers:    "",
+		securityModes:       []string{},
 		valid:               true,
 		envBootstrapServers: "",
 	}
@@ -48,6 +54,16 @@ func (this *KafkasqlCF) Sense() {
 		spec := specEntry.GetValue().(*ar.ApicurioRegistry)
 		this.persistence = spec.Spec.Configuration.Persistence
 		this.bootstrapServers = spec.Spec.Configuration.Kafkasql.BootstrapServers
+		this.securityModes = GetSecurityModes(spec.Spec.Configuration.Kafkasql.Security)
+	}
+	if connection, exists := GetStrimziConnection(this.svcResourceCache); exists {
+		this.bootstrapServers = connection.BootstrapServers
+	}
+
+	// Only one security mode can be used at the same time
+	if this.persistence == PERSISTENCE_ID && len(this.securityModes) > 1 {
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(strings.Join(this.securityModes, ", "), "spec.configuration.kafkasql.security")
 	}
 
 	// Observation #2 + #3
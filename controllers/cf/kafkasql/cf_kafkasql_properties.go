@@ -0,0 +1,154 @@
+package kafkasql
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	"sort"
+	"strconv"
+)
+
+var _ loop.ControlFunction = &KafkasqlPropertiesCF{}
+
+const ENV_REGISTRY_KAFKASQL_TOPIC = "REGISTRY_KAFKASQL_TOPIC"
+const ENV_REGISTRY_KAFKASQL_SNAPSHOTS_TOPIC = "REGISTRY_KAFKASQL_SNAPSHOTS_TOPIC"
+const ENV_REGISTRY_KAFKASQL_TOPIC_AUTO_CREATE = "REGISTRY_KAFKASQL_TOPIC_AUTO_CREATE"
+
+// This control function is responsible for the kafkasql topic configuration
+// and additional Kafka client properties.
+type KafkasqlPropertiesCF struct {
+	ctx              context.LoopContext
+	log              *zap.SugaredLogger
+	svcResourceCache resources.ResourceCache
+	svcEnvCache      env.EnvCache
+	services         services.LoopServices
+	persistence      ar.ApicurioRegistryPersistence
+	targetEnv        map[string]string
+	// To know which were deleted, we need to remember the previously set ones
+	previousTargetEnv map[string]string
+	invalidKeys       []string
+	update            bool
+	remove            []string
+}
+
+func NewKafkasqlPropertiesCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &KafkasqlPropertiesCF{
+		ctx:               ctx,
+		svcResourceCache:  ctx.GetResourceCache(),
+		svcEnvCache:       ctx.GetEnvCache(),
+		services:          services,
+		persistence:       "",
+		targetEnv:         make(map[string]string),
+		previousTargetEnv: make(map[string]string),
+		invalidKeys:       make([]string, 0),
+		update:            false,
+		remove:            make([]string, 0),
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *KafkasqlPropertiesCF) Describe() string {
+	return "KafkasqlPropertiesCF"
+}
+
+func (this *KafkasqlPropertiesCF) Sense() {
+	this.targetEnv = make(map[string]string)
+	this.invalidKeys = make([]string, 0)
+
+	// Observation #1
+	// Read the config values
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry)
+		this.persistence = spec.Spec.Configuration.Persistence
+
+		if this.persistence == PERSISTENCE_ID {
+			topic := spec.Spec.Configuration.Kafkasql.Topic
+			if topic.Name != "" {
+				this.targetEnv[ENV_REGISTRY_KAFKASQL_TOPIC] = topic.Name
+			} else if connection, exists := GetStrimziConnection(this.svcResourceCache); exists {
+				// The default topic created for Strimzi is specific to this ApicurioRegistry
+				this.targetEnv[ENV_REGISTRY_KAFKASQL_TOPIC] = connection.TopicName
+			}
+			if topic.SnapshotsName != "" {
+				this.targetEnv[ENV_REGISTRY_KAFKASQL_SNAPSHOTS_TOPIC] = topic.SnapshotsName
+			}
+			if topic.AutoCreate != nil {
+				this.targetEnv[ENV_REGISTRY_KAFKASQL_TOPIC_AUTO_CREATE] = strconv.FormatBool(*topic.AutoCreate)
+			}
+
+			for key, value := range spec.Spec.Configuration.Kafkasql.Properties {
+				if name, allowed := GetKafkaPropertyEnvName(key); allowed {
+					this.targetEnv[name] = value
+				} else {
+					this.invalidKeys = append(this.invalidKeys, key)
+				}
+			}
+		}
+	}
+
+	// Observation #2
+	// Report unsupported properties, they are ignored
+	sort.Strings(this.invalidKeys)
+	for _, key := range this.invalidKeys {
+		this.log.Warnw("unsupported Kafka client property", "key", key)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(key, "spec.configuration.kafkasql.properties")
+	}
+
+	// Observation #3
+	// Compare with the env values
+	this.update = false
+	for name, value := range this.targetEnv {
+		if entry, exists := this.svcEnvCache.Get(name); !exists || entry.GetValue().Value != value {
+			this.update = true
+		}
+	}
+	this.remove = make([]string, 0)
+	for name := range this.previousTargetEnv {
+		if _, target := this.targetEnv[name]; !target {
+			if _, exists := this.svcEnvCache.Get(name); exists {
+				this.remove = append(this.remove, name)
+			}
+		}
+	}
+}
+
+func (this *KafkasqlPropertiesCF) Compare() bool {
+	// Condition #1
+	// The env. variables differ or some should be removed
+	return this.update || len(this.remove) > 0
+}
+
+func (this *KafkasqlPropertiesCF) Respond() {
+	// Response #1
+	// Remove first
+	for _, name := range this.remove {
+		this.svcEnvCache.DeleteByName(name)
+	}
+
+	// Response #2
+	// Set the values
+	if len(this.targetEnv) > 0 {
+		this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_PROPERTIES_PREFIX, "REGISTRY_").Build())
+	}
+	names := make([]string, 0, len(this.targetEnv))
+	for name := range this.targetEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(name, this.targetEnv[name]).Build())
+	}
+
+	this.previousTargetEnv = this.targetEnv
+}
+
+func (this *KafkasqlPropertiesCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
@@ -0,0 +1,193 @@
+package kafkasql
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+)
+
+var _ loop.ControlFunction = &KafkasqlSecurityOAuthCF{}
+
+const ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_ID = "REGISTRY_KAFKASQL_OAUTH_CLIENT_ID"
+const ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET = "REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET"
+
+const ENV_REGISTRY_KAFKA_COMMON_SASL_OAUTHBEARER_TOKEN_ENDPOINT_URL = "REGISTRY_KAFKA_COMMON_SASL_OAUTHBEARER_TOKEN_ENDPOINT_URL"
+const ENV_REGISTRY_KAFKA_COMMON_SASL_LOGIN_CALLBACK_HANDLER_CLASS = "REGISTRY_KAFKA_COMMON_SASL_LOGIN_CALLBACK_HANDLER_CLASS"
+
+const OAUTH_SASL_MECHANISM = "OAUTHBEARER"
+const OAUTH_LOGIN_CALLBACK_HANDLER_CLASS = "org.apache.kafka.common.security.oauthbearer.secured.OAuthBearerLoginCallbackHandler"
+
+const OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME = "registry-kafkasql-oauth-truststore"
+
+type KafkasqlSecurityOAuthCF struct {
+	ctx                       context.LoopContext
+	svcResourceCache          resources.ResourceCache
+	svcEnvCache               env.EnvCache
+	persistence               ar.ApicurioRegistryPersistence
+	bootstrapServers          string
+	securityConflict          bool
+	strimziConnected          bool
+	tokenEndpointUrl          string
+	clientId                  string
+	clientSecretSecretName    string
+	scope                     string
+	truststoreSecretName      string
+	securityProtocol          string
+	valid                     bool
+	deploymentEntry           resources.ResourceCacheEntry
+	foundTokenEndpointUrl     string
+	foundClientId             string
+	foundClientSecretName     string
+	foundJaasConfig           string
+	foundMechanism            string
+	foundSecurityProtocol     string
+	foundTruststoreSecretName string
+}
+
+func NewKafkasqlSecurityOAuthCF(ctx context.LoopContext) loop.ControlFunction {
+	return &KafkasqlSecurityOAuthCF{
+		ctx:                       ctx,
+		svcResourceCache:          ctx.GetResourceCache(),
+		svcEnvCache:               ctx.GetEnvCache(),
+		persistence:               "",
+		bootstrapServers:          "",
+		securityConflict:          false,
+		strimziConnected:          false,
+		tokenEndpointUrl:          "",
+		clientId:                  "",
+		clientSecretSecretName:    "",
+		scope:                     "",
+		truststoreSecretName:      "",
+		securityProtocol:          "",
+		valid:                     false,
+		foundTokenEndpointUrl:     "",
+		foundClientId:             "",
+		foundClientSecretName:     "",
+		foundJaasConfig:           "",
+		foundMechanism:            "",
+		foundSecurityProtocol:     "",
+		foundTruststoreSecretName: "",
+	}
+}
+
+func (this *KafkasqlSecurityOAuthCF) Describe() string {
+	return "KafkasqlSecurityOAuthCF"
+}
+
+func (this *KafkasqlSecurityOAuthCF) Sense() {
+	// Observation #1
+	// Read the config values
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry)
+		this.persistence = spec.Spec.Configuration.Persistence
+		this.bootstrapServers = spec.Spec.Configuration.Kafkasql.BootstrapServers
+		this.securityConflict = len(GetSecurityModes(spec.Spec.Configuration.Kafkasql.Security)) > 1
+
+		oauth := spec.Spec.Configuration.Kafkasql.Security.OAuth
+		this.tokenEndpointUrl = oauth.TokenEndpointUrl
+		this.clientId = oauth.ClientId
+		this.clientSecretSecretName = oauth.ClientSecretSecretName
+		this.scope = oauth.Scope
+		this.truststoreSecretName = oauth.TruststoreSecretName
+		this.securityProtocol = string(oauth.SecurityProtocol)
+	}
+	// Strimzi integration configures the security options itself
+	_, this.strimziConnected = GetStrimziConnection(this.svcResourceCache)
+	if this.securityProtocol == "" {
+		this.securityProtocol = string(ar.KafkaSecurityProtocolSaslSsl)
+	}
+
+	// Observation #2
+	// Read the env values and the deployment
+	this.foundTokenEndpointUrl = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKA_COMMON_SASL_OAUTHBEARER_TOKEN_ENDPOINT_URL); exists {
+		this.foundTokenEndpointUrl = entry.GetValue().Value
+	}
+	this.foundClientId = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_ID); exists {
+		this.foundClientId = entry.GetValue().Value
+	}
+	this.foundClientSecretName = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET); exists &&
+		entry.GetValue().ValueFrom != nil && entry.GetValue().ValueFrom.SecretKeyRef != nil {
+		this.foundClientSecretName = entry.GetValue().ValueFrom.SecretKeyRef.Name
+	}
+	this.foundJaasConfig = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG); exists {
+		this.foundJaasConfig = entry.GetValue().Value
+	}
+	this.foundMechanism = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKA_COMMON_SASL_MECHANISM); exists {
+		this.foundMechanism = entry.GetValue().Value
+	}
+	this.foundSecurityProtocol = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKA_COMMON_SECURITY_PROTOCOL); exists {
+		this.foundSecurityProtocol = entry.GetValue().Value
+	}
+
+	this.foundTruststoreSecretName = ""
+	deploymentEntry, deploymentExists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT)
+	if deploymentExists {
+		this.foundTruststoreSecretName = getSecretVolumeSecretName(deploymentEntry, OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME)
+	}
+	this.deploymentEntry = deploymentEntry
+
+	// Observation #3
+	// Validate the config values
+	this.valid = this.persistence == PERSISTENCE_ID && this.bootstrapServers != "" && !this.securityConflict &&
+		!this.strimziConnected && deploymentExists &&
+		this.tokenEndpointUrl != "" &&
+		this.clientId != "" &&
+		this.clientSecretSecretName != ""
+
+	// We won't actively delete old env values if not used
+}
+
+func (this *KafkasqlSecurityOAuthCF) Compare() bool {
+	// Condition #1
+	// Config values are valid
+	// Condition #2
+	// The env. variables or the truststore volume differ (the truststore is optional)
+	return this.valid && (this.tokenEndpointUrl != this.foundTokenEndpointUrl ||
+		this.clientId != this.foundClientId ||
+		this.clientSecretSecretName != this.foundClientSecretName ||
+		this.getJaasConfig() != this.foundJaasConfig ||
+		OAUTH_SASL_MECHANISM != this.foundMechanism ||
+		this.securityProtocol != this.foundSecurityProtocol ||
+		(this.truststoreSecretName != "" && this.truststoreSecretName != this.foundTruststoreSecretName))
+}
+
+func (this *KafkasqlSecurityOAuthCF) Respond() {
+	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_ID, this.clientId).Build())
+	addSecretKeyEnv(this.svcEnvCache, ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET, this.clientSecretSecretName, "client-secret")
+
+	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SASL_OAUTHBEARER_TOKEN_ENDPOINT_URL, this.tokenEndpointUrl).Build())
+	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SASL_LOGIN_CALLBACK_HANDLER_CLASS, OAUTH_LOGIN_CALLBACK_HANDLER_CLASS).Build())
+
+	addSaslEnv(this.svcEnvCache, OAUTH_SASL_MECHANISM, this.securityProtocol, this.getJaasConfig(),
+		ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_ID, ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET)
+
+	if this.truststoreSecretName != "" {
+		addTruststoreEnv(this.svcEnvCache, this.truststoreSecretName, OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME)
+		addSecretVolumePatch(this.deploymentEntry, this.truststoreSecretName, OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME)
+		addSecretMountPatch(this.deploymentEntry, OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME, "etc/"+OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME)
+	}
+}
+
+func (this *KafkasqlSecurityOAuthCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
+
+func (this *KafkasqlSecurityOAuthCF) getJaasConfig() string {
+	options := [][2]string{
+		{"clientId", "$(" + ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_ID + ")"},
+		{"clientSecret", "$(" + ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET + ")"},
+	}
+	if this.scope != "" {
+		options = append(options, [2]string{"scope", this.scope})
+	}
+	return buildJaasConfig("org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule", options)
+}
@@ -0,0 +1,168 @@
+package kafkasql
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+)
+
+var _ loop.ControlFunction = &KafkasqlSecurityPlainCF{}
+
+const ENV_REGISTRY_KAFKASQL_PLAIN_USER = "REGISTRY_KAFKASQL_PLAIN_USER"
+const ENV_REGISTRY_KAFKASQL_PLAIN_PASSWORD = "REGISTRY_KAFKASQL_PLAIN_PASSWORD"
+
+const PLAIN_SASL_MECHANISM = "PLAIN"
+
+const PLAIN_TRUSTSTORE_SECRET_VOLUME_NAME = "registry-kafkasql-plain-truststore"
+
+type KafkasqlSecurityPlainCF struct {
+	ctx                       context.LoopContext
+	svcResourceCache          resources.ResourceCache
+	svcEnvCache               env.EnvCache
+	persistence               ar.ApicurioRegistryPersistence
+	bootstrapServers          string
+	securityConflict          bool
+	strimziConnected          bool
+	user                      string
+	passwordSecretName        string
+	truststoreSecretName      string
+	securityProtocol          string
+	valid                     bool
+	deploymentEntry           resources.ResourceCacheEntry
+	foundUser                 string
+	foundPasswordSecretName   string
+	foundJaasConfig           string
+	foundMechanism            string
+	foundSecurityProtocol     string
+	foundTruststoreSecretName string
+}
+
+func NewKafkasqlSecurityPlainCF(ctx context.LoopContext) loop.ControlFunction {
+	return &KafkasqlSecurityPlainCF{
+		ctx:                       ctx,
+		svcResourceCache:          ctx.GetResourceCache(),
+		svcEnvCache:               ctx.GetEnvCache(),
+		persistence:               "",
+		bootstrapServers:          "",
+		securityConflict:          false,
+		strimziConnected:          false,
+		user:                      "",
+		passwordSecretName:        "",
+		truststoreSecretName:      "",
+		securityProtocol:          "",
+		valid:                     false,
+		foundUser:                 "",
+		foundPasswordSecretName:   "",
+		foundJaasConfig:           "",
+		foundMechanism:            "",
+		foundSecurityProtocol:     "",
+		foundTruststoreSecretName: "",
+	}
+}
+
+func (this *KafkasqlSecurityPlainCF) Describe() string {
+	return "KafkasqlSecurityPlainCF"
+}
+
+func (this *KafkasqlSecurityPlainCF) Sense() {
+	// Observation #1
+	// Read the config values
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry)
+		this.persistence = spec.Spec.Configuration.Persistence
+		this.bootstrapServers = spec.Spec.Configuration.Kafkasql.BootstrapServers
+		this.securityConflict = len(GetSecurityModes(spec.Spec.Configuration.Kafkasql.Security)) > 1
+
+		plain := spec.Spec.Configuration.Kafkasql.Security.Plain
+		this.user = plain.User
+		this.passwordSecretName = plain.PasswordSecretName
+		this.truststoreSecretName = plain.TruststoreSecretName
+		this.securityProtocol = string(plain.SecurityProtocol)
+	}
+	// Strimzi integration configures the security options itself
+	_, this.strimziConnected = GetStrimziConnection(this.svcResourceCache)
+	if this.securityProtocol == "" {
+		this.securityProtocol = string(ar.KafkaSecurityProtocolSaslSsl)
+	}
+
+	// Observation #2
+	// Read the env values and the deployment
+	this.foundUser = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKASQL_PLAIN_USER); exists {
+		this.foundUser = entry.GetValue().Value
+	}
+	this.foundPasswordSecretName = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKASQL_PLAIN_PASSWORD); exists &&
+		entry.GetValue().ValueFrom != nil && entry.GetValue().ValueFrom.SecretKeyRef != nil {
+		this.foundPasswordSecretName = entry.GetValue().ValueFrom.SecretKeyRef.Name
+	}
+	this.foundJaasConfig = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG); exists {
+		this.foundJaasConfig = entry.GetValue().Value
+	}
+	this.foundMechanism = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKA_COMMON_SASL_MECHANISM); exists {
+		this.foundMechanism = entry.GetValue().Value
+	}
+	this.foundSecurityProtocol = ""
+	if entry, exists := this.svcEnvCache.Get(ENV_REGISTRY_KAFKA_COMMON_SECURITY_PROTOCOL); exists {
+		this.foundSecurityProtocol = entry.GetValue().Value
+	}
+
+	this.foundTruststoreSecretName = ""
+	deploymentEntry, deploymentExists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT)
+	if deploymentExists {
+		this.foundTruststoreSecretName = getSecretVolumeSecretName(deploymentEntry, PLAIN_TRUSTSTORE_SECRET_VOLUME_NAME)
+	}
+	this.deploymentEntry = deploymentEntry
+
+	// Observation #3
+	// Validate the config values
+	this.valid = this.persistence == PERSISTENCE_ID && this.bootstrapServers != "" && !this.securityConflict &&
+		!this.strimziConnected && deploymentExists &&
+		this.user != "" &&
+		this.passwordSecretName != ""
+
+	// We won't actively delete old env values if not used
+}
+
+func (this *KafkasqlSecurityPlainCF) Compare() bool {
+	// Condition #1
+	// Config values are valid
+	// Condition #2
+	// The env. variables or the truststore volume differ (the truststore is optional)
+	return this.valid && (this.user != this.foundUser ||
+		this.passwordSecretName != this.foundPasswordSecretName ||
+		this.getJaasConfig() != this.foundJaasConfig ||
+		PLAIN_SASL_MECHANISM != this.foundMechanism ||
+		this.securityProtocol != this.foundSecurityProtocol ||
+		(this.truststoreSecretName != "" && this.truststoreSecretName != this.foundTruststoreSecretName))
+}
+
+func (this *KafkasqlSecurityPlainCF) Respond() {
+	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKASQL_PLAIN_USER, this.user).Build())
+	addSecretKeyEnv(this.svcEnvCache, ENV_REGISTRY_KAFKASQL_PLAIN_PASSWORD, this.passwordSecretName, "password")
+
+	addSaslEnv(this.svcEnvCache, PLAIN_SASL_MECHANISM, this.securityProtocol, this.getJaasConfig(),
+		ENV_REGISTRY_KAFKASQL_PLAIN_USER, ENV_REGISTRY_KAFKASQL_PLAIN_PASSWORD)
+
+	if this.truststoreSecretName != "" {
+		addTruststoreEnv(this.svcEnvCache, this.truststoreSecretName, PLAIN_TRUSTSTORE_SECRET_VOLUME_NAME)
+		addSecretVolumePatch(this.deploymentEntry, this.truststoreSecretName, PLAIN_TRUSTSTORE_SECRET_VOLUME_NAME)
+		addSecretMountPatch(this.deploymentEntry, PLAIN_TRUSTSTORE_SECRET_VOLUME_NAME, "etc/"+PLAIN_TRUSTSTORE_SECRET_VOLUME_NAME)
+	}
+}
+
+func (this *KafkasqlSecurityPlainCF) Cleanup() bool {
+	// No cleanup
+	return true
+}
+
+func (this *KafkasqlSecurityPlainCF) getJaasConfig() string {
+	return buildJaasConfig("org.apache.kafka.common.security.plain.PlainLoginModule", [][2]string{
+		{"username", "$(" + ENV_REGISTRY_KAFKASQL_PLAIN_USER + ")"},
+		{"password", "$(" + ENV_REGISTRY_KAFKASQL_PLAIN_PASSWORD + ")"},
+	})
+}
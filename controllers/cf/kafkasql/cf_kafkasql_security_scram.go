@@ -1,14 +1,12 @@
 package kafkasql
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
-	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 	apps "k8s.io/api/apps/v1"
-	core "k8s.io/api/core/v1"
 )
 
 var _ loop.ControlFunction = &KafkasqlSecurityScramCF{}
@@ -27,7 +25,7 @@ type KafkasqlSecurityScramCF struct {
 	ctx                          context.LoopContext
 	svcResourceCache             resources.ResourceCache
 	svcEnvCache                  env.EnvCache
-	persistence                  string
+	persistence                  ar.ApicurioRegistryPersistence
 	bootstrapServers             string
 	truststoreSecretName         string
 	valid                        bool
@@ -40,6 +38,7 @@ type KafkasqlSecurityScramCF struct {
 	foundScramUser               string
 	foundScramPasswordSecretName string
 	foundScramMechanism          string
+	securityConflict             bool
 }
 
 func NewKafkasqlSecurityScramCF(ctx context.LoopContext) loop.ControlFunction {
@@ -58,6 +57,7 @@ func NewKafkasqlSecurityScramCF(ctx context.LoopContext) loop.ControlFunction {
 		foundScramUser:               "",
 		foundScramPasswordSecretName: "",
 		foundScramMechanism:          "",
+		securityConflict:             false,
 	}
 }
 
@@ -76,11 +76,26 @@ func (this *KafkasqlSecurityScramCF) Sense() {
 		this.truststoreSecretName = spec.Spec.Configuration.Kafkasql.Security.Scram.TruststoreSecretName
 		this.scramUser = spec.Spec.Configuration.Kafkasql.Security.Scram.User
 		this.scramPasswordSecretName = spec.Spec.Configuration.Kafkasql.Security.Scram.PasswordSecretName
-		this.scramMechanism = spec.Spec.Configuration.Kafkasql.Security.Scram.Mechanism
+		this.scramMechanism = string(spec.Spec.Configuration.Kafkasql.Security.Scram.Mechanism)
+		this.securityConflict = len(GetSecurityModes(spec.Spec.Configuration.Kafkasql.Security)) > 1
+	}
+	// Strimzi connection takes precedence over the spec
+	if connection, exists := GetStrimziConnection(this.svcResourceCache); exists {
+		this.bootstrapServers = connection.BootstrapServers
+		this.truststoreSecretName = ""
+		this.scramUser = ""
+		this.scramPasswordSecretName = ""
+		this.scramMechanism = ""
+		if connection.Authentication == ar.StrimziAuthenticationScramSha512 {
+			this.truststoreSecretName = connection.ClusterCaSecretName
+			this.scramUser = connection.UserName
+			this.scramPasswordSecretName = connection.UserSecretName
+			this.scramMechanism = string(ar.ScramMechanismSha512)
+		}
 	}
 
 	if this.scramMechanism == "" {
-		this.scramMechanism = "SCRAM-SHA-512"
+		this.scramMechanism = string(ar.ScramMechanismSha512)
 	}
 
 	// Observation #2
@@ -113,7 +128,7 @@ func (this *KafkasqlSecurityScramCF) Sense() {
 
 	// Observation #3
 	// Validate the config values
-	this.valid = this.persistence == PERSISTENCE_ID && this.bootstrapServers != "" &&
+	this.valid = this.persistence == PERSISTENCE_ID && this.bootstrapServers != "" && !this.securityConflict &&
 		this.truststoreSecretName != "" &&
 		this.scramUser != "" &&
 		this.scramPasswordSecretName != ""
@@ -142,97 +157,25 @@ func (this *KafkasqlSecurityScramCF) Respond() {
 func (this *KafkasqlSecurityScramCF) AddEnv(truststoreSecretName string, truststoreSecretVolumeName string,
 	scramUser string, scramPasswordSecretName string, scramMechanism string) {
 
-	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_PROPERTIES_PREFIX, "REGISTRY_").Build())
-
 	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKASQL_SCRAM_USER, scramUser).Build())
-	this.svcEnvCache.Set(env.NewEnvCacheEntryBuilder(&core.EnvVar{
-		Name: ENV_REGISTRY_KAFKASQL_SCRAM_PASSWORD,
-		ValueFrom: &core.EnvVarSource{
-			SecretKeyRef: &core.SecretKeySelector{
-				LocalObjectReference: core.LocalObjectReference{
-					Name: scramPasswordSecretName,
-				},
-				Key: "password",
-			},
-		},
-	}).Build())
-
-	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SASL_MECHANISM, scramMechanism).Build())
-
-	jaasConfig := "org.apache.kafka.common.security.scram.ScramLoginModule required username='$(" + ENV_REGISTRY_KAFKASQL_SCRAM_USER +
-		")' password='$(" + ENV_REGISTRY_KAFKASQL_SCRAM_PASSWORD + ")';"
-
-	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG, jaasConfig).
-		SetDependency(ENV_REGISTRY_KAFKASQL_SCRAM_USER).
-		SetDependency(ENV_REGISTRY_KAFKASQL_SCRAM_PASSWORD).Build())
-
-	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SECURITY_PROTOCOL, "SASL_SSL").Build())
-	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_TYPE, "PKCS12").Build())
-	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_LOCATION,
-		"/etc/"+truststoreSecretVolumeName+"/ca.p12").Build())
-	this.svcEnvCache.Set(env.NewEnvCacheEntryBuilder(&core.EnvVar{
-		Name: ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_PASSWORD,
-		ValueFrom: &core.EnvVarSource{
-			SecretKeyRef: &core.SecretKeySelector{
-				LocalObjectReference: core.LocalObjectReference{
-					Name: truststoreSecretName,
-				},
-				Key: "ca.password",
-			},
-		},
-	}).Build())
+	addSecretKeyEnv(this.svcEnvCache, ENV_REGISTRY_KAFKASQL_SCRAM_PASSWORD, scramPasswordSecretName, "password")
 
+	jaasConfig := buildJaasConfig("org.apache.kafka.common.security.scram.ScramLoginModule", [][2]string{
+		{"username", "$(" + ENV_REGISTRY_KAFKASQL_SCRAM_USER + ")"},
+		{"password", "$(" + ENV_REGISTRY_KAFKASQL_SCRAM_PASSWORD + ")"},
+	})
+	addSaslEnv(this.svcEnvCache, scramMechanism, string(ar.KafkaSecurityProtocolSaslSsl), jaasConfig,
+		ENV_REGISTRY_KAFKASQL_SCRAM_USER, ENV_REGISTRY_KAFKASQL_SCRAM_PASSWORD)
+
+	addTruststoreEnv(this.svcEnvCache, truststoreSecretName, truststoreSecretVolumeName)
 }
 
 func (this *KafkasqlSecurityScramCF) AddSecretVolumePatch(deploymentEntry resources.ResourceCacheEntry, secretName string, volumeName string) {
-	deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
-		deployment := value.(*apps.Deployment).DeepCopy()
-		volume := core.Volume{
-			Name: volumeName,
-			VolumeSource: core.VolumeSource{
-				Secret: &core.SecretVolumeSource{
-					SecretName: secretName,
-				},
-			},
-		}
-		j := -1
-		for i, v := range deployment.Spec.Template.Spec.Volumes {
-			if v.Name == volumeName {
-				j = i
-				deployment.Spec.Template.Spec.Volumes[i] = volume
-			}
-		}
-		if j == -1 {
-			deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
-		}
-		return deployment
-	})
+	addSecretVolumePatch(deploymentEntry, secretName, volumeName)
 }
 
 func (this *KafkasqlSecurityScramCF) AddSecretMountPatch(deploymentEntry resources.ResourceCacheEntry, volumeName string, mountPath string) {
-	deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
-		deployment := value.(*apps.Deployment).DeepCopy()
-		for ci, c := range deployment.Spec.Template.Spec.Containers {
-			if c.Name == factory.REGISTRY_CONTAINER_NAME {
-				mount := core.VolumeMount{
-					Name:      volumeName,
-					ReadOnly:  true,
-					MountPath: mountPath,
-				}
-				j := -1
-				for i, v := range deployment.Spec.Template.Spec.Containers[ci].VolumeMounts {
-					if v.Name == volumeName {
-						j = i
-						deployment.Spec.Template.Spec.Containers[ci].VolumeMounts[i] = mount
-					}
-				}
-				if j == -1 {
-					deployment.Spec.Template.Spec.Containers[ci].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[ci].VolumeMounts, mount)
-				}
-			}
-		}
-		return deployment
-	})
+	addSecretMountPatch(deploymentEntry, volumeName, mountPath)
 }
 
 func (this *KafkasqlSecurityScramCF) Cleanup() bool {
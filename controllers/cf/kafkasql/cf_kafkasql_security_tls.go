@@ -1,11 +1,10 @@
 package kafkasql
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
-	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
@@ -38,7 +37,7 @@ type KafkasqlSecurityTLSCF struct {
 	ctx                       context.LoopContext
 	svcResourceCache          resources.ResourceCache
 	svcEnvCache               env.EnvCache
-	persistence               string
+	persistence               ar.ApicurioRegistryPersistence
 	bootstrapServers          string
 	keystoreSecretName        string
 	truststoreSecretName      string
@@ -47,6 +46,8 @@ type KafkasqlSecurityTLSCF struct {
 	foundTruststoreSecretName string
 	deploymentExists          bool
 	deploymentEntry           resources.ResourceCacheEntry
+	securityConflict          bool
+	pem                       bool
 }
 
 func NewKafkasqlSecurityTLSCF(ctx context.LoopContext) loop.ControlFunction {
@@ -61,6 +62,8 @@ func NewKafkasqlSecurityTLSCF(ctx context.LoopContext) loop.ControlFunction {
 		valid:                     true,
 		foundKeystoreSecretName:   "",
 		foundTruststoreSecretName: "",
+		securityConflict:          false,
+		pem:                       false,
 	}
 }
 
@@ -78,6 +81,20 @@ func (this *KafkasqlSecurityTLSCF) Sense() {
 
 		this.keystoreSecretName = spec.Spec.Configuration.Kafkasql.Security.Tls.KeystoreSecretName
 		this.truststoreSecretName = spec.Spec.Configuration.Kafkasql.Security.Tls.TruststoreSecretName
+		this.securityConflict = len(GetSecurityModes(spec.Spec.Configuration.Kafkasql.Security)) > 1
+		// PEM stores are handled by KafkasqlSecurityTLSPemCF
+		this.pem = spec.Spec.Configuration.Kafkasql.Security.Tls.Format == ar.KafkaTlsFormatPem
+	}
+	// Strimzi connection takes precedence over the spec
+	if connection, exists := GetStrimziConnection(this.svcResourceCache); exists {
+		this.bootstrapServers = connection.BootstrapServers
+		this.keystoreSecretName = ""
+		this.truststoreSecretName = ""
+		this.pem = false
+		if connection.Authentication == ar.StrimziAuthenticationTls {
+			this.keystoreSecretName = connection.UserSecretName
+			this.truststoreSecretName = connection.ClusterCaSecretName
+		}
 	}
 
 	// Observation #2
@@ -102,7 +119,7 @@ func (this *KafkasqlSecurityTLSCF) Sense() {
 
 	// Observation #3
 	// Validate the config values
-	this.valid = this.persistence == PERSISTENCE_ID && this.bootstrapServers != "" &&
+	this.valid = this.persistence == PERSISTENCE_ID && this.bootstrapServers != "" && !this.securityConflict && !this.pem &&
 		this.keystoreSecretName != "" && this.truststoreSecretName != ""
 
 	// We won't actively delete old env values if not used
@@ -163,54 +180,11 @@ func (this *KafkasqlSecurityTLSCF) AddEnv(keystoreSecretName string, keystoreSec
 }
 
 func (this *KafkasqlSecurityTLSCF) AddSecretVolumePatch(deploymentEntry resources.ResourceCacheEntry, secretName string, volumeName string) {
-	deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
-		deployment := value.(*apps.Deployment).DeepCopy()
-		volume := core.Volume{
-			Name: volumeName,
-			VolumeSource: core.VolumeSource{
-				Secret: &core.SecretVolumeSource{
-					SecretName: secretName,
-				},
-			},
-		}
-		j := -1
-		for i, v := range deployment.Spec.Template.Spec.Volumes {
-			if v.Name == volumeName {
-				j = i
-				deployment.Spec.Template.Spec.Volumes[i] = volume
-			}
-		}
-		if j == -1 {
-			deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
-		}
-		return deployment
-	})
+	addSecretVolumePatch(deploymentEntry, secretName, volumeName)
 }
 
 func (this *KafkasqlSecurityTLSCF) AddSecretMountPatch(deploymentEntry resources.ResourceCacheEntry, volumeName string, mountPath string) {
-	deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
-		deployment := value.(*apps.Deployment).DeepCopy()
-		for ci, c := range deployment.Spec.Template.Spec.Containers {
-			if c.Name == factory.REGISTRY_CONTAINER_NAME {
-				mount := core.VolumeMount{
-					Name:      volumeName,
-					ReadOnly:  true,
-					MountPath: mountPath,
-				}
-				j := -1
-				for i, v := range deployment.Spec.Template.Spec.Containers[ci].VolumeMounts {
-					if v.Name == volumeName {
-						j = i
-						deployment.Spec.Template.Spec.Containers[ci].VolumeMounts[i] = mount
-					}
-				}
-				if j == -1 {
-					deployment.Spec.Template.Spec.Containers[ci].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[ci].VolumeMounts, mount)
-				}
-			}
-		}
-		return deployment
-	})
+	addSecretMountPatch(deploymentEntry, volumeName, mountPath)
 }
 
 func (this *KafkasqlSecurityTLSCF) Cleanup() bool {
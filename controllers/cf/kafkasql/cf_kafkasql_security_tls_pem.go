@@ -0,0 +1,288 @@
+package kafkasql
+
+import (
+	"errors"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+)
+
+var _ loop.ControlFunction = &KafkasqlSecurityTLSPemCF{}
+
+const TLS_PEM_SECRET_VOLUME_NAME = "registry-kafkasql-tls-pem"
+
+// Hash of the generated stores, so the pods are restarted when the stores are rotated
+const TLS_PEM_HASH_ANNOTATION = "apicur.io/kafkasql-tls-pem-hash"
+
+// The source Secrets are not watched, so they are checked for changes periodically
+const TLS_PEM_SOURCE_CHECK_DELAY_SEC = 60
+
+// This control function configures Kafka TLS using keystore and truststore Secrets in PEM format.
+// The Secrets are converted into an Operator-owned Secret with stores using the Kafka PEM store type.
+type KafkasqlSecurityTLSPemCF struct {
+	ctx                  context.LoopContext
+	log                  *zap.SugaredLogger
+	svcResourceCache     resources.ResourceCache
+	svcEnvCache          env.EnvCache
+	svcClients           *client.Clients
+	services             services.LoopServices
+	persistence          ar.ApicurioRegistryPersistence
+	bootstrapServers     string
+	keystoreSecretName   string
+	truststoreSecretName string
+	valid                bool
+	deploymentEntry      resources.ResourceCacheEntry
+	targetSecretName     string
+	targetData           map[string][]byte
+	targetHash           string
+	secret               *core.Secret
+	secretReady          bool
+	foundSecretName      string
+	foundHash            string
+	envReady             bool
+}
+
+func NewKafkasqlSecurityTLSPemCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &KafkasqlSecurityTLSPemCF{
+		ctx:                  ctx,
+		svcResourceCache:     ctx.GetResourceCache(),
+		svcEnvCache:          ctx.GetEnvCache(),
+		svcClients:           ctx.GetClients(),
+		services:             services,
+		persistence:          "",
+		bootstrapServers:     "",
+		keystoreSecretName:   "",
+		truststoreSecretName: "",
+		valid:                false,
+		targetSecretName:     ctx.GetAppName().Str() + "-kafkasql-tls-pem",
+		targetData:           nil,
+		targetHash:           "",
+		secret:               nil,
+		secretReady:          false,
+		foundSecretName:      "",
+		foundHash:            "",
+		envReady:             false,
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *KafkasqlSecurityTLSPemCF) Describe() string {
+	return "KafkasqlSecurityTLSPemCF"
+}
+
+func (this *KafkasqlSecurityTLSPemCF) Sense() {
+	// Observation #1
+	// Read the config values
+	format := ar.KafkaTlsFormatPkcs12
+	onlyMode := false
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry)
+		this.persistence = spec.Spec.Configuration.Persistence
+		this.bootstrapServers = spec.Spec.Configuration.Kafkasql.BootstrapServers
+		this.keystoreSecretName = spec.Spec.Configuration.Kafkasql.Security.Tls.KeystoreSecretName
+		this.truststoreSecretName = spec.Spec.Configuration.Kafkasql.Security.Tls.TruststoreSecretName
+		format = spec.Spec.Configuration.Kafkasql.Security.Tls.Format
+		onlyMode = isOnlySecurityMode(spec.Spec.Configuration.Kafkasql.Security, SECURITY_MODE_TLS)
+	}
+	// Strimzi provides the stores in PKCS12 format
+	_, strimziConnected := GetStrimziConnection(this.svcResourceCache)
+
+	// Observation #2
+	// Deployment exists
+	deploymentEntry, deploymentExists := this.svcResourceCache.Get(resources.RC_KEY_DEPLOYMENT)
+	this.deploymentEntry = deploymentEntry
+
+	// Observation #3
+	// Validate the config values
+	this.valid = this.persistence == PERSISTENCE_ID && this.bootstrapServers != "" && onlyMode &&
+		format == ar.KafkaTlsFormatPem && this.keystoreSecretName != "" && this.truststoreSecretName != "" &&
+		!strimziConnected && deploymentExists
+
+	this.targetData = nil
+	this.targetHash = ""
+	this.secret = nil
+	this.secretReady = false
+	if !this.valid {
+		// We won't actively delete old env values if not used
+		return
+	}
+	this.ctx.SetRequeueDelaySec(TLS_PEM_SOURCE_CHECK_DELAY_SEC)
+
+	// Observation #4
+	// Build the stores from the source Secrets
+	if data, ok := this.buildTargetData(); ok {
+		this.targetData = data
+		this.targetHash = hashSecretData(data)
+	} else {
+		this.ctx.SetRequeueDelaySec(10)
+		return
+	}
+
+	// Observation #5
+	// Get the Operator-owned Secret
+	secret, err := this.svcClients.Kube().
+		GetSecret(this.ctx.GetAppNamespace(), common.Name(this.targetSecretName), &meta.GetOptions{})
+	if err == nil {
+		this.secret = secret
+		this.secretReady = reflect.DeepEqual(secret.Data, this.targetData)
+	} else if !api_errors.IsNotFound(err) {
+		this.log.Errorw("could not get Secret", "name", this.targetSecretName, "error", err)
+	}
+
+	// Observation #6
+	// Check the Deployment and env. variables
+	deployment := deploymentEntry.GetValue().(*apps.Deployment)
+	this.foundSecretName = getSecretVolumeSecretName(deploymentEntry, TLS_PEM_SECRET_VOLUME_NAME)
+	this.foundHash = deployment.Spec.Template.Annotations[TLS_PEM_HASH_ANNOTATION]
+
+	this.envReady = true
+	for name, value := range this.getTargetEnv() {
+		if entry, exists := this.svcEnvCache.Get(name); !exists || entry.GetValue().Value != value {
+			this.envReady = false
+		}
+	}
+	for _, name := range []string{ENV_REGISTRY_KAFKA_COMMON_SSL_KEYSTORE_PASSWORD, ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_PASSWORD} {
+		if _, exists := this.svcEnvCache.Get(name); exists {
+			this.envReady = false
+		}
+	}
+}
+
+func (this *KafkasqlSecurityTLSPemCF) Compare() bool {
+	// Condition #1
+	// Configuration is valid and the stores have been built
+	// Condition #2
+	// The Secret is missing or outdated, act only once per loop so a failing request does not prevent stabilization
+	// Condition #3
+	// The Secret is up to date, but the Deployment or env. variables are not
+	return this.valid && this.targetData != nil &&
+		((!this.secretReady && this.ctx.GetAttempts() == 0) ||
+			(this.secretReady && (this.foundSecretName != this.targetSecretName || this.foundHash != this.targetHash || !this.envReady)))
+}
+
+func (this *KafkasqlSecurityTLSPemCF) Respond() {
+	// Response #1
+	// Create or update the Secret
+	if !this.secretReady {
+		if err := this.writeSecret(); err != nil {
+			this.log.Errorw("could not create or update Secret", "name", this.targetSecretName, "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+			return
+		}
+	}
+
+	// Response #2
+	// Set the env. variables
+	this.svcEnvCache.DeleteByName(ENV_REGISTRY_KAFKA_COMMON_SSL_KEYSTORE_PASSWORD)
+	this.svcEnvCache.DeleteByName(ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_PASSWORD)
+	this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_PROPERTIES_PREFIX, "REGISTRY_").Build())
+	for name, value := range this.getTargetEnv() {
+		this.svcEnvCache.Set(env.NewSimpleEnvCacheEntryBuilder(name, value).Build())
+	}
+
+	// Response #3
+	// Mount the Secret and set the hash annotation
+	addSecretVolumePatch(this.deploymentEntry, this.targetSecretName, TLS_PEM_SECRET_VOLUME_NAME)
+	addSecretMountPatch(this.deploymentEntry, TLS_PEM_SECRET_VOLUME_NAME, "/etc/"+TLS_PEM_SECRET_VOLUME_NAME)
+	this.deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+		deployment := value.(*apps.Deployment).DeepCopy()
+		common.LabelsUpdate(&deployment.Spec.Template.Annotations, map[string]string{
+			TLS_PEM_HASH_ANNOTATION: this.targetHash,
+		})
+		return deployment
+	})
+}
+
+func (this *KafkasqlSecurityTLSPemCF) Cleanup() bool {
+	// The Secret is owned by the ApicurioRegistry resource and deleted by the garbage collector
+	return true
+}
+
+func (this *KafkasqlSecurityTLSPemCF) getTargetEnv() map[string]string {
+	return map[string]string{
+		ENV_REGISTRY_KAFKA_COMMON_SECURITY_PROTOCOL:       "SSL",
+		ENV_REGISTRY_KAFKA_COMMON_SSL_KEYSTORE_TYPE:       "PEM",
+		ENV_REGISTRY_KAFKA_COMMON_SSL_KEYSTORE_LOCATION:   "/etc/" + TLS_PEM_SECRET_VOLUME_NAME + "/" + PEM_KEYSTORE_KEY,
+		ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_TYPE:     "PEM",
+		ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_LOCATION: "/etc/" + TLS_PEM_SECRET_VOLUME_NAME + "/" + PEM_TRUSTSTORE_KEY,
+	}
+}
+
+// Returns false if the source Secrets are missing or invalid, in which case the error is reported
+func (this *KafkasqlSecurityTLSPemCF) buildTargetData() (map[string][]byte, bool) {
+	keystoreSecret, err := this.svcClients.Kube().
+		GetSecret(this.ctx.GetAppNamespace(), common.Name(this.keystoreSecretName), &meta.GetOptions{})
+	if err != nil || !common.SecretHasField(keystoreSecret, PEM_CERTIFICATE_KEY) || !common.SecretHasField(keystoreSecret, PEM_PRIVATE_KEY_KEY) {
+		this.log.Errorw("Kafka TLS keystore Secret referenced in Apicurio Registry CR is missing, "+
+			"or does not have both "+PEM_CERTIFICATE_KEY+" and "+PEM_PRIVATE_KEY_KEY+" fields",
+			"secretName", this.keystoreSecretName, "error", err)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(this.keystoreSecretName, "spec.configuration.kafkasql.security.tls.keystoreSecretName")
+		return nil, false
+	}
+	truststoreSecret, err := this.svcClients.Kube().
+		GetSecret(this.ctx.GetAppNamespace(), common.Name(this.truststoreSecretName), &meta.GetOptions{})
+	if err != nil || !common.SecretHasField(truststoreSecret, PEM_CA_CERTIFICATE_KEY) {
+		this.log.Errorw("Kafka TLS truststore Secret referenced in Apicurio Registry CR is missing, "+
+			"or does not have the "+PEM_CA_CERTIFICATE_KEY+" field",
+			"secretName", this.truststoreSecretName, "error", err)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(this.truststoreSecretName, "spec.configuration.kafkasql.security.tls.truststoreSecretName")
+		return nil, false
+	}
+
+	keystore, err := buildPemKeystore(keystoreSecret.Data[PEM_CERTIFICATE_KEY], keystoreSecret.Data[PEM_PRIVATE_KEY_KEY])
+	if err != nil {
+		this.log.Errorw("could not build Kafka TLS keystore", "secretName", this.keystoreSecretName, "error", err)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(this.keystoreSecretName, "spec.configuration.kafkasql.security.tls.keystoreSecretName")
+		return nil, false
+	}
+	truststore, err := buildPemTruststore(truststoreSecret.Data[PEM_CA_CERTIFICATE_KEY])
+	if err != nil {
+		this.log.Errorw("could not build Kafka TLS truststore", "secretName", this.truststoreSecretName, "error", err)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(this.truststoreSecretName, "spec.configuration.kafkasql.security.tls.truststoreSecretName")
+		return nil, false
+	}
+	return map[string][]byte{
+		PEM_KEYSTORE_KEY:   keystore,
+		PEM_TRUSTSTORE_KEY: truststore,
+	}, true
+}
+
+func (this *KafkasqlSecurityTLSPemCF) writeSecret() error {
+	if this.secret != nil {
+		secret := this.secret.DeepCopy()
+		secret.Data = this.targetData
+		_, err := this.svcClients.Kube().UpdateSecret(this.ctx.GetAppNamespace(), secret)
+		return err
+	}
+	specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC)
+	if !exists {
+		return errors.New("could not find ApicurioRegistry")
+	}
+	secret := &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      this.targetSecretName,
+			Namespace: this.ctx.GetAppNamespace().Str(),
+			Labels:    this.services.GetKubeFactory().GetLabels(),
+		},
+		Type: core.SecretTypeOpaque,
+		Data: this.targetData,
+	}
+	_, err := this.svcClients.Kube().CreateSecret(specEntry.GetValue().(*ar.ApicurioRegistry), this.ctx.GetAppNamespace(), secret)
+	return err
+}
@@ -0,0 +1,321 @@
+package kafkasql
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/cf"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"go.uber.org/zap"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ loop.ControlFunction = &KafkasqlStrimziCF{}
+
+const DEFAULT_STRIMZI_LISTENER_NAME = "tls"
+
+// Kafka connection details resolved from the Strimzi resources.
+// When present in the resource cache, the other kafkasql CFs use them instead of the values in the spec.
+type StrimziConnection struct {
+	BootstrapServers    string
+	Authentication      ar.ApicurioRegistryStrimziAuthentication
+	UserName            string
+	UserSecretName      string
+	ClusterCaSecretName string
+	TopicName           string
+}
+
+func GetStrimziConnection(resourceCache resources.ResourceCache) (*StrimziConnection, bool) {
+	if entry, exists := resourceCache.Get(resources.RC_KEY_KAFKASQL_STRIMZI_CONNECTION); exists {
+		return entry.GetValue().(*StrimziConnection), true
+	}
+	return nil, false
+}
+
+type KafkasqlStrimziCF struct {
+	ctx              context.LoopContext
+	log              *zap.SugaredLogger
+	svcResourceCache resources.ResourceCache
+	svcClients       *client.Clients
+	services         services.LoopServices
+	strimziFactory   *factory.StrimziFactory
+	persistence      ar.ApicurioRegistryPersistence
+	clusterName      string
+	listenerName     string
+	authentication   ar.ApicurioRegistryStrimziAuthentication
+	topicName        string
+	enabled          bool
+	kafka            *unstructured.Unstructured
+	kafkaUser        *unstructured.Unstructured
+	kafkaTopic       *unstructured.Unstructured
+	topicConflict    bool
+}
+
+func NewKafkasqlStrimziCF(ctx context.LoopContext, services services.LoopServices) loop.ControlFunction {
+	res := &KafkasqlStrimziCF{
+		ctx:              ctx,
+		svcResourceCache: ctx.GetResourceCache(),
+		svcClients:       ctx.GetClients(),
+		services:         services,
+		strimziFactory:   services.GetStrimziFactory(),
+		persistence:      "",
+		clusterName:      "",
+		listenerName:     "",
+		authentication:   "",
+		topicName:        "",
+		enabled:          false,
+		kafka:            nil,
+		kafkaUser:        nil,
+		kafkaTopic:       nil,
+		topicConflict:    false,
+	}
+	res.log = ctx.GetLog().Sugar().With("cf", res.Describe())
+	return res
+}
+
+func (this *KafkasqlStrimziCF) Describe() string {
+	return "KafkasqlStrimziCF"
+}
+
+func (this *KafkasqlStrimziCF) Sense() {
+	// Observation #1
+	// Read the config values
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		spec := specEntry.GetValue().(*ar.ApicurioRegistry)
+		this.persistence = spec.Spec.Configuration.Persistence
+		this.clusterName = spec.Spec.Configuration.Kafkasql.Strimzi.ClusterName
+		this.listenerName = spec.Spec.Configuration.Kafkasql.Strimzi.ListenerName
+		this.authentication = spec.Spec.Configuration.Kafkasql.Strimzi.Authentication
+		this.topicName = spec.Spec.Configuration.Kafkasql.Topic.Name
+	}
+	if this.listenerName == "" {
+		this.listenerName = DEFAULT_STRIMZI_LISTENER_NAME
+	}
+	if this.authentication == "" {
+		this.authentication = ar.StrimziAuthenticationScramSha512
+	}
+	if this.topicName == "" {
+		this.topicName = this.strimziFactory.GetDefaultKafkaTopicName()
+	}
+
+	this.enabled = this.persistence == PERSISTENCE_ID && this.clusterName != ""
+	this.kafka = nil
+	this.kafkaUser = nil
+	this.kafkaTopic = nil
+	this.topicConflict = false
+
+	if !this.enabled {
+		this.svcResourceCache.Remove(resources.RC_KEY_KAFKASQL_STRIMZI_CONNECTION)
+		return
+	}
+
+	strimziClient := this.svcClients.Strimzi()
+	namespace := this.ctx.GetAppNamespace()
+
+	// Observation #2
+	// Get the Kafka cluster
+	kafka, err := strimziClient.GetKafka(namespace, common.Name(this.clusterName))
+	if err != nil {
+		this.log.Errorw("could not get Strimzi Kafka resource referenced in Apicurio Registry CR",
+			"name", this.clusterName, "error", err)
+		this.services.GetConditionManager().GetConfigurationErrorCondition().
+			TransitionInvalid(this.clusterName, "spec.configuration.kafkasql.strimzi.clusterName")
+		this.svcResourceCache.Remove(resources.RC_KEY_KAFKASQL_STRIMZI_CONNECTION)
+		this.ctx.SetRequeueDelaySec(10)
+		return
+	}
+	this.kafka = kafka
+
+	// Observation #3
+	// Get the KafkaUser
+	kafkaUser, err := strimziClient.GetKafkaUser(namespace, common.Name(this.strimziFactory.GetKafkaUserName()))
+	if err == nil {
+		this.kafkaUser = kafkaUser
+	} else if !api_errors.IsNotFound(err) {
+		this.log.Errorw("could not get KafkaUser", "error", err)
+	}
+
+	// Observation #4
+	// Get the KafkaTopic, it must not be used if it belongs to another ApicurioRegistry
+	kafkaTopic, err := strimziClient.GetKafkaTopic(namespace, common.Name(this.strimziFactory.GetKafkaTopicName(this.topicName)))
+	if err == nil {
+		if !cf.IsControlledByApp(this.ctx, kafkaTopic) {
+			this.log.Errorw("KafkaTopic already exists and is not managed by this Apicurio Registry CR, "+
+				"set a different topic name", "name", kafkaTopic.GetName())
+			this.services.GetConditionManager().GetConfigurationErrorCondition().
+				TransitionInvalid(this.topicName, "spec.configuration.kafkasql.topic.name")
+			this.topicConflict = true
+			this.svcResourceCache.Remove(resources.RC_KEY_KAFKASQL_STRIMZI_CONNECTION)
+			this.ctx.SetRequeueDelaySec(10)
+			return
+		}
+		this.kafkaTopic = kafkaTopic
+	} else if !api_errors.IsNotFound(err) {
+		this.log.Errorw("could not get KafkaTopic", "error", err)
+	}
+
+	// Observation #5
+	// Resolve the connection details, the resources may not be ready yet
+	if connection := this.resolveConnection(); connection != nil {
+		this.svcResourceCache.Set(resources.RC_KEY_KAFKASQL_STRIMZI_CONNECTION,
+			resources.NewResourceCacheEntry(common.Name(this.clusterName), connection))
+	} else {
+		this.log.Debugw("waiting for Strimzi resources to become ready", "cluster", this.clusterName)
+		this.svcResourceCache.Remove(resources.RC_KEY_KAFKASQL_STRIMZI_CONNECTION)
+		this.ctx.SetRequeueDelaySoon()
+	}
+}
+
+func (this *KafkasqlStrimziCF) Compare() bool {
+	// Condition #1
+	// Strimzi is configured and the Kafka cluster exists
+	// Condition #2
+	// KafkaUser or KafkaTopic are missing, or the KafkaUser has a different authentication type
+	// Condition #3
+	// KafkaTopic does not belong to another ApicurioRegistry
+	// Condition #4
+	// Act only once per loop, so a failing request does not prevent stabilization
+	return this.enabled && this.kafka != nil && !this.topicConflict &&
+		(this.kafkaUser == nil || this.kafkaTopic == nil || this.getUserAuthentication() != string(this.authentication)) &&
+		this.ctx.GetAttempts() == 0
+}
+
+func (this *KafkasqlStrimziCF) Respond() {
+	strimziClient := this.svcClients.Strimzi()
+	namespace := this.ctx.GetAppNamespace()
+
+	specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC)
+	if !exists {
+		return
+	}
+	owner := specEntry.GetValue().(*ar.ApicurioRegistry)
+
+	// Response #1
+	// Create or update the KafkaUser
+	if this.kafkaUser == nil {
+		kafkaUser := this.strimziFactory.NewKafkaUser(this.clusterName, string(this.authentication))
+		if _, err := strimziClient.CreateKafkaUser(owner, namespace, kafkaUser); err != nil {
+			this.log.Errorw("could not create KafkaUser", "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+		}
+	} else if this.getUserAuthentication() != string(this.authentication) {
+		kafkaUser := this.kafkaUser.DeepCopy()
+		if err := unstructured.SetNestedField(kafkaUser.Object, string(this.authentication), "spec", "authentication", "type"); err != nil {
+			this.log.Errorw("could not set KafkaUser authentication type", "error", err)
+		} else if _, err := strimziClient.UpdateKafkaUser(namespace, kafkaUser); err != nil {
+			this.log.Errorw("could not update KafkaUser", "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+		}
+	}
+
+	// Response #2
+	// Create the KafkaTopic, it is not updated afterwards
+	if this.kafkaTopic == nil {
+		replicas, _, _ := unstructured.NestedInt64(this.kafka.Object, "spec", "kafka", "replicas")
+		kafkaTopic := this.strimziFactory.NewKafkaTopic(this.clusterName, this.topicName, replicas)
+		if _, err := strimziClient.CreateKafkaTopic(owner, namespace, kafkaTopic); err != nil {
+			this.log.Errorw("could not create KafkaTopic", "error", err)
+			this.ctx.SetRequeueDelaySec(10)
+		}
+	}
+}
+
+func (this *KafkasqlStrimziCF) Cleanup() bool {
+	// KafkaUser and KafkaTopic are owned by the ApicurioRegistry resource and deleted by the garbage collector,
+	// unless they are retained
+	strimziClient := this.svcClients.Strimzi()
+	namespace := this.ctx.GetAppNamespace()
+	topicName := this.strimziFactory.GetDefaultKafkaTopicName()
+	if specEntry, exists := this.svcResourceCache.Get(resources.RC_KEY_SPEC); exists {
+		if name := specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Configuration.Kafkasql.Topic.Name; name != "" {
+			topicName = name
+		}
+	}
+	success := true
+	if cf.IsRetainedOnDeletion(this.ctx, ar.ManagedResourceKindKafkaUser) {
+		success = this.retain(ar.ManagedResourceKindKafkaUser,
+			func() (*unstructured.Unstructured, error) {
+				return strimziClient.GetKafkaUser(namespace, common.Name(this.strimziFactory.GetKafkaUserName()))
+			},
+			func(value *unstructured.Unstructured) error {
+				_, err := strimziClient.UpdateKafkaUser(namespace, value)
+				return err
+			}) && success
+	}
+	if cf.IsRetainedOnDeletion(this.ctx, ar.ManagedResourceKindKafkaTopic) {
+		success = this.retain(ar.ManagedResourceKindKafkaTopic,
+			func() (*unstructured.Unstructured, error) {
+				return strimziClient.GetKafkaTopic(namespace, common.Name(this.strimziFactory.GetKafkaTopicName(topicName)))
+			},
+			func(value *unstructured.Unstructured) error {
+				_, err := strimziClient.UpdateKafkaTopic(namespace, value)
+				return err
+			}) && success
+	}
+	return success
+}
+
+// Removes the owner reference, so the resource is not deleted by the garbage collector
+func (this *KafkasqlStrimziCF) retain(kind ar.ApicurioRegistryManagedResourceKind,
+	get func() (*unstructured.Unstructured, error), update func(value *unstructured.Unstructured) error) bool {
+	value, err := get()
+	if api_errors.IsNotFound(err) {
+		return true
+	}
+	if err != nil {
+		this.log.Errorw("could not get resource during cleanup", "kind", kind, "error", err)
+		return false
+	}
+	value = value.DeepCopy()
+	if cf.RemoveAppOwnerReference(this.ctx, value) {
+		if err := update(value); err != nil && !api_errors.IsNotFound(err) {
+			this.log.Errorw("could not remove the owner reference during cleanup", "kind", kind, "error", err)
+			return false
+		}
+		this.ctx.GetLog().Sugar().Infow("resource has been retained", "kind", kind, "name", value.GetName())
+	}
+	return true
+}
+
+func (this *KafkasqlStrimziCF) getUserAuthentication() string {
+	if this.kafkaUser == nil {
+		return ""
+	}
+	res, _, _ := unstructured.NestedString(this.kafkaUser.Object, "spec", "authentication", "type")
+	return res
+}
+
+// Returns nil if the Kafka cluster or the KafkaUser are not ready
+func (this *KafkasqlStrimziCF) resolveConnection() *StrimziConnection {
+	if this.kafka == nil || this.kafkaUser == nil || this.kafkaTopic == nil {
+		return nil
+	}
+	bootstrapServers := ""
+	listeners, _, _ := unstructured.NestedSlice(this.kafka.Object, "status", "listeners")
+	for _, l := range listeners {
+		if listener, ok := l.(map[string]interface{}); ok {
+			if name, _, _ := unstructured.NestedString(listener, "name"); name == this.listenerName {
+				bootstrapServers, _, _ = unstructured.NestedString(listener, "bootstrapServers")
+			}
+		}
+	}
+	userName, _, _ := unstructured.NestedString(this.kafkaUser.Object, "status", "username")
+	userSecretName, _, _ := unstructured.NestedString(this.kafkaUser.Object, "status", "secret")
+	if bootstrapServers == "" || userName == "" || userSecretName == "" ||
+		this.getUserAuthentication() != string(this.authentication) {
+		return nil
+	}
+	return &StrimziConnection{
+		BootstrapServers:    bootstrapServers,
+		Authentication:      this.authentication,
+		UserName:            userName,
+		UserSecretName:      userSecretName,
+		ClusterCaSecretName: this.clusterName + "-cluster-ca-cert",
+		TopicName:           this.topicName,
+	}
+}
@@ -0,0 +1,89 @@
+package kafkasql
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/cf"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"testing"
+)
+
+func TestKafkasqlStrimziCF(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	services := services2.NewLoopServicesMock(ctx)
+	spec := &ar.ApicurioRegistry{}
+	spec.UID = "registry-uid"
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), spec))
+	strimziFactory := services.GetStrimziFactory()
+
+	this := NewKafkasqlStrimziCF(ctx, services).(*KafkasqlStrimziCF)
+	this.enabled = true
+	this.clusterName = "my-cluster"
+	this.listenerName = DEFAULT_STRIMZI_LISTENER_NAME
+	this.authentication = ar.StrimziAuthenticationScramSha512
+	this.topicName = strimziFactory.GetDefaultKafkaTopicName()
+	this.kafka = &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "plain", "bootstrapServers": "my-cluster-kafka-bootstrap:9092"},
+				map[string]interface{}{"name": "tls", "bootstrapServers": "my-cluster-kafka-bootstrap:9093"},
+			},
+		},
+	}}
+
+	// The KafkaUser and KafkaTopic are created
+	c.AssertEquals(t, true, this.Compare())
+	c.AssertEquals(t, true, this.resolveConnection() == nil)
+
+	// Only once per loop
+	ctx.SetAttempts(1)
+	c.AssertEquals(t, false, this.Compare())
+	ctx.SetAttempts(0)
+
+	this.kafkaUser = strimziFactory.NewKafkaUser(this.clusterName, string(ar.StrimziAuthenticationTls))
+	this.kafkaTopic = strimziFactory.NewKafkaTopic(this.clusterName, this.topicName, 3)
+	this.kafkaTopic.SetOwnerReferences([]meta.OwnerReference{
+		{UID: spec.UID, Controller: pointer.Bool(true)},
+	})
+	c.AssertEquals(t, true, cf.IsControlledByApp(ctx, this.kafkaTopic))
+
+	// The KafkaUser authentication type is updated
+	c.AssertEquals(t, true, this.Compare())
+	c.AssertEquals(t, true, unstructured.SetNestedField(this.kafkaUser.Object, string(ar.StrimziAuthenticationScramSha512),
+		"spec", "authentication", "type") == nil)
+	c.AssertEquals(t, false, this.Compare())
+
+	// The KafkaUser is not ready yet
+	c.AssertEquals(t, true, this.resolveConnection() == nil)
+	this.kafkaUser.Object["status"] = map[string]interface{}{
+		"username": "registry-kafkasql",
+		"secret":   "registry-kafkasql",
+	}
+	connection := this.resolveConnection()
+	c.AssertEquals(t, "my-cluster-kafka-bootstrap:9093", connection.BootstrapServers)
+	c.AssertEquals(t, "registry-kafkasql", connection.UserName)
+	c.AssertEquals(t, "my-cluster-cluster-ca-cert", connection.ClusterCaSecretName)
+	c.AssertEquals(t, ctx.GetAppName().Str()+"-kafkasql-journal", connection.TopicName)
+
+	// The listener does not exist
+	this.listenerName = "external"
+	c.AssertEquals(t, true, this.resolveConnection() == nil)
+	this.listenerName = DEFAULT_STRIMZI_LISTENER_NAME
+
+	// The KafkaTopic belongs to another ApicurioRegistry, it is not created or used
+	this.kafkaTopic.SetOwnerReferences([]meta.OwnerReference{
+		{UID: "other-uid", Controller: pointer.Bool(true)},
+		{UID: spec.UID},
+	})
+	c.AssertEquals(t, false, cf.IsControlledByApp(ctx, this.kafkaTopic))
+	this.kafkaTopic.SetOwnerReferences(nil)
+	c.AssertEquals(t, false, cf.IsControlledByApp(ctx, this.kafkaTopic))
+	this.kafkaTopic = nil
+	this.topicConflict = true
+	c.AssertEquals(t, false, this.Compare())
+}
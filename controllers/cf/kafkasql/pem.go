@@ -0,0 +1,99 @@
+package kafkasql
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"sort"
+)
+
+// Keys of the Secrets in PEM format, as used by cert-manager
+const PEM_CERTIFICATE_KEY = "tls.crt"
+const PEM_PRIVATE_KEY_KEY = "tls.key"
+const PEM_CA_CERTIFICATE_KEY = "ca.crt"
+
+// Keys of the Operator-owned Secret with the generated stores
+const PEM_KEYSTORE_KEY = "keystore.pem"
+const PEM_TRUSTSTORE_KEY = "truststore.pem"
+
+// Build a Kafka PEM keystore, which consists of an unencrypted private key in PKCS#8 format,
+// followed by the certificate chain.
+// PKCS#1 (RSA) and SEC 1 (EC) private keys are converted to PKCS#8, since Kafka does not support them.
+func buildPemKeystore(certificate []byte, privateKey []byte) ([]byte, error) {
+	keyBlock, _ := pem.Decode(privateKey)
+	if keyBlock == nil {
+		return nil, errors.New("could not decode the private key")
+	}
+	var key interface{}
+	var err error
+	switch keyBlock.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(keyBlock.Bytes)
+	default:
+		err = errors.New("unsupported private key type " + keyBlock.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := getPemCertificates(certificate)
+	if err != nil {
+		return nil, err
+	}
+	return append(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), chain...), nil
+}
+
+// Build a Kafka PEM truststore, which consists of the CA certificates
+func buildPemTruststore(caCertificate []byte) ([]byte, error) {
+	return getPemCertificates(caCertificate)
+}
+
+// Return the certificate blocks in the given PEM data, ignoring any other content.
+// Returns an error if there is no valid certificate.
+func getPemCertificates(data []byte) ([]byte, error) {
+	res := make([]byte, 0)
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, err
+		}
+		res = append(res, pem.EncodeToMemory(block)...)
+	}
+	if len(res) == 0 {
+		return nil, errors.New("could not find any certificate")
+	}
+	return res, nil
+}
+
+// Return a hash of the Secret data, which does not depend on the order of the keys
+func hashSecretData(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(data[k])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,76 @@
+package kafkasql
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func createTestCertificate(t *testing.T, key crypto.Signer) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "registry"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestBuildPemKeystore(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyBytes, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		signer crypto.Signer
+		key    []byte
+	}{
+		{rsaKey, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})},
+		{ecKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecKeyBytes})},
+	} {
+		certificate := createTestCertificate(t, tc.signer)
+		keystore, err := buildPemKeystore(certificate, tc.key)
+		c.AssertEquals(t, nil, err)
+
+		// The key is converted to PKCS#8 and followed by the certificate
+		keyBlock, rest := pem.Decode(keystore)
+		c.AssertEquals(t, "PRIVATE KEY", keyBlock.Type)
+		_, err = x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+		c.AssertEquals(t, nil, err)
+		c.AssertEquals(t, string(certificate), string(rest))
+	}
+
+	_, err = buildPemKeystore(createTestCertificate(t, rsaKey), []byte("invalid"))
+	c.AssertEquals(t, true, err != nil)
+	_, err = buildPemKeystore([]byte("invalid"),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+	c.AssertEquals(t, true, err != nil)
+}
+
+func TestHashSecretData(t *testing.T) {
+	a := hashSecretData(map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+	c.AssertEquals(t, a, hashSecretData(map[string][]byte{"b": []byte("2"), "a": []byte("1")}))
+	c.AssertEquals(t, false, a == hashSecretData(map[string][]byte{"a": []byte("1"), "b": []byte("3")}))
+	c.AssertEquals(t, false, a == hashSecretData(map[string][]byte{"a1": []byte(""), "b": []byte("2")}))
+}
@@ -0,0 +1,85 @@
+package kafkasql
+
+import (
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"strings"
+)
+
+// Env. variable prefixes for the supported scopes of `spec.configuration.kafkasql.properties`
+var kafkaPropertyScopes = map[string]string{
+	"common":   "REGISTRY_KAFKA_COMMON_",
+	"producer": "REGISTRY_KAFKASQL_PRODUCER_",
+	"consumer": "REGISTRY_KAFKASQL_CONSUMER_",
+}
+
+// Kafka client properties that can be used in any scope.
+// Bootstrap servers and security properties are configured by the Operator and are not allowed.
+var allowedKafkaCommonProperties = []string{
+	"client.id",
+	"client.dns.lookup",
+	"connections.max.idle.ms",
+	"metadata.max.age.ms",
+	"receive.buffer.bytes",
+	"reconnect.backoff.max.ms",
+	"reconnect.backoff.ms",
+	"request.timeout.ms",
+	"retry.backoff.ms",
+	"send.buffer.bytes",
+	"socket.connection.setup.timeout.max.ms",
+	"socket.connection.setup.timeout.ms",
+}
+
+var allowedKafkaProducerProperties = []string{
+	"acks",
+	"batch.size",
+	"buffer.memory",
+	"compression.type",
+	"delivery.timeout.ms",
+	"enable.idempotence",
+	"linger.ms",
+	"max.block.ms",
+	"max.in.flight.requests.per.connection",
+	"max.request.size",
+	"retries",
+}
+
+var allowedKafkaConsumerProperties = []string{
+	"auto.offset.reset",
+	"fetch.max.bytes",
+	"fetch.max.wait.ms",
+	"fetch.min.bytes",
+	"group.id",
+	"heartbeat.interval.ms",
+	"isolation.level",
+	"max.partition.fetch.bytes",
+	"max.poll.interval.ms",
+	"max.poll.records",
+	"session.timeout.ms",
+}
+
+// Returns the env. variable name for the given `<scope>.<property>` key,
+// or false if the key is not allowed.
+func GetKafkaPropertyEnvName(key string) (string, bool) {
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+	prefix, exists := kafkaPropertyScopes[parts[0]]
+	if !exists {
+		return "", false
+	}
+	property := parts[1]
+	_, allowed := c.FindString(allowedKafkaCommonProperties, property)
+	switch parts[0] {
+	case "producer":
+		_, found := c.FindString(allowedKafkaProducerProperties, property)
+		allowed = allowed || found
+	case "consumer":
+		_, found := c.FindString(allowedKafkaConsumerProperties, property)
+		allowed = allowed || found
+	}
+	if !allowed {
+		return "", false
+	}
+	return prefix + strings.ToUpper(strings.ReplaceAll(property, ".", "_")), true
+}
@@ -0,0 +1,32 @@
+package kafkasql
+
+import (
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"testing"
+)
+
+func TestGetKafkaPropertyEnvName(t *testing.T) {
+	name, allowed := GetKafkaPropertyEnvName("producer.linger.ms")
+	c.AssertEquals(t, true, allowed)
+	c.AssertEquals(t, "REGISTRY_KAFKASQL_PRODUCER_LINGER_MS", name)
+
+	name, allowed = GetKafkaPropertyEnvName("consumer.group.id")
+	c.AssertEquals(t, true, allowed)
+	c.AssertEquals(t, "REGISTRY_KAFKASQL_CONSUMER_GROUP_ID", name)
+
+	name, allowed = GetKafkaPropertyEnvName("common.request.timeout.ms")
+	c.AssertEquals(t, true, allowed)
+	c.AssertEquals(t, "REGISTRY_KAFKA_COMMON_REQUEST_TIMEOUT_MS", name)
+
+	// Scope specific property in a wrong scope
+	_, allowed = GetKafkaPropertyEnvName("producer.group.id")
+	c.AssertEquals(t, false, allowed)
+	// Managed by the Operator
+	_, allowed = GetKafkaPropertyEnvName("common.security.protocol")
+	c.AssertEquals(t, false, allowed)
+	// Typo
+	_, allowed = GetKafkaPropertyEnvName("producer.linger.msec")
+	c.AssertEquals(t, false, allowed)
+	_, allowed = GetKafkaPropertyEnvName("linger.ms")
+	c.AssertEquals(t, false, allowed)
+}
@@ -0,0 +1,152 @@
+package kafkasql
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"reflect"
+	"strings"
+)
+
+const (
+	SECURITY_MODE_TLS   = "tls"
+	SECURITY_MODE_SCRAM = "scram"
+	SECURITY_MODE_OAUTH = "oauth"
+	SECURITY_MODE_PLAIN = "plain"
+)
+
+// Returns the names of the Kafka security modes that have at least one option configured.
+// At most one mode can be used at the same time.
+func GetSecurityModes(security ar.ApicurioRegistrySpecConfigurationKafkaSecurity) []string {
+	res := make([]string, 0)
+	if !reflect.DeepEqual(security.Tls, ar.ApicurioRegistrySpecConfigurationKafkaSecurityTls{}) {
+		res = append(res, SECURITY_MODE_TLS)
+	}
+	if !reflect.DeepEqual(security.Scram, ar.ApicurioRegistrySpecConfigurationKafkaSecurityScram{}) {
+		res = append(res, SECURITY_MODE_SCRAM)
+	}
+	if !reflect.DeepEqual(security.OAuth, ar.ApicurioRegistrySpecConfigurationKafkaSecurityOAuth{}) {
+		res = append(res, SECURITY_MODE_OAUTH)
+	}
+	if !reflect.DeepEqual(security.Plain, ar.ApicurioRegistrySpecConfigurationKafkaSecurityPlain{}) {
+		res = append(res, SECURITY_MODE_PLAIN)
+	}
+	return res
+}
+
+// Returns true if the given mode is the only security mode configured
+func isOnlySecurityMode(security ar.ApicurioRegistrySpecConfigurationKafkaSecurity, mode string) bool {
+	modes := GetSecurityModes(security)
+	return len(modes) == 1 && modes[0] == mode
+}
+
+// Build a JAAS configuration entry for the given login module.
+// Option values can reference other env. variables using the `$(NAME)` syntax,
+// in which case the entry must declare a dependency on them.
+func buildJaasConfig(loginModule string, options [][2]string) string {
+	var b strings.Builder
+	b.WriteString(loginModule)
+	b.WriteString(" required")
+	for _, o := range options {
+		b.WriteString(" " + o[0] + "='" + o[1] + "'")
+	}
+	b.WriteString(";")
+	return b.String()
+}
+
+func addSaslEnv(envCache env.EnvCache, mechanism string, securityProtocol string, jaasConfig string, jaasDependencies ...string) {
+	envCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_PROPERTIES_PREFIX, "REGISTRY_").Build())
+	envCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SASL_MECHANISM, mechanism).Build())
+	jaasBuilder := env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG, jaasConfig)
+	for _, d := range jaasDependencies {
+		jaasBuilder.SetDependency(d)
+	}
+	envCache.Set(jaasBuilder.Build())
+	envCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SECURITY_PROTOCOL, securityProtocol).Build())
+}
+
+func addSecretKeyEnv(envCache env.EnvCache, name string, secretName string, key string) {
+	envCache.Set(env.NewEnvCacheEntryBuilder(&core.EnvVar{
+		Name: name,
+		ValueFrom: &core.EnvVarSource{
+			SecretKeyRef: &core.SecretKeySelector{
+				LocalObjectReference: core.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}).Build())
+}
+
+func addTruststoreEnv(envCache env.EnvCache, truststoreSecretName string, truststoreSecretVolumeName string) {
+	envCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_TYPE, "PKCS12").Build())
+	envCache.Set(env.NewSimpleEnvCacheEntryBuilder(ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_LOCATION,
+		"/etc/"+truststoreSecretVolumeName+"/ca.p12").Build())
+	addSecretKeyEnv(envCache, ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_PASSWORD, truststoreSecretName, "ca.password")
+}
+
+// Returns the name of the secret mounted as the given volume, or an empty string
+func getSecretVolumeSecretName(deploymentEntry resources.ResourceCacheEntry, volumeName string) string {
+	deployment := deploymentEntry.GetValue().(*apps.Deployment)
+	for _, v := range deployment.Spec.Template.Spec.Volumes {
+		if v.Name == volumeName && v.VolumeSource.Secret != nil {
+			return v.VolumeSource.Secret.SecretName
+		}
+	}
+	return ""
+}
+
+func addSecretVolumePatch(deploymentEntry resources.ResourceCacheEntry, secretName string, volumeName string) {
+	deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+		deployment := value.(*apps.Deployment).DeepCopy()
+		volume := core.Volume{
+			Name: volumeName,
+			VolumeSource: core.VolumeSource{
+				Secret: &core.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		}
+		j := -1
+		for i, v := range deployment.Spec.Template.Spec.Volumes {
+			if v.Name == volumeName {
+				j = i
+				deployment.Spec.Template.Spec.Volumes[i] = volume
+			}
+		}
+		if j == -1 {
+			deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
+		}
+		return deployment
+	})
+}
+
+func addSecretMountPatch(deploymentEntry resources.ResourceCacheEntry, volumeName string, mountPath string) {
+	deploymentEntry.ApplyPatch(func(value interface{}) interface{} {
+		deployment := value.(*apps.Deployment).DeepCopy()
+		for ci, c := range deployment.Spec.Template.Spec.Containers {
+			if c.Name == factory.REGISTRY_CONTAINER_NAME {
+				mount := core.VolumeMount{
+					Name:      volumeName,
+					ReadOnly:  true,
+					MountPath: mountPath,
+				}
+				j := -1
+				for i, v := range deployment.Spec.Template.Spec.Containers[ci].VolumeMounts {
+					if v.Name == volumeName {
+						j = i
+						deployment.Spec.Template.Spec.Containers[ci].VolumeMounts[i] = mount
+					}
+				}
+				if j == -1 {
+					deployment.Spec.Template.Spec.Containers[ci].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[ci].VolumeMounts, mount)
+				}
+			}
+		}
+		return deployment
+	})
+}
@@ -0,0 +1,170 @@
+package kafkasql
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	loop_impl "github.com/Apicurio/apicurio-registry-operator/controllers/loop/impl"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestGetSecurityModes(t *testing.T) {
+	c.AssertEquals(t, []string{}, GetSecurityModes(ar.ApicurioRegistrySpecConfigurationKafkaSecurity{}))
+	c.AssertEquals(t, []string{SECURITY_MODE_SCRAM, SECURITY_MODE_PLAIN}, GetSecurityModes(ar.ApicurioRegistrySpecConfigurationKafkaSecurity{
+		Scram: ar.ApicurioRegistrySpecConfigurationKafkaSecurityScram{
+			User: "user",
+		},
+		Plain: ar.ApicurioRegistrySpecConfigurationKafkaSecurityPlain{
+			User: "user",
+		},
+	}))
+}
+
+func TestKafkasqlSecurityPlainCF(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	services := services2.NewLoopServicesMock(ctx)
+	loop := loop_impl.NewControlLoopImpl(ctx, services)
+	loop.AddControlFunction(NewKafkasqlSecurityPlainCF(ctx))
+
+	ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, resources.NewResourceCacheEntry(ctx.GetAppName(), &apps.Deployment{
+		Spec: apps.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: factory.REGISTRY_CONTAINER_NAME,
+						},
+					},
+				},
+			},
+		},
+	}))
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+		Spec: ar.ApicurioRegistrySpec{
+			Configuration: ar.ApicurioRegistrySpecConfiguration{
+				Persistence: ar.PersistenceKafkasql,
+				Kafkasql: ar.ApicurioRegistrySpecConfigurationKafkasql{
+					BootstrapServers: "kafka:9092",
+					Security: ar.ApicurioRegistrySpecConfigurationKafkaSecurity{
+						Plain: ar.ApicurioRegistrySpecConfigurationKafkaSecurityPlain{
+							User:               "registry",
+							PasswordSecretName: "registry-password",
+							SecurityProtocol:   ar.KafkaSecurityProtocolSaslPlaintext,
+						},
+					},
+				},
+			},
+		},
+	}))
+	loop.Run()
+	ctx.Finalize()
+
+	sorted := ctx.GetEnvCache().GetSorted()
+	values := make(map[string]string)
+	sortedI := make([]interface{}, len(sorted))
+	for i, v := range sorted {
+		values[v.Name] = v.Value
+		sortedI[i] = v.Name
+	}
+	c.AssertEquals(t, "PLAIN", values[ENV_REGISTRY_KAFKA_COMMON_SASL_MECHANISM])
+	c.AssertEquals(t, "SASL_PLAINTEXT", values[ENV_REGISTRY_KAFKA_COMMON_SECURITY_PROTOCOL])
+	c.AssertEquals(t, "org.apache.kafka.common.security.plain.PlainLoginModule required "+
+		"username='$(REGISTRY_KAFKASQL_PLAIN_USER)' password='$(REGISTRY_KAFKASQL_PLAIN_PASSWORD)';",
+		values[ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG])
+	// Referenced variables must be defined before the JAAS config
+	c.AssertIsInOrder(t, sortedI, ENV_REGISTRY_KAFKASQL_PLAIN_USER, ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG)
+	c.AssertIsInOrder(t, sortedI, ENV_REGISTRY_KAFKASQL_PLAIN_PASSWORD, ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG)
+}
+
+func TestKafkasqlSecurityOAuthCF(t *testing.T) {
+	for _, truststoreSecretName := range []string{"", "kafka-truststore"} {
+		t.Run("truststore="+truststoreSecretName, func(t *testing.T) {
+			ctx := context.NewLoopContextMock()
+			services := services2.NewLoopServicesMock(ctx)
+			loop := loop_impl.NewControlLoopImpl(ctx, services)
+			loop.AddControlFunction(NewKafkasqlSecurityOAuthCF(ctx))
+
+			deploymentEntry := resources.NewResourceCacheEntry(ctx.GetAppName(), &apps.Deployment{
+				Spec: apps.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name: factory.REGISTRY_CONTAINER_NAME,
+								},
+							},
+						},
+					},
+				},
+			})
+			ctx.GetResourceCache().Set(resources.RC_KEY_DEPLOYMENT, deploymentEntry)
+			ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+				Spec: ar.ApicurioRegistrySpec{
+					Configuration: ar.ApicurioRegistrySpecConfiguration{
+						Persistence: ar.PersistenceKafkasql,
+						Kafkasql: ar.ApicurioRegistrySpecConfigurationKafkasql{
+							BootstrapServers: "kafka:9093",
+							Security: ar.ApicurioRegistrySpecConfigurationKafkaSecurity{
+								OAuth: ar.ApicurioRegistrySpecConfigurationKafkaSecurityOAuth{
+									TokenEndpointUrl:       "https://sso.example.com/token",
+									ClientId:               "registry",
+									ClientSecretSecretName: "registry-client-secret",
+									Scope:                  "kafka",
+									TruststoreSecretName:   truststoreSecretName,
+								},
+							},
+						},
+					},
+				},
+			}))
+			loop.Run()
+			ctx.Finalize()
+
+			sorted := ctx.GetEnvCache().GetSorted()
+			values := make(map[string]corev1.EnvVar)
+			sortedI := make([]interface{}, len(sorted))
+			for i, v := range sorted {
+				values[v.Name] = v
+				sortedI[i] = v.Name
+			}
+			c.AssertEquals(t, "OAUTHBEARER", values[ENV_REGISTRY_KAFKA_COMMON_SASL_MECHANISM].Value)
+			// The default security protocol
+			c.AssertEquals(t, "SASL_SSL", values[ENV_REGISTRY_KAFKA_COMMON_SECURITY_PROTOCOL].Value)
+			c.AssertEquals(t, "org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required "+
+				"clientId='$(REGISTRY_KAFKASQL_OAUTH_CLIENT_ID)' clientSecret='$(REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET)' scope='kafka';",
+				values[ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG].Value)
+			// Referenced variables must be defined before the JAAS config
+			c.AssertIsInOrder(t, sortedI, ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_ID, ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG)
+			c.AssertIsInOrder(t, sortedI, ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET, ENV_REGISTRY_KAFKA_COMMON_SASL_JAAS_CONFIG)
+			c.AssertEquals(t, "registry", values[ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_ID].Value)
+			c.AssertEquals(t, &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "registry-client-secret"},
+				Key:                  "client-secret",
+			}, values[ENV_REGISTRY_KAFKASQL_OAUTH_CLIENT_SECRET].ValueFrom.SecretKeyRef)
+			c.AssertEquals(t, "https://sso.example.com/token", values[ENV_REGISTRY_KAFKA_COMMON_SASL_OAUTHBEARER_TOKEN_ENDPOINT_URL].Value)
+			c.AssertEquals(t, OAUTH_LOGIN_CALLBACK_HANDLER_CLASS, values[ENV_REGISTRY_KAFKA_COMMON_SASL_LOGIN_CALLBACK_HANDLER_CLASS].Value)
+
+			// The truststore is optional
+			_, exists := values[ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_LOCATION]
+			c.AssertEquals(t, truststoreSecretName != "", exists)
+			c.AssertEquals(t, truststoreSecretName, getSecretVolumeSecretName(deploymentEntry, OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME))
+			mounts := deploymentEntry.GetValue().(*apps.Deployment).Spec.Template.Spec.Containers[0].VolumeMounts
+			if truststoreSecretName != "" {
+				c.AssertEquals(t, "/etc/"+OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME+"/ca.p12", values[ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_LOCATION].Value)
+				c.AssertEquals(t, truststoreSecretName, values[ENV_REGISTRY_KAFKA_COMMON_SSL_TRUSTSTORE_PASSWORD].ValueFrom.SecretKeyRef.Name)
+				c.AssertEquals(t, []corev1.VolumeMount{{
+					Name:      OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME,
+					ReadOnly:  true,
+					MountPath: "etc/" + OAUTH_TRUSTSTORE_SECRET_VOLUME_NAME,
+				}}, mounts)
+			} else {
+				c.AssertEquals(t, 0, len(mounts))
+			}
+		})
+	}
+}
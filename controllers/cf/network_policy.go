@@ -0,0 +1,154 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const DnsPort = 53
+
+// Default ports of the supported JDBC URL schemes
+var jdbcDefaultPorts = map[string]int{
+	"postgresql": 5432,
+	"mysql":      3306,
+	"sqlserver":  1433,
+}
+
+// Build the egress rules for the destinations derived from the Apicurio Registry configuration.
+// NetworkPolicies can not select destinations by hostname, so the rules only restrict the destination port,
+// unless an IP address is used instead of a hostname.
+func NewConfigEgressRules(spec *ar.ApicurioRegistrySpec) []networking.NetworkPolicyEgressRule {
+	res := make([]networking.NetworkPolicyEgressRule, 0)
+	add := func(rule networking.NetworkPolicyEgressRule) {
+		for _, r := range res {
+			if reflect.DeepEqual(r, rule) {
+				return
+			}
+		}
+		res = append(res, rule)
+	}
+
+	// DNS
+	udp := core.ProtocolUDP
+	tcp := core.ProtocolTCP
+	dnsPort := intstr.FromInt(DnsPort)
+	add(networking.NetworkPolicyEgressRule{
+		Ports: []networking.NetworkPolicyPort{
+			{Protocol: &udp, Port: &dnsPort},
+			{Protocol: &tcp, Port: &dnsPort},
+		},
+	})
+
+	switch spec.Configuration.Persistence {
+	case ar.PersistenceSql:
+		if host, port, ok := parseJdbcUrl(spec.Configuration.Sql.DataSource.Url); ok {
+			add(newEgressRule(host, port))
+		}
+	case ar.PersistenceKafkasql:
+		for _, server := range strings.Split(spec.Configuration.Kafkasql.BootstrapServers, ",") {
+			if host, port, ok := parseHostPort(strings.TrimSpace(server), 0); ok {
+				add(newEgressRule(host, port))
+			}
+		}
+		// The bootstrap servers are read from the Kafka resource, allow all Strimzi cluster pods
+		if clusterName := spec.Configuration.Kafkasql.Strimzi.ClusterName; clusterName != "" {
+			add(networking.NetworkPolicyEgressRule{
+				To: []networking.NetworkPolicyPeer{
+					{
+						PodSelector: &meta.LabelSelector{
+							MatchLabels: map[string]string{"strimzi.io/cluster": clusterName},
+						},
+					},
+				},
+			})
+		}
+		if host, port, ok := parseUrl(spec.Configuration.Kafkasql.Security.OAuth.TokenEndpointUrl); ok {
+			add(newEgressRule(host, port))
+		}
+	}
+
+	if host, port, ok := parseUrl(spec.Configuration.Security.Keycloak.Url); ok {
+		add(newEgressRule(host, port))
+	}
+	return res
+}
+
+func newEgressRule(host string, port int) networking.NetworkPolicyEgressRule {
+	tcp := core.ProtocolTCP
+	targetPort := intstr.FromInt(port)
+	rule := networking.NetworkPolicyEgressRule{
+		Ports: []networking.NetworkPolicyPort{
+			{Protocol: &tcp, Port: &targetPort},
+		},
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		cidr := ip.String() + "/32"
+		if ip.To4() == nil {
+			cidr = ip.String() + "/128"
+		}
+		rule.To = []networking.NetworkPolicyPeer{
+			{
+				IPBlock: &networking.IPBlock{CIDR: cidr},
+			},
+		}
+	}
+	return rule
+}
+
+// For example, jdbc:postgresql://postgresql.registry.svc:5432/registry
+func parseJdbcUrl(value string) (string, int, bool) {
+	if !strings.HasPrefix(value, "jdbc:") {
+		return "", 0, false
+	}
+	parsed, err := url.Parse(strings.TrimPrefix(value, "jdbc:"))
+	if err != nil || parsed.Hostname() == "" {
+		return "", 0, false
+	}
+	return parseHostPort(parsed.Host, jdbcDefaultPorts[parsed.Scheme])
+}
+
+func parseUrl(value string) (string, int, bool) {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Hostname() == "" {
+		return "", 0, false
+	}
+	defaultPort := 0
+	switch parsed.Scheme {
+	case "http":
+		defaultPort = 80
+	case "https":
+		defaultPort = 443
+	}
+	return parseHostPort(parsed.Host, defaultPort)
+}
+
+// Returns false if the value is empty, or the port is missing and there is no default
+func parseHostPort(value string, defaultPort int) (string, int, bool) {
+	if value == "" {
+		return "", 0, false
+	}
+	host, portStr, err := net.SplitHostPort(value)
+	if err != nil {
+		// No port
+		host = strings.Trim(value, "[]")
+		portStr = ""
+	}
+	port := defaultPort
+	if portStr != "" {
+		if port, err = strconv.Atoi(portStr); err != nil {
+			return "", 0, false
+		}
+	}
+	if host == "" || port <= 0 {
+		return "", 0, false
+	}
+	return host, port, true
+}
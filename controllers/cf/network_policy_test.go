@@ -0,0 +1,47 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	networking "k8s.io/api/networking/v1"
+	"testing"
+)
+
+func TestNewConfigEgressRules(t *testing.T) {
+	spec := &ar.ApicurioRegistrySpec{
+		Configuration: ar.ApicurioRegistrySpecConfiguration{
+			Persistence: ar.PersistenceSql,
+			Sql: ar.ApicurioRegistrySpecConfigurationSql{
+				DataSource: ar.ApicurioRegistrySpecConfigurationDataSource{
+					Url: "jdbc:postgresql://postgresql.registry.svc/registry",
+				},
+			},
+			Security: ar.ApicurioRegistrySpecConfigurationSecurity{
+				Keycloak: ar.ApicurioRegistrySpecConfigurationSecurityKeycloak{
+					Url: "https://10.0.0.1:8443/auth",
+				},
+			},
+		},
+	}
+	rules := NewConfigEgressRules(spec)
+	c.AssertEquals(t, 3, len(rules))
+	c.AssertEquals(t, DnsPort, rules[0].Ports[0].Port.IntValue())
+	c.AssertEquals(t, 5432, rules[1].Ports[0].Port.IntValue())
+	c.AssertEquals(t, []networking.NetworkPolicyPeer(nil), rules[1].To)
+	c.AssertEquals(t, 8443, rules[2].Ports[0].Port.IntValue())
+	c.AssertEquals(t, "10.0.0.1/32", rules[2].To[0].IPBlock.CIDR)
+
+	spec = &ar.ApicurioRegistrySpec{
+		Configuration: ar.ApicurioRegistrySpecConfiguration{
+			Persistence: ar.PersistenceKafkasql,
+			Kafkasql: ar.ApicurioRegistrySpecConfigurationKafkasql{
+				BootstrapServers: "kafka-0.kafka:9092, kafka-1.kafka:9092,kafka-2.kafka:9093,invalid",
+			},
+		},
+	}
+	rules = NewConfigEgressRules(spec)
+	// Duplicate rules are removed
+	c.AssertEquals(t, 3, len(rules))
+	c.AssertEquals(t, 9092, rules[1].Ports[0].Port.IntValue())
+	c.AssertEquals(t, 9093, rules[2].Ports[0].Port.IntValue())
+}
@@ -0,0 +1,86 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	loop_impl "github.com/Apicurio/apicurio-registry-operator/controllers/loop/impl"
+	services2 "github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	corev1 "k8s.io/api/core/v1"
+	"sort"
+	"testing"
+)
+
+func TestRegistryConfigCF(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	services := services2.NewLoopServicesMock(ctx)
+	loop := loop_impl.NewControlLoopImpl(ctx, services)
+	loop.AddControlFunction(NewRegistryRulesCF(ctx, services))
+	loop.AddControlFunction(NewRegistryLimitsCF(ctx, services))
+	loop.AddControlFunction(NewEnvCF(ctx))
+
+	setSpec := func(configuration ar.ApicurioRegistrySpecConfiguration) {
+		ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(ctx.GetAppName(), &ar.ApicurioRegistry{
+			Spec: ar.ApicurioRegistrySpec{
+				Configuration: configuration,
+			},
+		}))
+		loop.Run()
+		ctx.Finalize()
+	}
+
+	setSpec(ar.ApicurioRegistrySpecConfiguration{
+		Registry: ar.ApicurioRegistrySpecConfigurationRegistry{
+			Rules: ar.ApicurioRegistrySpecConfigurationRegistryRules{
+				Validity: ar.ValidityRuleFull,
+			},
+			Limits: ar.ApicurioRegistrySpecConfigurationRegistryLimits{
+				MaxArtifacts: 100,
+			},
+		},
+	})
+	c.AssertEquals(t, []corev1.EnvVar{
+		{Name: ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACTS, Value: "100"},
+		{Name: ENV_REGISTRY_RULES_GLOBAL_VALIDITY, Value: "FULL"},
+	}, sortedByName(ctx.GetEnvCache().GetSorted()))
+
+	// Overridden in the spec
+	setSpec(ar.ApicurioRegistrySpecConfiguration{
+		Registry: ar.ApicurioRegistrySpecConfigurationRegistry{
+			Rules: ar.ApicurioRegistrySpecConfigurationRegistryRules{
+				Validity: ar.ValidityRuleFull,
+			},
+			Limits: ar.ApicurioRegistrySpecConfigurationRegistryLimits{
+				MaxArtifacts: 100,
+			},
+		},
+		Env: []corev1.EnvVar{
+			{Name: ENV_REGISTRY_RULES_GLOBAL_VALIDITY, Value: "SYNTAX_ONLY"},
+		},
+	})
+	c.AssertEquals(t, []corev1.EnvVar{
+		{Name: ENV_REGISTRY_LIMITS_CONFIG_MAX_ARTIFACTS, Value: "100"},
+		{Name: ENV_REGISTRY_RULES_GLOBAL_VALIDITY, Value: "SYNTAX_ONLY"},
+	}, sortedByName(ctx.GetEnvCache().GetSorted()))
+
+	// Removing
+	setSpec(ar.ApicurioRegistrySpecConfiguration{
+		Registry: ar.ApicurioRegistrySpecConfigurationRegistry{
+			Rules: ar.ApicurioRegistrySpecConfigurationRegistryRules{
+				Validity: ar.ValidityRuleFull,
+			},
+		},
+	})
+	c.AssertEquals(t, []corev1.EnvVar{
+		{Name: ENV_REGISTRY_RULES_GLOBAL_VALIDITY, Value: "FULL"},
+	}, sortedByName(ctx.GetEnvCache().GetSorted()))
+}
+
+func sortedByName(data []corev1.EnvVar) []corev1.EnvVar {
+	res := append([]corev1.EnvVar{}, data...)
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
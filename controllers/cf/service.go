@@ -0,0 +1,84 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"reflect"
+)
+
+// Returns true if the Service port is managed by HttpsCF
+func isHttpServicePort(port *core.ServicePort) bool {
+	return port.Name == "http" || port.Name == "https"
+}
+
+// Returns true if the NetworkPolicy ingress rule is managed by HttpsCF
+func isHttpIngressRule(rule *networking.NetworkPolicyIngressRule) bool {
+	if len(rule.Ports) != 1 || rule.Ports[0].Port == nil {
+		return false
+	}
+	port := rule.Ports[0].Port.IntValue()
+	return port == HttpPort || port == HttpsPort
+}
+
+// Build the additional Service ports, with the default values set, so they do not appear to be changed after they are read back.
+// Returns the names of the invalid ports, which are skipped.
+func NewServiceAdditionalPorts(spec *ar.ApicurioRegistrySpecDeploymentService) ([]core.ServicePort, []string) {
+	res := make([]core.ServicePort, 0)
+	invalid := make([]string, 0)
+	names := map[string]bool{"http": true, "https": true}
+	for _, port := range spec.Ports {
+		if port.Name == "" || names[port.Name] || port.Port == HttpPort || port.Port == HttpsPort {
+			invalid = append(invalid, port.Name)
+			continue
+		}
+		names[port.Name] = true
+		servicePort := core.ServicePort{
+			Name:       port.Name,
+			Protocol:   port.Protocol,
+			Port:       port.Port,
+			TargetPort: intstr.FromInt(int(port.Port)),
+		}
+		if servicePort.Protocol == "" {
+			servicePort.Protocol = core.ProtocolTCP
+		}
+		if port.TargetPort != nil {
+			servicePort.TargetPort = *port.TargetPort
+		}
+		if spec.Type == core.ServiceTypeNodePort || spec.Type == core.ServiceTypeLoadBalancer {
+			servicePort.NodePort = port.NodePort
+		}
+		res = append(res, servicePort)
+	}
+	return res, invalid
+}
+
+// Returns true if the existing Service port matches the target. The node port is allocated by Kubernetes if it is not set.
+func isServicePortEqual(existing *core.ServicePort, target *core.ServicePort) bool {
+	return existing.Name == target.Name &&
+		existing.Protocol == target.Protocol &&
+		existing.Port == target.Port &&
+		reflect.DeepEqual(existing.TargetPort, target.TargetPort) &&
+		(target.NodePort == 0 || existing.NodePort == target.NodePort)
+}
+
+// Build one ingress rule for each additional port, so the traffic to the target ports is allowed
+func NewServiceIngressRules(ports []core.ServicePort, from []networking.NetworkPolicyPeer) []networking.NetworkPolicyIngressRule {
+	res := make([]networking.NetworkPolicyIngressRule, 0, len(ports))
+	for i := range ports {
+		protocol := ports[i].Protocol
+		targetPort := ports[i].TargetPort
+		rule := networking.NetworkPolicyIngressRule{
+			From: from,
+			Ports: []networking.NetworkPolicyPort{
+				{Protocol: &protocol, Port: &targetPort},
+			},
+		}
+		// The HTTP and HTTPS ports are already allowed
+		if !isHttpIngressRule(&rule) {
+			res = append(res, rule)
+		}
+	}
+	return res
+}
@@ -0,0 +1,52 @@
+package cf
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"testing"
+)
+
+func TestNewServiceAdditionalPorts(t *testing.T) {
+	management := intstr.FromString("management")
+	http := intstr.FromInt(HttpPort)
+	spec := &ar.ApicurioRegistrySpecDeploymentService{
+		Type: core.ServiceTypeNodePort,
+		Ports: []ar.ApicurioRegistrySpecDeploymentServicePort{
+			{Name: "metrics", Port: 9000, NodePort: 30900},
+			{Name: "management", Port: 9001, TargetPort: &management, Protocol: core.ProtocolUDP},
+			{Name: "alternative", Port: 80, TargetPort: &http},
+			{Name: "http", Port: 8081},
+			{Name: "metrics", Port: 9002},
+			{Name: "other", Port: HttpsPort},
+		},
+	}
+	ports, invalid := NewServiceAdditionalPorts(spec)
+	c.AssertEquals(t, []string{"http", "metrics", "other"}, invalid)
+	c.AssertEquals(t, 3, len(ports))
+	c.AssertEquals(t, core.ProtocolTCP, ports[0].Protocol)
+	c.AssertEquals(t, intstr.FromInt(9000), ports[0].TargetPort)
+	c.AssertEquals(t, int32(30900), ports[0].NodePort)
+	c.AssertEquals(t, management, ports[1].TargetPort)
+	c.AssertEquals(t, core.ProtocolUDP, ports[1].Protocol)
+
+	// The node port is allocated by Kubernetes
+	existing := ports[1]
+	existing.NodePort = 31000
+	c.AssertEquals(t, true, isServicePortEqual(&existing, &ports[1]))
+	existing = ports[0]
+	existing.NodePort = 31000
+	c.AssertEquals(t, false, isServicePortEqual(&existing, &ports[0]))
+
+	// The HTTP port is already allowed by the NetworkPolicy
+	rules := NewServiceIngressRules(ports, nil)
+	c.AssertEquals(t, 2, len(rules))
+	c.AssertEquals(t, 9000, rules[0].Ports[0].Port.IntValue())
+	c.AssertEquals(t, management, *rules[1].Ports[0].Port)
+
+	// Node ports are not used for the ClusterIP type
+	spec.Type = ""
+	ports, _ = NewServiceAdditionalPorts(spec)
+	c.AssertEquals(t, int32(0), ports[0].NodePort)
+}
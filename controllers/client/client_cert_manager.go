@@ -0,0 +1,63 @@
+package client
+
+import (
+	ctx "context"
+	"errors"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// cert-manager resources are accessed using the dynamic client,
+// so we do not depend on the cert-manager API module.
+
+const CERT_MANAGER_API_GROUP_VERSION = "cert-manager.io/v1"
+
+var CertManagerCertificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// =====
+
+type CertManagerClient struct {
+	log    *zap.Logger
+	client dynamic.Interface
+	scheme *runtime.Scheme
+}
+
+func NewCertManagerClient(log *zap.Logger, scheme *runtime.Scheme, config *rest.Config) *CertManagerClient {
+	return &CertManagerClient{
+		log:    log,
+		client: dynamic.NewForConfigOrDie(config),
+		scheme: scheme,
+	}
+}
+
+// ===
+// Certificate
+
+func (this *CertManagerClient) CreateCertificate(owner meta.Object, namespace common.Namespace, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if owner == nil {
+		return nil, errors.New("Could not find ApicurioRegistry. Retrying.")
+	}
+	if err := controllerutil.SetControllerReference(owner, obj, this.scheme); err != nil {
+		return nil, err
+	}
+	return this.client.Resource(CertManagerCertificateGVR).Namespace(namespace.Str()).Create(ctx.TODO(), obj, meta.CreateOptions{})
+}
+
+func (this *CertManagerClient) GetCertificate(namespace common.Namespace, name common.Name) (*unstructured.Unstructured, error) {
+	return this.client.Resource(CertManagerCertificateGVR).Namespace(namespace.Str()).Get(ctx.TODO(), name.Str(), meta.GetOptions{})
+}
+
+func (this *CertManagerClient) UpdateCertificate(namespace common.Namespace, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return this.client.Resource(CertManagerCertificateGVR).Namespace(namespace.Str()).Update(ctx.TODO(), obj, meta.UpdateOptions{})
+}
+
+func (this *CertManagerClient) DeleteCertificate(obj *unstructured.Unstructured) error {
+	return this.client.Resource(CertManagerCertificateGVR).Namespace(obj.GetNamespace()).Delete(ctx.TODO(), obj.GetName(), meta.DeleteOptions{})
+}
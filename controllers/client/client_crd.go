@@ -3,7 +3,7 @@ package client
 import (
 	ctx "context"
 	"encoding/json"
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -26,7 +26,11 @@ type CRDClient struct {
 
 func NewCRDClient(log *zap.Logger, scheme *runtime.Scheme, config *rest.Config) *CRDClient {
 
-	scheme.AddKnownTypes(ar.GroupVersion, &ar.ApicurioRegistry{}, &ar.ApicurioRegistryList{})
+	scheme.AddKnownTypes(ar.GroupVersion, &ar.ApicurioRegistry{}, &ar.ApicurioRegistryList{},
+		&ar.ApicurioRegistryGlobalRule{}, &ar.ApicurioRegistryGlobalRuleList{},
+		&ar.ApicurioRegistryGroup{}, &ar.ApicurioRegistryGroupList{},
+		&ar.ApicurioRegistryRoleMapping{}, &ar.ApicurioRegistryRoleMappingList{},
+		&ar.ApicurioRegistryArtifact{}, &ar.ApicurioRegistryArtifactList{})
 	meta.AddToGroupVersion(scheme, ar.GroupVersion)
 
 	config2 := rest.CopyConfig(config)
@@ -123,3 +127,120 @@ func (this *CRDClient) PatchApicurioRegistryStatus(namespace common.Namespace, n
 
 	return &result.Status, err
 }
+
+// ===
+// Apicurio Registry content resources
+
+// Returns nil if the resource is not found
+func (this *CRDClient) GetApicurioRegistryGlobalRule(namespace common.Namespace, name common.Name) (*ar.ApicurioRegistryGlobalRule, error) {
+	result := &ar.ApicurioRegistryGlobalRule{}
+	if err := this.get("apicurioregistryglobalrules", namespace, name, result); err != nil || result.Name == "" {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *CRDClient) UpdateApicurioRegistryGlobalRule(namespace common.Namespace, value *ar.ApicurioRegistryGlobalRule) (*ar.ApicurioRegistryGlobalRule, error) {
+	result := &ar.ApicurioRegistryGlobalRule{}
+	err := this.update("apicurioregistryglobalrules", "", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+func (this *CRDClient) UpdateApicurioRegistryGlobalRuleStatus(namespace common.Namespace, value *ar.ApicurioRegistryGlobalRule) (*ar.ApicurioRegistryGlobalRule, error) {
+	result := &ar.ApicurioRegistryGlobalRule{}
+	err := this.update("apicurioregistryglobalrules", "status", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+// Returns nil if the resource is not found
+func (this *CRDClient) GetApicurioRegistryGroup(namespace common.Namespace, name common.Name) (*ar.ApicurioRegistryGroup, error) {
+	result := &ar.ApicurioRegistryGroup{}
+	if err := this.get("apicurioregistrygroups", namespace, name, result); err != nil || result.Name == "" {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *CRDClient) UpdateApicurioRegistryGroup(namespace common.Namespace, value *ar.ApicurioRegistryGroup) (*ar.ApicurioRegistryGroup, error) {
+	result := &ar.ApicurioRegistryGroup{}
+	err := this.update("apicurioregistrygroups", "", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+func (this *CRDClient) UpdateApicurioRegistryGroupStatus(namespace common.Namespace, value *ar.ApicurioRegistryGroup) (*ar.ApicurioRegistryGroup, error) {
+	result := &ar.ApicurioRegistryGroup{}
+	err := this.update("apicurioregistrygroups", "status", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+// Returns nil if the resource is not found
+func (this *CRDClient) GetApicurioRegistryRoleMapping(namespace common.Namespace, name common.Name) (*ar.ApicurioRegistryRoleMapping, error) {
+	result := &ar.ApicurioRegistryRoleMapping{}
+	if err := this.get("apicurioregistryrolemappings", namespace, name, result); err != nil || result.Name == "" {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *CRDClient) UpdateApicurioRegistryRoleMapping(namespace common.Namespace, value *ar.ApicurioRegistryRoleMapping) (*ar.ApicurioRegistryRoleMapping, error) {
+	result := &ar.ApicurioRegistryRoleMapping{}
+	err := this.update("apicurioregistryrolemappings", "", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+func (this *CRDClient) UpdateApicurioRegistryRoleMappingStatus(namespace common.Namespace, value *ar.ApicurioRegistryRoleMapping) (*ar.ApicurioRegistryRoleMapping, error) {
+	result := &ar.ApicurioRegistryRoleMapping{}
+	err := this.update("apicurioregistryrolemappings", "status", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+// Returns nil if the resource is not found
+func (this *CRDClient) GetApicurioRegistryArtifact(namespace common.Namespace, name common.Name) (*ar.ApicurioRegistryArtifact, error) {
+	result := &ar.ApicurioRegistryArtifact{}
+	if err := this.get("apicurioregistryartifacts", namespace, name, result); err != nil || result.Name == "" {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *CRDClient) UpdateApicurioRegistryArtifact(namespace common.Namespace, value *ar.ApicurioRegistryArtifact) (*ar.ApicurioRegistryArtifact, error) {
+	result := &ar.ApicurioRegistryArtifact{}
+	err := this.update("apicurioregistryartifacts", "", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+func (this *CRDClient) UpdateApicurioRegistryArtifactStatus(namespace common.Namespace, value *ar.ApicurioRegistryArtifact) (*ar.ApicurioRegistryArtifact, error) {
+	result := &ar.ApicurioRegistryArtifact{}
+	err := this.update("apicurioregistryartifacts", "status", namespace, common.Name(value.Name), value, result)
+	return result, err
+}
+
+// Leaves the result empty if the resource is not found
+func (this *CRDClient) get(resource string, namespace common.Namespace, name common.Name, result runtime.Object) error {
+	err := this.client.
+		Get().
+		Resource(resource).
+		Namespace(namespace.Str()).
+		Name(name.Str()).
+		Do(ctx.TODO()).
+		Into(result)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (this *CRDClient) update(resource string, subResource string, namespace common.Namespace, name common.Name, value runtime.Object, result runtime.Object) error {
+	request := this.client.
+		Put().
+		Resource(resource).
+		Namespace(namespace.Str()).
+		Name(name.Str())
+	if subResource != "" {
+		request = request.SubResource(subResource)
+	}
+	return request.
+		Body(value).
+		Do(ctx.TODO()).
+		Into(result)
+}
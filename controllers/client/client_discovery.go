@@ -43,6 +43,19 @@ func (this *DiscoveryClient) IsMonitoringInstalled() (bool, error) {
 	return this.resourceExists("monitoring.coreos.com/v1", "ServiceMonitor")
 }
 
+func (this *DiscoveryClient) IsStrimziInstalled() (bool, error) {
+	for _, kind := range []string{"Kafka", "KafkaUser", "KafkaTopic"} {
+		if exists, err := this.resourceExists(STRIMZI_API_GROUP_VERSION, kind); err != nil || !exists {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (this *DiscoveryClient) IsCertManagerInstalled() (bool, error) {
+	return this.resourceExists(CERT_MANAGER_API_GROUP_VERSION, "Certificate")
+}
+
 // Get information about the given API group.
 // Returns an error if the API Group does not exist or the info could not be determined.
 func (this *DiscoveryClient) GetVersionInfoForAPIGroup(apiGroup string) (*APIGroupInfo, error) {
@@ -14,26 +14,40 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	typed_core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // =====
 
 type KubeClient struct {
-	log    *zap.Logger
-	client kubernetes.Interface
-	scheme *runtime.Scheme
+	log      *zap.Logger
+	client   kubernetes.Interface
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
 }
 
 func NewKubeClient(log *zap.Logger, scheme *runtime.Scheme, config *rest.Config) *KubeClient {
+	client := kubernetes.NewForConfigOrDie(config)
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typed_core.EventSinkImpl{Interface: client.CoreV1().Events("")})
 	return &KubeClient{
-		client: kubernetes.NewForConfigOrDie(config),
-		log:    log,
-		scheme: scheme,
+		client:   client,
+		log:      log,
+		scheme:   scheme,
+		recorder: broadcaster.NewRecorder(scheme, core.EventSource{Component: "apicurio-registry-operator"}),
 	}
 }
 
+// ===
+// Event
+
+func (this *KubeClient) RecordEvent(object runtime.Object, eventType string, reason string, message string) {
+	this.recorder.Event(object, eventType, reason, message)
+}
+
 // ===
 // Deployment
 
@@ -75,6 +89,14 @@ func (this *KubeClient) DeleteDeployment(value *apps.Deployment) error {
 	return this.client.AppsV1().Deployments(value.Namespace).Delete(ctx.TODO(), value.Name, meta.DeleteOptions{})
 }
 
+// ===
+// ReplicaSet
+
+func (this *KubeClient) GetReplicaSets(namespace common.Namespace, options meta.ListOptions) (*apps.ReplicaSetList, error) {
+	return this.client.AppsV1().ReplicaSets(namespace.Str()).
+		List(ctx.TODO(), options)
+}
+
 // ===
 // Service
 
@@ -290,6 +312,36 @@ func (this *KubeClient) GetPod(namespace common.Namespace, name common.Name) (*c
 		Get(ctx.TODO(), name.Str(), meta.GetOptions{})
 }
 
+// ===
+// ConfigMap
+
+func (this *KubeClient) CreateConfigMap(owner meta.Object, namespace common.Namespace, value *core.ConfigMap) (*core.ConfigMap, error) {
+	if owner == nil {
+		return nil, errors.New("Could not find ApicurioRegistry. Retrying.")
+	}
+	if err := controllerutil.SetControllerReference(owner, value, this.scheme); err != nil {
+		return nil, err
+	}
+	res, err := this.client.CoreV1().ConfigMaps(namespace.Str()).Create(ctx.TODO(), value, meta.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (this *KubeClient) GetConfigMap(namespace common.Namespace, name common.Name, options *meta.GetOptions) (*core.ConfigMap, error) {
+	return this.client.CoreV1().ConfigMaps(namespace.Str()).
+		Get(ctx.TODO(), name.Str(), *options)
+}
+
+func (this *KubeClient) UpdateConfigMap(namespace common.Namespace, value *core.ConfigMap) (*core.ConfigMap, error) {
+	return this.client.CoreV1().ConfigMaps(namespace.Str()).
+		Update(ctx.TODO(), value, meta.UpdateOptions{})
+}
+
+// ===
+// Secret
+
 func (this *KubeClient) CreateSecret(owner meta.Object, namespace common.Namespace, value *core.Secret) (*core.Secret, error) {
 	if owner == nil {
 		return nil, errors.New("Could not find ApicurioRegistry. Retrying.")
@@ -309,6 +361,11 @@ func (this *KubeClient) GetSecret(namespace common.Namespace, name common.Name,
 		Get(ctx.TODO(), name.Str(), *options)
 }
 
+func (this *KubeClient) UpdateSecret(namespace common.Namespace, value *core.Secret) (*core.Secret, error) {
+	return this.client.CoreV1().Secrets(namespace.Str()).
+		Update(ctx.TODO(), value, meta.UpdateOptions{})
+}
+
 func (this *KubeClient) DeleteSecret(value *core.Secret, options *meta.DeleteOptions) error {
 	return this.client.CoreV1().Secrets(value.Namespace).
 		Delete(ctx.TODO(), value.Name, *options)
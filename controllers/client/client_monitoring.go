@@ -18,7 +18,7 @@ import (
 type MonitoringClient struct {
 	//ctx             context.LoopContext
 	log    *zap.Logger
-	client *monclientv1.MonitoringV1Client
+	client monclientv1.MonitoringV1Interface
 	scheme *runtime.Scheme
 
 	//discoveryClient *discovery.DiscoveryClient
@@ -18,8 +18,8 @@ import (
 
 type OCPClient struct {
 	log            *zap.Logger
-	ocpAppsClient  *ocp_apps_client.AppsV1Client
-	ocpRouteClient *ocp_route_client.RouteV1Client
+	ocpAppsClient  ocp_apps_client.AppsV1Interface
+	ocpRouteClient ocp_route_client.RouteV1Interface
 	scheme         *runtime.Scheme
 }
 
@@ -88,3 +88,24 @@ func (this *OCPClient) GetRoutes(namespace common.Namespace, options *meta.ListO
 	return this.ocpRouteClient.Routes(namespace.Str()).
 		List(ctx.TODO(), *options)
 }
+
+func (this *OCPClient) CreateRoute(owner meta.Object, namespace common.Namespace, value *ocp_route.Route) (*ocp_route.Route, error) {
+	if owner == nil {
+		return nil, errors.New("Could not find ApicurioRegistry. Retrying.")
+	}
+	if err := controllerutil.SetControllerReference(owner, value, this.scheme); err != nil {
+		return nil, err
+	}
+	return this.ocpRouteClient.Routes(namespace.Str()).
+		Create(ctx.TODO(), value, meta.CreateOptions{})
+}
+
+func (this *OCPClient) UpdateRoute(namespace common.Namespace, value *ocp_route.Route) (*ocp_route.Route, error) {
+	return this.ocpRouteClient.Routes(namespace.Str()).
+		Update(ctx.TODO(), value, meta.UpdateOptions{})
+}
+
+func (this *OCPClient) DeleteRoute(value *ocp_route.Route) error {
+	return this.ocpRouteClient.Routes(value.Namespace).
+		Delete(ctx.TODO(), value.Name, meta.DeleteOptions{})
+}
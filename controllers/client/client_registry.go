@@ -0,0 +1,324 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// =====
+
+const REGISTRY_API_PATH = "/apis/registry/v2"
+
+// Name of the group that contains the artifacts without a group
+const REGISTRY_DEFAULT_GROUP = "default"
+
+// Error returned by the Apicurio Registry REST API
+type RegistryApiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (this *RegistryApiError) Error() string {
+	return fmt.Sprintf("Apicurio Registry REST API request has failed with status %d: %s", this.StatusCode, this.Message)
+}
+
+func IsRegistryApiNotFound(err error) bool {
+	apiError, ok := err.(*RegistryApiError)
+	return ok && apiError.StatusCode == http.StatusNotFound
+}
+
+type RegistryRule struct {
+	Type   string `json:"type"`
+	Config string `json:"config"`
+}
+
+type RegistryRoleMapping struct {
+	PrincipalId   string `json:"principalId"`
+	Role          string `json:"role"`
+	PrincipalName string `json:"principalName,omitempty"`
+}
+
+type RegistryArtifactMetaData struct {
+	GroupId  string `json:"groupId"`
+	Id       string `json:"id"`
+	Version  string `json:"version"`
+	GlobalId int64  `json:"globalId"`
+	State    string `json:"state"`
+}
+
+// Client for the Apicurio Registry REST API of a single Apicurio Registry instance
+type RegistryClient struct {
+	log        *zap.Logger
+	httpClient *http.Client
+	baseUrl    string
+	token      string
+}
+
+// The token is optional, and is sent as a bearer token if it is not empty
+func NewRegistryClient(log *zap.Logger, httpClient *http.Client, baseUrl string, token string) *RegistryClient {
+	return &RegistryClient{
+		log:        log,
+		httpClient: httpClient,
+		baseUrl:    baseUrl + REGISTRY_API_PATH,
+		token:      token,
+	}
+}
+
+// ===
+// Global rules
+
+func (this *RegistryClient) GetGlobalRule(ruleType string) (*RegistryRule, error) {
+	result := &RegistryRule{}
+	if err := this.request(http.MethodGet, "/admin/rules/"+url.PathEscape(ruleType), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *RegistryClient) CreateGlobalRule(value *RegistryRule) error {
+	return this.request(http.MethodPost, "/admin/rules", value, nil)
+}
+
+func (this *RegistryClient) UpdateGlobalRule(value *RegistryRule) error {
+	return this.request(http.MethodPut, "/admin/rules/"+url.PathEscape(value.Type), value, nil)
+}
+
+func (this *RegistryClient) DeleteGlobalRule(ruleType string) error {
+	return this.request(http.MethodDelete, "/admin/rules/"+url.PathEscape(ruleType), nil, nil)
+}
+
+// ===
+// Artifacts
+
+// Returns the IDs of all artifacts in the group
+func (this *RegistryClient) GetArtifactIds(groupId string) ([]string, error) {
+	const limit = 100
+	result := make([]string, 0)
+	for offset := 0; ; offset += limit {
+		page := &struct {
+			Artifacts []struct {
+				Id string `json:"id"`
+			} `json:"artifacts"`
+			Count int `json:"count"`
+		}{}
+		path := "/groups/" + url.PathEscape(groupId) + "/artifacts?limit=" + strconv.Itoa(limit) + "&offset=" + strconv.Itoa(offset)
+		if err := this.request(http.MethodGet, path, nil, page); err != nil {
+			return nil, err
+		}
+		for _, artifact := range page.Artifacts {
+			result = append(result, artifact.Id)
+		}
+		if len(page.Artifacts) < limit || len(result) >= page.Count {
+			return result, nil
+		}
+	}
+}
+
+// Creates the artifact, or applies the content to the existing artifact as configured by the ifExists parameter,
+// for example RETURN_OR_UPDATE. The artifact type and version are optional.
+func (this *RegistryClient) CreateArtifact(groupId string, artifactId string, artifactType string, version string,
+	ifExists string, contentType string, content []byte) (*RegistryArtifactMetaData, error) {
+	headers := map[string]string{
+		"X-Registry-ArtifactId": artifactId,
+	}
+	if artifactType != "" {
+		headers["X-Registry-ArtifactType"] = artifactType
+	}
+	if version != "" {
+		headers["X-Registry-Version"] = version
+	}
+	result := &RegistryArtifactMetaData{}
+	path := "/groups/" + url.PathEscape(groupId) + "/artifacts?ifExists=" + url.QueryEscape(ifExists)
+	if err := this.send(http.MethodPost, path, headers, contentType, content, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *RegistryClient) DeleteArtifact(groupId string, artifactId string) error {
+	return this.request(http.MethodDelete, artifactPath(groupId, artifactId), nil, nil)
+}
+
+// Returns the versions of the artifact
+func (this *RegistryClient) GetArtifactVersions(groupId string, artifactId string) ([]string, error) {
+	const limit = 100
+	result := make([]string, 0)
+	for offset := 0; ; offset += limit {
+		page := &struct {
+			Versions []struct {
+				Version string `json:"version"`
+			} `json:"versions"`
+			Count int `json:"count"`
+		}{}
+		path := artifactPath(groupId, artifactId) + "/versions?limit=" + strconv.Itoa(limit) + "&offset=" + strconv.Itoa(offset)
+		if err := this.request(http.MethodGet, path, nil, page); err != nil {
+			return nil, err
+		}
+		for _, version := range page.Versions {
+			result = append(result, version.Version)
+		}
+		if len(page.Versions) < limit || len(result) >= page.Count {
+			return result, nil
+		}
+	}
+}
+
+// Changes the state of the artifact version, for example to DISABLED
+func (this *RegistryClient) UpdateArtifactVersionState(groupId string, artifactId string, version string, state string) error {
+	return this.request(http.MethodPut, artifactPath(groupId, artifactId)+"/versions/"+url.PathEscape(version)+"/state", &struct {
+		State string `json:"state"`
+	}{state}, nil)
+}
+
+// ===
+// Artifact rules
+
+func (this *RegistryClient) GetArtifactRule(groupId string, artifactId string, ruleType string) (*RegistryRule, error) {
+	result := &RegistryRule{}
+	if err := this.request(http.MethodGet, artifactPath(groupId, artifactId)+"/rules/"+url.PathEscape(ruleType), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *RegistryClient) CreateArtifactRule(groupId string, artifactId string, value *RegistryRule) error {
+	return this.request(http.MethodPost, artifactPath(groupId, artifactId)+"/rules", value, nil)
+}
+
+func (this *RegistryClient) UpdateArtifactRule(groupId string, artifactId string, value *RegistryRule) error {
+	return this.request(http.MethodPut, artifactPath(groupId, artifactId)+"/rules/"+url.PathEscape(value.Type), value, nil)
+}
+
+func (this *RegistryClient) DeleteArtifactRule(groupId string, artifactId string, ruleType string) error {
+	return this.request(http.MethodDelete, artifactPath(groupId, artifactId)+"/rules/"+url.PathEscape(ruleType), nil, nil)
+}
+
+func artifactPath(groupId string, artifactId string) string {
+	return "/groups/" + url.PathEscape(groupId) + "/artifacts/" + url.PathEscape(artifactId)
+}
+
+// ===
+// Role mappings
+
+func (this *RegistryClient) GetRoleMapping(principalId string) (*RegistryRoleMapping, error) {
+	result := &RegistryRoleMapping{}
+	if err := this.request(http.MethodGet, "/admin/roleMappings/"+url.PathEscape(principalId), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *RegistryClient) CreateRoleMapping(value *RegistryRoleMapping) error {
+	return this.request(http.MethodPost, "/admin/roleMappings", value, nil)
+}
+
+func (this *RegistryClient) UpdateRoleMapping(value *RegistryRoleMapping) error {
+	return this.request(http.MethodPut, "/admin/roleMappings/"+url.PathEscape(value.PrincipalId), &struct {
+		Role string `json:"role"`
+	}{value.Role}, nil)
+}
+
+func (this *RegistryClient) DeleteRoleMapping(principalId string) error {
+	return this.request(http.MethodDelete, "/admin/roleMappings/"+url.PathEscape(principalId), nil, nil)
+}
+
+// ===
+
+// Sends the request body and decodes the response body as JSON, if they are not nil
+func (this *RegistryClient) request(method string, path string, body interface{}, result interface{}) error {
+	var data []byte = nil
+	contentType := ""
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+		contentType = "application/json"
+	}
+	return this.send(method, path, nil, contentType, data, result)
+}
+
+// Sends the raw request body with the additional headers, and decodes the response body as JSON, if it is not nil
+func (this *RegistryClient) send(method string, path string, headers map[string]string, contentType string, body []byte, result interface{}) error {
+	var reader io.Reader = nil
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, this.baseUrl+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if this.token != "" {
+		req.Header.Set("Authorization", "Bearer "+this.token)
+	}
+	this.log.Sugar().Debugw("Apicurio Registry REST API request", "method", method, "path", path)
+	res, err := this.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		apiError := &RegistryApiError{StatusCode: res.StatusCode}
+		// The error response contains the message, but fall back to the raw body
+		errorBody := &struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(data, errorBody) == nil && errorBody.Message != "" {
+			apiError.Message = errorBody.Message
+		} else {
+			apiError.Message = string(data)
+		}
+		return apiError
+	}
+	if result != nil && len(data) > 0 {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
+
+// ===
+// Authentication
+
+// Requests an access token from the OpenID Connect token endpoint, using the client credentials grant
+func RequestClientCredentialsToken(httpClient *http.Client, tokenUrl string, clientId string, clientSecret string) (string, error) {
+	res, err := httpClient.PostForm(tokenUrl, url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request has failed with status %d: %s", res.StatusCode, string(data))
+	}
+	token := &struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
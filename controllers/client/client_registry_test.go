@@ -0,0 +1,205 @@
+package client
+
+import (
+	"encoding/json"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type recordedRequest struct {
+	method  string
+	uri     string
+	headers http.Header
+	body    string
+}
+
+// Records the requests, and responds with the status and body returned by the handler
+func newRegistryServerMock(t *testing.T, handler func(req *recordedRequest) (int, string)) (*httptest.Server, *[]*recordedRequest) {
+	requests := make([]*recordedRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		common.AssertEquals(t, nil, err)
+		req := &recordedRequest{
+			method:  r.Method,
+			uri:     r.URL.RequestURI(),
+			headers: r.Header,
+			body:    string(body),
+		}
+		requests = append(requests, req)
+		status, res := handler(req)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(res))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestRegistryClientGlobalRules(t *testing.T) {
+	server, requests := newRegistryServerMock(t, func(req *recordedRequest) (int, string) {
+		if req.method == http.MethodGet {
+			return http.StatusOK, `{"type":"VALIDITY","config":"FULL"}`
+		}
+		return http.StatusNoContent, ""
+	})
+	registryClient := NewRegistryClient(zap.NewNop(), server.Client(), server.URL, "token")
+
+	rule, err := registryClient.GetGlobalRule("VALIDITY")
+	common.AssertEquals(t, nil, err)
+	common.AssertEquals(t, &RegistryRule{Type: "VALIDITY", Config: "FULL"}, rule)
+	common.AssertEquals(t, nil, registryClient.CreateGlobalRule(&RegistryRule{Type: "COMPATIBILITY", Config: "BACKWARD"}))
+	common.AssertEquals(t, nil, registryClient.UpdateGlobalRule(&RegistryRule{Type: "COMPATIBILITY", Config: "FULL"}))
+	common.AssertEquals(t, nil, registryClient.DeleteGlobalRule("COMPATIBILITY"))
+
+	common.AssertEquals(t, 4, len(*requests))
+	expected := []recordedRequest{
+		{method: http.MethodGet, uri: "/apis/registry/v2/admin/rules/VALIDITY", body: ""},
+		{method: http.MethodPost, uri: "/apis/registry/v2/admin/rules", body: `{"type":"COMPATIBILITY","config":"BACKWARD"}`},
+		{method: http.MethodPut, uri: "/apis/registry/v2/admin/rules/COMPATIBILITY", body: `{"type":"COMPATIBILITY","config":"FULL"}`},
+		{method: http.MethodDelete, uri: "/apis/registry/v2/admin/rules/COMPATIBILITY", body: ""},
+	}
+	for i, req := range *requests {
+		common.AssertEquals(t, expected[i].method, req.method)
+		common.AssertEquals(t, expected[i].uri, req.uri)
+		common.AssertEquals(t, expected[i].body, req.body)
+		common.AssertEquals(t, "Bearer token", req.headers.Get("Authorization"))
+		common.AssertEquals(t, "application/json", req.headers.Get("Accept"))
+	}
+	common.AssertEquals(t, "application/json", (*requests)[1].headers.Get("Content-Type"))
+	common.AssertEquals(t, "", (*requests)[3].headers.Get("Content-Type"))
+}
+
+func TestRegistryClientWithoutToken(t *testing.T) {
+	server, requests := newRegistryServerMock(t, func(req *recordedRequest) (int, string) {
+		return http.StatusNoContent, ""
+	})
+	registryClient := NewRegistryClient(zap.NewNop(), server.Client(), server.URL, "")
+
+	common.AssertEquals(t, nil, registryClient.DeleteRoleMapping("user@example.com"))
+	common.AssertEquals(t, 1, len(*requests))
+	common.AssertEquals(t, "/apis/registry/v2/admin/roleMappings/user@example.com", (*requests)[0].uri)
+	_, exists := (*requests)[0].headers["Authorization"]
+	common.AssertEquals(t, false, exists)
+}
+
+func TestRegistryClientRoleMappings(t *testing.T) {
+	server, requests := newRegistryServerMock(t, func(req *recordedRequest) (int, string) {
+		return http.StatusNoContent, ""
+	})
+	registryClient := NewRegistryClient(zap.NewNop(), server.Client(), server.URL, "")
+
+	common.AssertEquals(t, nil, registryClient.UpdateRoleMapping(&RegistryRoleMapping{PrincipalId: "a/b", Role: "READ_ONLY"}))
+	common.AssertEquals(t, http.MethodPut, (*requests)[0].method)
+	// The path segments are escaped
+	common.AssertEquals(t, "/apis/registry/v2/admin/roleMappings/a%2Fb", (*requests)[0].uri)
+	// Only the role can be updated
+	common.AssertEquals(t, `{"role":"READ_ONLY"}`, (*requests)[0].body)
+}
+
+func TestRegistryClientCreateArtifact(t *testing.T) {
+	server, requests := newRegistryServerMock(t, func(req *recordedRequest) (int, string) {
+		return http.StatusOK, `{"groupId":"group","id":"artifact","version":"2","globalId":5,"state":"ENABLED"}`
+	})
+	registryClient := NewRegistryClient(zap.NewNop(), server.Client(), server.URL, "")
+
+	metaData, err := registryClient.CreateArtifact("group", "artifact", "AVRO", "2", "RETURN_OR_UPDATE",
+		"application/json", []byte(`{"type":"string"}`))
+	common.AssertEquals(t, nil, err)
+	common.AssertEquals(t, &RegistryArtifactMetaData{GroupId: "group", Id: "artifact", Version: "2", GlobalId: 5, State: "ENABLED"}, metaData)
+	req := (*requests)[0]
+	common.AssertEquals(t, http.MethodPost, req.method)
+	common.AssertEquals(t, "/apis/registry/v2/groups/group/artifacts?ifExists=RETURN_OR_UPDATE", req.uri)
+	common.AssertEquals(t, "artifact", req.headers.Get("X-Registry-ArtifactId"))
+	common.AssertEquals(t, "AVRO", req.headers.Get("X-Registry-ArtifactType"))
+	common.AssertEquals(t, "2", req.headers.Get("X-Registry-Version"))
+	common.AssertEquals(t, "application/json", req.headers.Get("Content-Type"))
+	common.AssertEquals(t, `{"type":"string"}`, req.body)
+
+	// The optional headers are not sent
+	_, err = registryClient.CreateArtifact("group", "artifact", "", "", "FAIL", "application/json", []byte(`{}`))
+	common.AssertEquals(t, nil, err)
+	_, exists := (*requests)[1].headers["X-Registry-ArtifactType"]
+	common.AssertEquals(t, false, exists)
+	_, exists = (*requests)[1].headers["X-Registry-Version"]
+	common.AssertEquals(t, false, exists)
+}
+
+func TestRegistryClientPagination(t *testing.T) {
+	// 150 versions are returned in two pages
+	server, requests := newRegistryServerMock(t, func(req *recordedRequest) (int, string) {
+		offset := 0
+		if req.uri == "/apis/registry/v2/groups/group/artifacts/artifact/versions?limit=100&offset=100" {
+			offset = 100
+		}
+		versions := make([]map[string]string, 0)
+		for i := offset; i < 150 && i < offset+100; i++ {
+			versions = append(versions, map[string]string{"version": strconv.Itoa(i + 1)})
+		}
+		data, _ := json.Marshal(map[string]interface{}{"versions": versions, "count": 150})
+		return http.StatusOK, string(data)
+	})
+	registryClient := NewRegistryClient(zap.NewNop(), server.Client(), server.URL, "")
+
+	versions, err := registryClient.GetArtifactVersions("group", "artifact")
+	common.AssertEquals(t, nil, err)
+	common.AssertEquals(t, 150, len(versions))
+	common.AssertEquals(t, "1", versions[0])
+	common.AssertEquals(t, "150", versions[149])
+	common.AssertEquals(t, 2, len(*requests))
+	common.AssertEquals(t, "/apis/registry/v2/groups/group/artifacts/artifact/versions?limit=100&offset=0", (*requests)[0].uri)
+}
+
+func TestRegistryClientErrors(t *testing.T) {
+	status := http.StatusNotFound
+	body := `{"error_code":404,"message":"No rule named 'VALIDITY' was found."}`
+	server, _ := newRegistryServerMock(t, func(req *recordedRequest) (int, string) {
+		return status, body
+	})
+	registryClient := NewRegistryClient(zap.NewNop(), server.Client(), server.URL, "")
+
+	// The message is read from the error response
+	_, err := registryClient.GetGlobalRule("VALIDITY")
+	common.AssertEquals(t, &RegistryApiError{StatusCode: http.StatusNotFound, Message: "No rule named 'VALIDITY' was found."}, err)
+	common.AssertEquals(t, true, IsRegistryApiNotFound(err))
+
+	// The raw body is used if the response is not an error response
+	status = http.StatusInternalServerError
+	body = "Internal Server Error"
+	err = registryClient.DeleteGlobalRule("VALIDITY")
+	common.AssertEquals(t, &RegistryApiError{StatusCode: http.StatusInternalServerError, Message: "Internal Server Error"}, err)
+	common.AssertEquals(t, false, IsRegistryApiNotFound(err))
+
+	// Connection errors are not API errors
+	server.Close()
+	err = registryClient.DeleteGlobalRule("VALIDITY")
+	common.AssertEquals(t, true, err != nil)
+	common.AssertEquals(t, false, IsRegistryApiNotFound(err))
+	_, isApiError := err.(*RegistryApiError)
+	common.AssertEquals(t, false, isApiError)
+}
+
+func TestRequestClientCredentialsToken(t *testing.T) {
+	status := http.StatusOK
+	server, requests := newRegistryServerMock(t, func(req *recordedRequest) (int, string) {
+		if status != http.StatusOK {
+			return status, `{"error":"unauthorized_client"}`
+		}
+		return status, `{"access_token":"token","token_type":"Bearer"}`
+	})
+
+	token, err := RequestClientCredentialsToken(server.Client(), server.URL+"/token", "operator", "secret")
+	common.AssertEquals(t, nil, err)
+	common.AssertEquals(t, "token", token)
+	req := (*requests)[0]
+	common.AssertEquals(t, http.MethodPost, req.method)
+	common.AssertEquals(t, "application/x-www-form-urlencoded", req.headers.Get("Content-Type"))
+	common.AssertEquals(t, "client_id=operator&client_secret=secret&grant_type=client_credentials", req.body)
+
+	status = http.StatusUnauthorized
+	_, err = RequestClientCredentialsToken(server.Client(), server.URL+"/token", "operator", "wrong")
+	common.AssertEquals(t, `token request has failed with status 401: {"error":"unauthorized_client"}`, err.Error())
+}
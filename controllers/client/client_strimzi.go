@@ -0,0 +1,97 @@
+package client
+
+import (
+	ctx "context"
+	"errors"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Strimzi resources are accessed using the dynamic client,
+// so we do not depend on the Strimzi API module.
+
+const STRIMZI_API_GROUP_VERSION = "kafka.strimzi.io/v1beta2"
+
+var StrimziKafkaGVR = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkas"}
+var StrimziKafkaUserGVR = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkausers"}
+var StrimziKafkaTopicGVR = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkatopics"}
+
+// =====
+
+type StrimziClient struct {
+	log    *zap.Logger
+	client dynamic.Interface
+	scheme *runtime.Scheme
+}
+
+func NewStrimziClient(log *zap.Logger, scheme *runtime.Scheme, config *rest.Config) *StrimziClient {
+	return &StrimziClient{
+		log:    log,
+		client: dynamic.NewForConfigOrDie(config),
+		scheme: scheme,
+	}
+}
+
+// ===
+// Kafka
+
+func (this *StrimziClient) GetKafka(namespace common.Namespace, name common.Name) (*unstructured.Unstructured, error) {
+	return this.client.Resource(StrimziKafkaGVR).Namespace(namespace.Str()).Get(ctx.TODO(), name.Str(), meta.GetOptions{})
+}
+
+// ===
+// KafkaUser
+
+func (this *StrimziClient) CreateKafkaUser(owner meta.Object, namespace common.Namespace, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return this.create(StrimziKafkaUserGVR, owner, namespace, obj)
+}
+
+func (this *StrimziClient) GetKafkaUser(namespace common.Namespace, name common.Name) (*unstructured.Unstructured, error) {
+	return this.client.Resource(StrimziKafkaUserGVR).Namespace(namespace.Str()).Get(ctx.TODO(), name.Str(), meta.GetOptions{})
+}
+
+func (this *StrimziClient) UpdateKafkaUser(namespace common.Namespace, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return this.client.Resource(StrimziKafkaUserGVR).Namespace(namespace.Str()).Update(ctx.TODO(), obj, meta.UpdateOptions{})
+}
+
+func (this *StrimziClient) DeleteKafkaUser(obj *unstructured.Unstructured) error {
+	return this.client.Resource(StrimziKafkaUserGVR).Namespace(obj.GetNamespace()).Delete(ctx.TODO(), obj.GetName(), meta.DeleteOptions{})
+}
+
+// ===
+// KafkaTopic
+
+func (this *StrimziClient) CreateKafkaTopic(owner meta.Object, namespace common.Namespace, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return this.create(StrimziKafkaTopicGVR, owner, namespace, obj)
+}
+
+func (this *StrimziClient) GetKafkaTopic(namespace common.Namespace, name common.Name) (*unstructured.Unstructured, error) {
+	return this.client.Resource(StrimziKafkaTopicGVR).Namespace(namespace.Str()).Get(ctx.TODO(), name.Str(), meta.GetOptions{})
+}
+
+func (this *StrimziClient) UpdateKafkaTopic(namespace common.Namespace, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return this.client.Resource(StrimziKafkaTopicGVR).Namespace(namespace.Str()).Update(ctx.TODO(), obj, meta.UpdateOptions{})
+}
+
+func (this *StrimziClient) DeleteKafkaTopic(obj *unstructured.Unstructured) error {
+	return this.client.Resource(StrimziKafkaTopicGVR).Namespace(obj.GetNamespace()).Delete(ctx.TODO(), obj.GetName(), meta.DeleteOptions{})
+}
+
+// ===
+
+func (this *StrimziClient) create(gvr schema.GroupVersionResource, owner meta.Object, namespace common.Namespace, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if owner == nil {
+		return nil, errors.New("Could not find ApicurioRegistry. Retrying.")
+	}
+	if err := controllerutil.SetControllerReference(owner, obj, this.scheme); err != nil {
+		return nil, err
+	}
+	return this.client.Resource(gvr).Namespace(namespace.Str()).Create(ctx.TODO(), obj, meta.CreateOptions{})
+}
@@ -15,6 +15,8 @@ type Clients struct {
 	ocpClient        *OCPClient
 	crdClient        *CRDClient
 	monitoringClient *MonitoringClient
+	strimziClient    *StrimziClient
+	certManager      *CertManagerClient
 	discoveryClient  *DiscoveryClient
 	scheme           *runtime.Scheme
 }
@@ -40,6 +42,10 @@ func NewClients(log *zap.Logger, scheme *runtime.Scheme, config *rest.Config) *C
 
 	this.monitoringClient = NewMonitoringClient(log, scheme, config)
 
+	this.strimziClient = NewStrimziClient(log, scheme, config)
+
+	this.certManager = NewCertManagerClient(log, scheme, config)
+
 	this.discoveryClient = NewDiscoveryClient(log, config)
 
 	return this
@@ -61,6 +67,14 @@ func (this *Clients) Monitoring() *MonitoringClient {
 	return this.monitoringClient
 }
 
+func (this *Clients) Strimzi() *StrimziClient {
+	return this.strimziClient
+}
+
+func (this *Clients) CertManager() *CertManagerClient {
+	return this.certManager
+}
+
 func (this *Clients) Discovery() *DiscoveryClient {
 	return this.discoveryClient
 }
@@ -0,0 +1,62 @@
+package client
+
+import (
+	ocp_apps_fake "github.com/openshift/client-go/apps/clientset/versioned/fake"
+	ocp_route_fake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	monitoring_fake "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/fake"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	kube_fake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+)
+
+// Clients backed by fake clientsets, so the control functions can be tested without a cluster.
+// The Kubernetes client is initialized with the given objects.
+// The CRD client is available after SetCRDClientMock, the discovery client is not available.
+func NewClientsMock(log *zap.Logger, scheme *runtime.Scheme, kubeObjects ...runtime.Object) *Clients {
+	dynamic := dynamic_fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		StrimziKafkaGVR:           "KafkaList",
+		StrimziKafkaUserGVR:       "KafkaUserList",
+		StrimziKafkaTopicGVR:      "KafkaTopicList",
+		CertManagerCertificateGVR: "CertificateList",
+	})
+	return &Clients{
+		log:    log,
+		scheme: scheme,
+		kubeClient: &KubeClient{
+			log:      log,
+			client:   kube_fake.NewSimpleClientset(kubeObjects...),
+			scheme:   scheme,
+			recorder: &record.FakeRecorder{},
+		},
+		ocpClient: &OCPClient{
+			log:            log,
+			ocpAppsClient:  ocp_apps_fake.NewSimpleClientset().AppsV1(),
+			ocpRouteClient: ocp_route_fake.NewSimpleClientset().RouteV1(),
+			scheme:         scheme,
+		},
+		monitoringClient: &MonitoringClient{
+			log:    log,
+			client: monitoring_fake.NewSimpleClientset().MonitoringV1(),
+			scheme: scheme,
+		},
+		strimziClient: &StrimziClient{
+			log:    log,
+			client: dynamic,
+			scheme: scheme,
+		},
+		certManager: &CertManagerClient{
+			log:    log,
+			client: dynamic,
+			scheme: scheme,
+		},
+	}
+}
+
+// Uses a CRD client connected to the given API server, for example an httptest.Server
+func (this *Clients) SetCRDClientMock(config *rest.Config) {
+	this.crdClient = NewCRDClient(this.log, this.scheme, config)
+}
@@ -0,0 +1,326 @@
+package common
+
+import (
+	go_ctx "context"
+	"errors"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	api_meta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	cr_client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Environment variables that provide the default Operator configuration
+const ENV_REGISTRY_IMAGE_MEM = "REGISTRY_IMAGE_MEM"
+const ENV_REGISTRY_IMAGE_KAFKASQL = "REGISTRY_IMAGE_KAFKASQL"
+const ENV_REGISTRY_IMAGE_SQL = "REGISTRY_IMAGE_SQL"
+const ENV_REGISTRY_IMAGE_PULL_POLICY = "REGISTRY_IMAGE_PULL_POLICY"
+const ENV_REGISTRY_VERSION = "REGISTRY_VERSION"
+const ENV_OPERATOR_NAME = "OPERATOR_NAME"
+
+// Name of the ConfigMap with the Operator configuration, in the namespace of the Operator
+const ENV_OPERATOR_CONFIG_MAP = "OPERATOR_CONFIG_MAP"
+const ENV_OPERATOR_NAMESPACE = "POD_NAMESPACE"
+
+const DEFAULT_OPERATOR_CONFIG_MAP = "apicurio-registry-operator-config"
+const DEFAULT_OPERATOR_NAME = "apicurio-registry-operator"
+
+// Keys of the Operator configuration ConfigMap, the values override the environment variables
+const OPERATOR_CONFIG_REGISTRY_IMAGE_MEM = "registry.image.mem"
+const OPERATOR_CONFIG_REGISTRY_IMAGE_KAFKASQL = "registry.image.kafkasql"
+const OPERATOR_CONFIG_REGISTRY_IMAGE_SQL = "registry.image.sql"
+const OPERATOR_CONFIG_REGISTRY_IMAGE_PULL_POLICY = "registry.image.pullPolicy"
+const OPERATOR_CONFIG_REGISTRY_VERSION = "registry.version"
+const OPERATOR_CONFIG_OPERATOR_NAME = "operator.name"
+const OPERATOR_CONFIG_DEFAULT_RESOURCES = "deployment.resources"
+const OPERATOR_CONFIG_DEFAULT_LABELS = "metadata.labels"
+const OPERATOR_CONFIG_DEFAULT_ANNOTATIONS = "metadata.annotations"
+const OPERATOR_CONFIG_DEFAULT_POD_ANNOTATIONS = "deployment.podAnnotations"
+const OPERATOR_CONFIG_REQUEUE_DELAY_SOON = "requeue.delaySoon"
+const OPERATOR_CONFIG_CONTENT_RESYNC_INTERVAL = "content.resyncInterval"
+const OPERATOR_CONFIG_CONTENT_RETRY_DELAY = "content.retryDelay"
+const OPERATOR_CONFIG_FEATURE_MONITORING = "feature.monitoring"
+const OPERATOR_CONFIG_FEATURE_STRIMZI = "feature.strimzi"
+const OPERATOR_CONFIG_FEATURE_CERT_MANAGER = "feature.certManager"
+
+// Operator-wide settings. The values MUST NOT be modified.
+type OperatorConfigValues struct {
+	RegistryImageMem        string
+	RegistryImageKafkasql   string
+	RegistryImageSql        string
+	RegistryImagePullPolicy string
+	// Empty if unknown
+	RegistryVersion string
+	OperatorName    string
+	// Nil if the factory default is used
+	DefaultResources *core.ResourceRequirements
+	// Added to the labels of the managed resources
+	DefaultLabels map[string]string
+	// Added to the annotations of the managed resources
+	DefaultAnnotations map[string]string
+	// Added to the annotations of the Apicurio Registry pods
+	DefaultPodAnnotations map[string]string
+	RequeueDelaySoon      time.Duration
+	ContentResyncInterval time.Duration
+	ContentRetryDelay     time.Duration
+	// Features can be disabled even if they are supported by the cluster
+	FeatureMonitoring  bool
+	FeatureStrimzi     bool
+	FeatureCertManager bool
+}
+
+// Operator configuration, read from a ConfigMap, with the defaults read from the environment variables.
+// The configuration is reloaded when the ConfigMap changes, without restarting the Operator.
+type OperatorConfig struct {
+	log       *zap.SugaredLogger
+	reader    cr_client.Reader
+	namespace Namespace
+	name      Name
+	mutex     sync.RWMutex
+	values    *OperatorConfigValues
+	// Resource version of the ConfigMap that has been loaded, empty if the defaults are used
+	revision string
+}
+
+// If the reader is nil, or the namespace or name is empty, the defaults are used
+func NewOperatorConfig(log *zap.Logger, reader cr_client.Reader, namespace Namespace, name Name) *OperatorConfig {
+	return &OperatorConfig{
+		log:       log.Sugar().Named("operator-config"),
+		reader:    reader,
+		namespace: namespace,
+		name:      name,
+		values:    newDefaultOperatorConfigValues(),
+		revision:  "",
+	}
+}
+
+// Reads the name of the ConfigMap and the namespace of the Operator from the environment variables
+func NewOperatorConfigFromEnv(log *zap.Logger, reader cr_client.Reader) *OperatorConfig {
+	namespace, name := GetOperatorConfigMapFromEnv()
+	return NewOperatorConfig(log, reader, namespace, name)
+}
+
+// Returns an empty namespace if the namespace of the Operator is unknown
+func GetOperatorConfigMapFromEnv() (Namespace, Name) {
+	name := os.Getenv(ENV_OPERATOR_CONFIG_MAP)
+	if name == "" {
+		name = DEFAULT_OPERATOR_CONFIG_MAP
+	}
+	return Namespace(os.Getenv(ENV_OPERATOR_NAMESPACE)), Name(name)
+}
+
+func newDefaultOperatorConfigValues() *OperatorConfigValues {
+	operatorName := os.Getenv(ENV_OPERATOR_NAME)
+	if operatorName == "" {
+		operatorName = DEFAULT_OPERATOR_NAME
+	}
+	return &OperatorConfigValues{
+		RegistryImageMem:        os.Getenv(ENV_REGISTRY_IMAGE_MEM),
+		RegistryImageKafkasql:   os.Getenv(ENV_REGISTRY_IMAGE_KAFKASQL),
+		RegistryImageSql:        os.Getenv(ENV_REGISTRY_IMAGE_SQL),
+		RegistryImagePullPolicy: os.Getenv(ENV_REGISTRY_IMAGE_PULL_POLICY),
+		RegistryVersion:         os.Getenv(ENV_REGISTRY_VERSION),
+		OperatorName:            operatorName,
+		DefaultResources:        nil,
+		DefaultLabels:           map[string]string{},
+		DefaultAnnotations:      map[string]string{},
+		DefaultPodAnnotations:   map[string]string{},
+		RequeueDelaySoon:        5 * time.Second,
+		ContentResyncInterval:   5 * time.Minute,
+		ContentRetryDelay:       30 * time.Second,
+		FeatureMonitoring:       true,
+		FeatureStrimzi:          true,
+		FeatureCertManager:      true,
+	}
+}
+
+// Returns true if the configuration is read from the ConfigMap
+func (this *OperatorConfig) IsEnabled() bool {
+	return this.reader != nil && this.namespace != "" && this.name != ""
+}
+
+func (this *OperatorConfig) GetNamespace() Namespace {
+	return this.namespace
+}
+
+func (this *OperatorConfig) GetName() Name {
+	return this.name
+}
+
+func (this *OperatorConfig) Get() *OperatorConfigValues {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return this.values
+}
+
+// Changes when a different configuration has been loaded
+func (this *OperatorConfig) GetRevision() string {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return this.revision
+}
+
+// Loads the ConfigMap if it has changed. If the ConfigMap is invalid, the previous configuration is kept.
+func (this *OperatorConfig) Refresh() error {
+	if !this.IsEnabled() {
+		return nil
+	}
+	configMap := &core.ConfigMap{}
+	if err := this.reader.Get(go_ctx.TODO(), types.NamespacedName{Namespace: this.namespace.Str(), Name: this.name.Str()}, configMap); err != nil {
+		if !api_errors.IsNotFound(err) {
+			return err
+		}
+		configMap = nil
+	}
+	revision := ""
+	if configMap != nil {
+		revision = configMap.ResourceVersion
+	}
+	if revision == this.GetRevision() {
+		return nil
+	}
+	values := newDefaultOperatorConfigValues()
+	var err error
+	if configMap != nil {
+		if values, err = ParseOperatorConfig(configMap.Data); err != nil {
+			// Do not try again until the ConfigMap changes
+			this.mutex.Lock()
+			defer this.mutex.Unlock()
+			this.revision = revision
+			return errors.New("Operator configuration in ConfigMap " + this.name.Str() + " is invalid, the previous configuration is kept: " + err.Error())
+		}
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.values = values
+	this.revision = revision
+	this.log.Infow("Operator configuration has been loaded", "configMap", this.name, "revision", revision)
+	return nil
+}
+
+// Reconciles the listed resources in all namespaces when the ConfigMap changes
+func (this *OperatorConfig) Watch(controllerBuilder *builder.Builder, list cr_client.ObjectList) {
+	if !this.IsEnabled() {
+		return
+	}
+	controllerBuilder.Watches(&core.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(
+		func(ctx go_ctx.Context, _ cr_client.Object) []reconcile.Request {
+			list := list.DeepCopyObject().(cr_client.ObjectList)
+			if err := this.reader.List(ctx, list); err != nil {
+				this.log.Errorw("could not list the resources to apply the Operator configuration", "error", err)
+				return nil
+			}
+			items, err := api_meta.ExtractList(list)
+			if err != nil {
+				this.log.Errorw("could not list the resources to apply the Operator configuration", "error", err)
+				return nil
+			}
+			requests := make([]reconcile.Request, 0, len(items))
+			for _, item := range items {
+				if object, ok := item.(cr_client.Object); ok {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+						Namespace: object.GetNamespace(),
+						Name:      object.GetName(),
+					}})
+				}
+			}
+			return requests
+		}),
+		builder.WithPredicates(predicate.NewPredicateFuncs(func(object cr_client.Object) bool {
+			return object.GetNamespace() == this.namespace.Str() && object.GetName() == this.name.Str()
+		})),
+	)
+}
+
+// Missing keys keep the default values
+func ParseOperatorConfig(data map[string]string) (*OperatorConfigValues, error) {
+	values := newDefaultOperatorConfigValues()
+	var err error
+	for key, value := range data {
+		switch key {
+		case OPERATOR_CONFIG_REGISTRY_IMAGE_MEM:
+			values.RegistryImageMem = value
+		case OPERATOR_CONFIG_REGISTRY_IMAGE_KAFKASQL:
+			values.RegistryImageKafkasql = value
+		case OPERATOR_CONFIG_REGISTRY_IMAGE_SQL:
+			values.RegistryImageSql = value
+		case OPERATOR_CONFIG_REGISTRY_IMAGE_PULL_POLICY:
+			values.RegistryImagePullPolicy = value
+		case OPERATOR_CONFIG_REGISTRY_VERSION:
+			values.RegistryVersion = value
+		case OPERATOR_CONFIG_OPERATOR_NAME:
+			values.OperatorName = value
+		case OPERATOR_CONFIG_DEFAULT_RESOURCES:
+			values.DefaultResources = &core.ResourceRequirements{}
+			err = yaml.UnmarshalStrict([]byte(value), values.DefaultResources)
+		case OPERATOR_CONFIG_DEFAULT_LABELS:
+			err = yaml.UnmarshalStrict([]byte(value), &values.DefaultLabels)
+		case OPERATOR_CONFIG_DEFAULT_ANNOTATIONS:
+			err = yaml.UnmarshalStrict([]byte(value), &values.DefaultAnnotations)
+		case OPERATOR_CONFIG_DEFAULT_POD_ANNOTATIONS:
+			err = yaml.UnmarshalStrict([]byte(value), &values.DefaultPodAnnotations)
+		case OPERATOR_CONFIG_REQUEUE_DELAY_SOON:
+			// The delay is used in whole seconds
+			values.RequeueDelaySoon, err = parseDuration(value, time.Second)
+		case OPERATOR_CONFIG_CONTENT_RESYNC_INTERVAL:
+			values.ContentResyncInterval, err = parseDuration(value, 0)
+		case OPERATOR_CONFIG_CONTENT_RETRY_DELAY:
+			values.ContentRetryDelay, err = parseDuration(value, 0)
+		case OPERATOR_CONFIG_FEATURE_MONITORING:
+			values.FeatureMonitoring, err = strconv.ParseBool(value)
+		case OPERATOR_CONFIG_FEATURE_STRIMZI:
+			values.FeatureStrimzi, err = strconv.ParseBool(value)
+		case OPERATOR_CONFIG_FEATURE_CERT_MANAGER:
+			values.FeatureCertManager, err = strconv.ParseBool(value)
+		default:
+			err = errors.New("unknown key")
+		}
+		if err != nil {
+			return nil, errors.New("could not parse " + key + ": " + err.Error())
+		}
+	}
+	if values.DefaultLabels == nil {
+		values.DefaultLabels = map[string]string{}
+	}
+	if values.DefaultAnnotations == nil {
+		values.DefaultAnnotations = map[string]string{}
+	}
+	if values.DefaultPodAnnotations == nil {
+		values.DefaultPodAnnotations = map[string]string{}
+	}
+	return values, nil
+}
+
+// Returns the supported features that have not been disabled in the configuration
+func (this *OperatorConfigValues) ApplyToFeatures(detected *SupportedFeatures) *SupportedFeatures {
+	features := *detected
+	features.SupportsMonitoring = features.SupportsMonitoring && this.FeatureMonitoring
+	features.SupportsStrimzi = features.SupportsStrimzi && this.FeatureStrimzi
+	features.SupportsCertManager = features.SupportsCertManager && this.FeatureCertManager
+	return &features
+}
+
+// The duration must be positive, and at least the given minimum
+func parseDuration(value string, minimum time.Duration) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, errors.New("the duration must be positive")
+	}
+	if duration < minimum {
+		return 0, errors.New("the duration must be at least " + minimum.String())
+	}
+	return duration, nil
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"testing"
+	"time"
+)
+
+func TestParseOperatorConfig(t *testing.T) {
+	values, err := ParseOperatorConfig(map[string]string{
+		OPERATOR_CONFIG_REGISTRY_IMAGE_SQL:      "localhost:5000/apicurio-registry-sql:latest",
+		OPERATOR_CONFIG_CONTENT_RESYNC_INTERVAL: "10m",
+		OPERATOR_CONFIG_FEATURE_MONITORING:      "false",
+		OPERATOR_CONFIG_DEFAULT_LABELS:          "team: registry\n",
+		OPERATOR_CONFIG_DEFAULT_RESOURCES:       "limits:\n  memory: 1Gi\n",
+	})
+	AssertEquals(t, nil, err)
+	AssertEquals(t, "localhost:5000/apicurio-registry-sql:latest", values.RegistryImageSql)
+	AssertEquals(t, 10*time.Minute, values.ContentResyncInterval)
+	AssertEquals(t, 30*time.Second, values.ContentRetryDelay)
+	AssertEquals(t, false, values.FeatureMonitoring)
+	AssertEquals(t, true, values.FeatureStrimzi)
+	AssertEquals(t, map[string]string{"team": "registry"}, values.DefaultLabels)
+	AssertEquals(t, map[string]string{}, values.DefaultPodAnnotations)
+	AssertEquals(t, 0, values.DefaultResources.Limits.Memory().Cmp(resource.MustParse("1Gi")))
+
+	features := values.ApplyToFeatures(&SupportedFeatures{SupportsMonitoring: true, SupportsStrimzi: false})
+	AssertEquals(t, false, features.SupportsMonitoring)
+	AssertEquals(t, false, features.SupportsStrimzi)
+
+	_, err = ParseOperatorConfig(map[string]string{"registry.unknown": "value"})
+	AssertEquals(t, true, err != nil)
+	_, err = ParseOperatorConfig(map[string]string{OPERATOR_CONFIG_CONTENT_RETRY_DELAY: "soon"})
+	AssertEquals(t, true, err != nil)
+
+	// The durations must be positive, the requeue delay is used in whole seconds
+	for key, value := range map[string]string{
+		OPERATOR_CONFIG_REQUEUE_DELAY_SOON:      "500ms",
+		OPERATOR_CONFIG_CONTENT_RESYNC_INTERVAL: "-5m",
+		OPERATOR_CONFIG_CONTENT_RETRY_DELAY:     "0s",
+	} {
+		_, err = ParseOperatorConfig(map[string]string{key: value})
+		AssertEquals(t, true, err != nil)
+	}
+	values, err = ParseOperatorConfig(map[string]string{
+		OPERATOR_CONFIG_REQUEUE_DELAY_SOON:  "1s",
+		OPERATOR_CONFIG_CONTENT_RETRY_DELAY: "500ms",
+	})
+	AssertEquals(t, nil, err)
+	AssertEquals(t, time.Second, values.RequeueDelaySoon)
+	AssertEquals(t, 500*time.Millisecond, values.ContentRetryDelay)
+}
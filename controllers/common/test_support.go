@@ -15,6 +15,7 @@ type TestSupport struct {
 type testSupportNamespaced struct {
 	canMakeHTTPRequestToOperand bool
 	operandMetricsReportReady   bool
+	operatorRestart             bool
 	loopTick                    time.Time
 }
 
@@ -34,6 +35,7 @@ func newTestSupportNamespaced() *testSupportNamespaced {
 	return &testSupportNamespaced{
 		canMakeHTTPRequestToOperand: false,
 		operandMetricsReportReady:   false,
+		operatorRestart:             false,
 		loopTick:                    time.Time{},
 	}
 }
@@ -80,6 +82,23 @@ func (this *TestSupport) GetMockOperandMetricsReportReady(namespace string) bool
 	return this.namespaced[namespace].operandMetricsReportReady
 }
 
+// The control loops in the namespace are deleted before the cleanup, as if the Operator has been restarted
+func (this *TestSupport) SetMockOperatorRestart(namespace string, value bool) {
+	this.panicIfNotTesting()
+	if _, e := this.namespaced[namespace]; !e {
+		this.namespaced[namespace] = newTestSupportNamespaced()
+	}
+	this.namespaced[namespace].operatorRestart = value
+}
+
+func (this *TestSupport) GetMockOperatorRestart(namespace string) bool {
+	this.panicIfNotTesting()
+	if _, e := this.namespaced[namespace]; !e {
+		this.namespaced[namespace] = newTestSupportNamespaced()
+	}
+	return this.namespaced[namespace].operatorRestart
+}
+
 func (this *TestSupport) ResetTimer(namespace string) {
 	this.panicIfNotTesting()
 	if _, e := this.namespaced[namespace]; !e {
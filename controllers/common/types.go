@@ -24,4 +24,6 @@ type SupportedFeatures struct {
 	SupportsPDBv1beta1  bool
 	PreferredPDBVersion string
 	SupportsMonitoring  bool
+	SupportsStrimzi     bool
+	SupportsCertManager bool
 }
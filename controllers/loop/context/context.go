@@ -5,6 +5,7 @@ import (
 	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
 	"go.uber.org/zap"
 	"time"
 )
@@ -20,9 +21,11 @@ type LoopContext interface {
 	GetClients() *client.Clients
 	GetResourceCache() resources.ResourceCache
 	GetEnvCache() env.EnvCache
+	GetState() state.LoopState
 	SetAttempts(attempts int)
 	GetAttempts() int
 	GetTestingSupport() *c.TestSupport
 	GetSupportedFeatures() *c.SupportedFeatures
+	GetOperatorConfig() *c.OperatorConfig
 	GetReconcileSequence() int64
 }
@@ -5,6 +5,7 @@ import (
 	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
 	"go.uber.org/zap"
 	"math"
 	"time"
@@ -21,15 +22,18 @@ type loopContext struct {
 	requeueDelay      time.Duration
 	resourceCache     resources.ResourceCache
 	envCache          env.EnvCache
+	state             state.LoopState
 	attempts          int
 	clients           *client.Clients
 	testing           *c.TestSupport
 	features          *c.SupportedFeatures
+	operatorConfig    *c.OperatorConfig
 	reconcileSequence int64
 }
 
 // Create a new context when the operator is deployed, provide mostly static data
-func NewLoopContext(appName c.Name, appNamespace c.Namespace, log *zap.Logger, clients *client.Clients, testing *c.TestSupport, features *c.SupportedFeatures) LoopContext {
+func NewLoopContext(appName c.Name, appNamespace c.Namespace, log *zap.Logger, clients *client.Clients, testing *c.TestSupport, features *c.SupportedFeatures,
+	operatorConfig *c.OperatorConfig) LoopContext {
 	this := &loopContext{
 		appName:           appName,
 		appNamespace:      appNamespace,
@@ -39,10 +43,12 @@ func NewLoopContext(appName c.Name, appNamespace c.Namespace, log *zap.Logger, c
 		testing:           testing,
 		log:               log,
 		features:          features,
+		operatorConfig:    operatorConfig,
 		reconcileSequence: 0,
 	}
 	this.resourceCache = resources.NewResourceCache()
 	this.envCache = env.NewEnvCache(log)
+	this.state = state.NewLoopState(log, clients, appName, appNamespace)
 	return this
 }
 
@@ -63,7 +69,7 @@ func (this *loopContext) SetRequeueNow() {
 }
 
 func (this *loopContext) SetRequeueDelaySoon() {
-	this.SetRequeueDelaySec(5)
+	this.SetRequeueDelaySec(uint(this.operatorConfig.Get().RequeueDelaySoon.Seconds()))
 }
 
 func (this *loopContext) SetRequeueDelaySec(delay uint) {
@@ -99,6 +105,10 @@ func (this *loopContext) GetEnvCache() env.EnvCache {
 	return this.envCache
 }
 
+func (this *loopContext) GetState() state.LoopState {
+	return this.state
+}
+
 func (this *loopContext) SetAttempts(attempts int) {
 	this.attempts = attempts
 }
@@ -115,6 +125,10 @@ func (this *loopContext) GetSupportedFeatures() *c.SupportedFeatures {
 	return this.features
 }
 
+func (this *loopContext) GetOperatorConfig() *c.OperatorConfig {
+	return this.operatorConfig
+}
+
 func (this *loopContext) GetReconcileSequence() int64 {
 	return this.reconcileSequence
 }
@@ -5,6 +5,7 @@ import (
 	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/env"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/state"
 	"go.uber.org/zap"
 	"math"
 	"time"
@@ -18,8 +19,14 @@ type LoopContextMock struct {
 	log               *zap.Logger
 	resourceCache     resources.ResourceCache
 	envCache          env.EnvCache
+	state             state.LoopState
 	attempts          int
+	operatorConfig    *c.OperatorConfig
 	reconcileSequence int64
+	clients           *client.Clients
+	features          *c.SupportedFeatures
+	requeue           bool
+	requeueDelay      time.Duration
 }
 
 func NewLoopContextMock() *LoopContextMock {
@@ -31,9 +38,25 @@ func NewLoopContextMock() *LoopContextMock {
 	res.log = c.GetRootLogger(true)
 	res.resourceCache = resources.NewResourceCache()
 	res.envCache = env.NewEnvCache(res.log)
+	res.operatorConfig = c.NewOperatorConfig(res.log, nil, "", "")
+	res.state = state.NewLoopState(res.log, nil, res.appName, res.appNamespace)
+	res.features = &c.SupportedFeatures{}
 	return res
 }
 
+func (this *LoopContextMock) SetClients(clients *client.Clients) {
+	this.clients = clients
+}
+
+func (this *LoopContextMock) SetSupportedFeatures(features *c.SupportedFeatures) {
+	this.features = features
+}
+
+// Returns the requeue delay requested since the last Finalize
+func (this *LoopContextMock) GetRequeueDelay() (bool, time.Duration) {
+	return this.requeue, this.requeueDelay
+}
+
 func (this *LoopContextMock) GetLog() *zap.Logger {
 	return this.log
 }
@@ -47,37 +70,50 @@ func (this *LoopContextMock) GetAppNamespace() c.Namespace {
 }
 
 func (this *LoopContextMock) SetRequeueNow() {
-	panic("not implemented")
+	this.SetRequeueDelaySec(0)
 }
 
 func (this *LoopContextMock) SetRequeueDelaySoon() {
-	panic("not implemented")
+	this.SetRequeueDelaySec(uint(this.operatorConfig.Get().RequeueDelaySoon.Seconds()))
 }
 
 func (this *LoopContextMock) SetRequeueDelaySec(delay uint) {
-	panic("not implemented")
+	d := time.Duration(delay) * time.Second
+	if this.requeue == false || d < this.requeueDelay {
+		this.requeueDelay = d
+		this.requeue = true
+	}
 }
 
 func (this *LoopContextMock) Finalize() (bool, time.Duration) {
+	defer func() {
+		this.requeue = false
+		this.requeueDelay = 0
+	}()
 	if this.reconcileSequence == math.MaxInt64 {
 		panic("int64 counter overflow. Restarting to reset.") // This will never happen
 	}
 	this.reconcileSequence += 1
-	return false, 0
+	return this.requeue, this.requeueDelay
 }
 
 func (this *LoopContextMock) GetResourceCache() resources.ResourceCache {
 	return this.resourceCache
 }
 
+// The clients are not available unless they are set, see client.NewClientsMock
 func (this *LoopContextMock) GetClients() *client.Clients {
-	panic("not implemented")
+	return this.clients
 }
 
 func (this *LoopContextMock) GetEnvCache() env.EnvCache {
 	return this.envCache
 }
 
+func (this *LoopContextMock) GetState() state.LoopState {
+	return this.state
+}
+
 func (this *LoopContextMock) SetAttempts(attempts int) {
 	this.attempts = attempts
 }
@@ -91,7 +127,11 @@ func (this *LoopContextMock) GetTestingSupport() *c.TestSupport {
 }
 
 func (this *LoopContextMock) GetSupportedFeatures() *c.SupportedFeatures {
-	panic("not implemented")
+	return this.features
+}
+
+func (this *LoopContextMock) GetOperatorConfig() *c.OperatorConfig {
+	return this.operatorConfig
 }
 
 func (this *LoopContextMock) GetReconcileSequence() int64 {
@@ -24,3 +24,11 @@ type ControlFunction interface {
 	// Warning: This function may be executed multiple times even if it returned *true*.
 	Cleanup() bool
 }
+
+// Implemented by the control functions that delete a resource during the cleanup.
+// The resource cache is empty if the control loop has not been executed yet, e.g. after the Operator restart,
+// so the resources have to be found before the cleanup starts, to keep the deletion order.
+type CleanupDiscovery interface {
+	// Find the resource managed by this CF, and add it to the resource cache if it is not cached already
+	DiscoverForCleanup()
+}
@@ -11,5 +11,6 @@ type ControlLoop interface {
 
 	Run()
 
-	Cleanup()
+	// Returns true if all control functions have finished the cleanup
+	Cleanup() bool
 }
@@ -73,11 +73,16 @@ func (this *controlLoopImpl) Run() {
 	this.services.AfterRun()
 }
 
-func (this *controlLoopImpl) Cleanup() {
+func (this *controlLoopImpl) Cleanup() bool {
 	// Perform resource cleanup
 
 	this.ctx.GetLog().Sugar().Infow("ApicurioRegistry CR has been removed. Starting resource cleanup.",
 		"app", this.ctx.GetAppName())
+	for _, cf := range this.GetControlFunctions() {
+		if discovery, ok := cf.(loop.CleanupDiscovery); ok {
+			discovery.DiscoverForCleanup()
+		}
+	}
 	maxAttempts := len(this.GetControlFunctions()) * 2
 	attempt := 0
 	for ; attempt < maxAttempts; attempt++ {
@@ -98,9 +103,11 @@ func (this *controlLoopImpl) Cleanup() {
 	}
 	if attempt == maxAttempts {
 		this.ctx.GetLog().Sugar().
-			Warnw("Cleanup did not finish successfully. You may need to delete some of the resources manually.",
+			Warnw("Cleanup did not finish successfully. The cleanup will be retried.",
 				"app", this.ctx.GetAppName())
+		return false
 	}
+	return true
 }
 
 func (this *controlLoopImpl) GetContext() context.LoopContext {
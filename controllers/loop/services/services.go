@@ -13,6 +13,9 @@ type LoopServices interface {
 	GetPatchers() *patcher.Patchers
 	GetKubeFactory() *factory.KubeFactory
 	GetMonitoringFactory() *factory.MonitoringFactory
+	GetStrimziFactory() *factory.StrimziFactory
+	GetCertificateFactory() *factory.CertificateFactory
+	GetOCPFactory() *factory.OCPFactory
 	GetConditionManager() conditions.ConditionManager
 	GetStatus() *status.Status
 }
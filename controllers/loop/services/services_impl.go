@@ -1,9 +1,11 @@
 package services
 
 import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/patcher"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status/conditions"
 )
@@ -11,19 +13,29 @@ import (
 var _ LoopServices = &loopServices{}
 
 type loopServices struct {
+	ctx context.LoopContext
+
 	patchers *patcher.Patchers
 
-	kubeFactory       *factory.KubeFactory
-	monitoringFactory *factory.MonitoringFactory
+	kubeFactory        *factory.KubeFactory
+	monitoringFactory  *factory.MonitoringFactory
+	strimziFactory     *factory.StrimziFactory
+	certificateFactory *factory.CertificateFactory
+	ocpFactory         *factory.OCPFactory
 
 	conditionManager conditions.ConditionManager
 	status           *status.Status
 }
 
 func NewLoopServices(ctx context.LoopContext) LoopServices {
-	this := &loopServices{}
+	this := &loopServices{
+		ctx: ctx,
+	}
 	this.kubeFactory = factory.NewKubeFactory(ctx)
 	this.monitoringFactory = factory.NewMonitoringFactory(ctx, this.kubeFactory)
+	this.strimziFactory = factory.NewStrimziFactory(ctx, this.kubeFactory)
+	this.certificateFactory = factory.NewCertificateFactory(ctx, this.kubeFactory)
+	this.ocpFactory = factory.NewOCPFactory(ctx, this.kubeFactory)
 	this.conditionManager = conditions.NewConditionManager(ctx)
 	this.status = status.NewStatus(ctx, this.conditionManager)
 	this.patchers = patcher.NewPatchers(ctx, this.kubeFactory, this.status)
@@ -31,6 +43,7 @@ func NewLoopServices(ctx context.LoopContext) LoopServices {
 }
 
 func (this *loopServices) BeforeRun() {
+	this.ctx.GetState().Load()
 	this.patchers.Reload()
 }
 
@@ -38,6 +51,10 @@ func (this *loopServices) AfterRun() {
 	this.conditionManager.AfterLoop() // TODO Unify nomenclature
 	this.status.ComputeStatus()
 	this.patchers.Execute()
+	// The state is owned by the ApicurioRegistry resource
+	if specEntry, exists := this.ctx.GetResourceCache().Get(resources.RC_KEY_SPEC); exists {
+		this.ctx.GetState().Save(specEntry.GetValue().(*ar.ApicurioRegistry))
+	}
 }
 
 func (this *loopServices) GetPatchers() *patcher.Patchers {
@@ -52,6 +69,18 @@ func (this *loopServices) GetMonitoringFactory() *factory.MonitoringFactory {
 	return this.monitoringFactory
 }
 
+func (this *loopServices) GetStrimziFactory() *factory.StrimziFactory {
+	return this.strimziFactory
+}
+
+func (this *loopServices) GetCertificateFactory() *factory.CertificateFactory {
+	return this.certificateFactory
+}
+
+func (this *loopServices) GetOCPFactory() *factory.OCPFactory {
+	return this.ocpFactory
+}
+
 func (this *loopServices) GetConditionManager() conditions.ConditionManager {
 	return this.conditionManager
 }
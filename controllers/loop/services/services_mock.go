@@ -11,10 +11,25 @@ import (
 var _ LoopServices = &LoopServicesMock{}
 
 type LoopServicesMock struct {
+	kubeFactory        *factory.KubeFactory
+	monitoringFactory  *factory.MonitoringFactory
+	strimziFactory     *factory.StrimziFactory
+	certificateFactory *factory.CertificateFactory
+	ocpFactory         *factory.OCPFactory
+	conditionManager   conditions.ConditionManager
+	status             *status.Status
 }
 
+// The factories and the condition manager do not need the clients, so they are available in the mock
 func NewLoopServicesMock(ctx context.LoopContext) *LoopServicesMock {
 	this := &LoopServicesMock{}
+	this.kubeFactory = factory.NewKubeFactory(ctx)
+	this.monitoringFactory = factory.NewMonitoringFactory(ctx, this.kubeFactory)
+	this.strimziFactory = factory.NewStrimziFactory(ctx, this.kubeFactory)
+	this.certificateFactory = factory.NewCertificateFactory(ctx, this.kubeFactory)
+	this.ocpFactory = factory.NewOCPFactory(ctx, this.kubeFactory)
+	this.conditionManager = conditions.NewConditionManager(ctx)
+	this.status = status.NewStatus(ctx, this.conditionManager)
 	return this
 }
 
@@ -33,17 +48,29 @@ func (this *LoopServicesMock) GetPatchers() *patcher.Patchers {
 }
 
 func (this *LoopServicesMock) GetKubeFactory() *factory.KubeFactory {
-	panic("not implemented")
+	return this.kubeFactory
 }
 
 func (this *LoopServicesMock) GetMonitoringFactory() *factory.MonitoringFactory {
-	panic("not implemented")
+	return this.monitoringFactory
+}
+
+func (this *LoopServicesMock) GetStrimziFactory() *factory.StrimziFactory {
+	return this.strimziFactory
+}
+
+func (this *LoopServicesMock) GetCertificateFactory() *factory.CertificateFactory {
+	return this.certificateFactory
+}
+
+func (this *LoopServicesMock) GetOCPFactory() *factory.OCPFactory {
+	return this.ocpFactory
 }
 
 func (this *LoopServicesMock) GetConditionManager() conditions.ConditionManager {
-	panic("not implemented")
+	return this.conditionManager
 }
 
 func (this *LoopServicesMock) GetStatus() *status.Status {
-	panic("not implemented")
+	return this.status
 }
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	go_ctx "context"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	api_meta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	cr_client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Environment variable with a label selector, e.g. "apicur.io/registry-operator=enabled".
+// If it is set, the Operator watches all namespaces, and manages the resources only in the namespaces that match the selector.
+// Namespaces can be labeled or unlabeled without restarting the Operator.
+const ENV_WATCH_NAMESPACE_SELECTOR = "WATCH_NAMESPACE_SELECTOR"
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Decides whether the Operator manages the resources in a namespace.
+// The resources in a namespace only ever reference other resources in the same namespace,
+// so a namespace that is in scope cannot use the Operator to read resources, such as Secrets, from another namespace.
+type NamespaceScope struct {
+	log      *zap.Logger
+	reader   cr_client.Reader
+	selector labels.Selector
+}
+
+// If the selector is nil, all watched namespaces are in scope, see WATCH_NAMESPACE
+func NewNamespaceScope(rootLog *zap.Logger, reader cr_client.Reader, selector labels.Selector) *NamespaceScope {
+	return &NamespaceScope{
+		log:      rootLog.Named("namespace-scope"),
+		reader:   reader,
+		selector: selector,
+	}
+}
+
+// Returns true if the namespaces are selected by a label selector
+func (this *NamespaceScope) IsSelectorEnabled() bool {
+	return this.selector != nil
+}
+
+func (this *NamespaceScope) Contains(namespace c.Namespace) (bool, error) {
+	if !this.IsSelectorEnabled() {
+		return true, nil
+	}
+	value := &core.Namespace{}
+	if err := this.reader.Get(go_ctx.TODO(), types.NamespacedName{Name: namespace.Str()}, value); err != nil {
+		if api_errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return this.selector.Matches(labels.Set(value.Labels)), nil
+}
+
+// Reconciles the resources of the listed type in a namespace, when the namespace enters or leaves the scope
+func (this *NamespaceScope) Watch(controllerBuilder *builder.Builder, list cr_client.ObjectList) {
+	if !this.IsSelectorEnabled() {
+		return
+	}
+	controllerBuilder.Watches(&core.Namespace{}, handler.EnqueueRequestsFromMapFunc(
+		func(ctx go_ctx.Context, namespace cr_client.Object) []reconcile.Request {
+			return this.getRequests(ctx, list, namespace.GetName())
+		}),
+		builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+			},
+		}),
+	)
+}
+
+func (this *NamespaceScope) getRequests(ctx go_ctx.Context, list cr_client.ObjectList, namespace string) []reconcile.Request {
+	list = list.DeepCopyObject().(cr_client.ObjectList)
+	if err := this.reader.List(ctx, list, cr_client.InNamespace(namespace)); err != nil {
+		this.log.Sugar().Errorw("could not list the resources in the namespace", "namespace", namespace, "error", err)
+		return nil
+	}
+	items, err := api_meta.ExtractList(list)
+	if err != nil {
+		this.log.Sugar().Errorw("could not list the resources in the namespace", "namespace", namespace, "error", err)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(cr_client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: object.GetNamespace(),
+				Name:      object.GetName(),
+			}})
+		}
+	}
+	return requests
+}
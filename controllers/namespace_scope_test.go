@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	go_ctx "context"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/impl"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/services"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+const testNamespaceSelector = "apicur.io/registry-operator=enabled"
+
+// Namespace scope backed by a fake reader that contains the given objects
+func newTestNamespaceScope(t *testing.T, objects ...runtime.Object) *NamespaceScope {
+	scheme := runtime.NewScheme()
+	c.AssertEquals(t, nil, core.AddToScheme(scheme))
+	c.AssertEquals(t, nil, ar.AddToScheme(scheme))
+	selector, err := labels.Parse(testNamespaceSelector)
+	c.AssertEquals(t, nil, err)
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+	return NewNamespaceScope(zap.NewNop(), reader, selector)
+}
+
+func newTestNamespace(name string, namespaceLabels map[string]string) *core.Namespace {
+	return &core.Namespace{ObjectMeta: meta.ObjectMeta{Name: name, Labels: namespaceLabels}}
+}
+
+func TestNamespaceScopeContains(t *testing.T) {
+	this := newTestNamespaceScope(t,
+		newTestNamespace("enabled", map[string]string{"apicur.io/registry-operator": "enabled"}),
+		newTestNamespace("disabled", map[string]string{"apicur.io/registry-operator": "disabled"}),
+		newTestNamespace("unlabeled", nil),
+	)
+	c.AssertEquals(t, true, this.IsSelectorEnabled())
+	for namespace, expected := range map[c.Namespace]bool{
+		"enabled":   true,
+		"disabled":  false,
+		"unlabeled": false,
+		"missing":   false,
+	} {
+		inScope, err := this.Contains(namespace)
+		c.AssertEquals(t, nil, err)
+		c.AssertEquals(t, expected, inScope)
+	}
+
+	// All namespaces are in scope without a selector
+	this = NewNamespaceScope(zap.NewNop(), nil, nil)
+	c.AssertEquals(t, false, this.IsSelectorEnabled())
+	inScope, err := this.Contains("missing")
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, true, inScope)
+}
+
+func TestNamespaceScopeGetRequests(t *testing.T) {
+	this := newTestNamespaceScope(t,
+		&ar.ApicurioRegistry{ObjectMeta: meta.ObjectMeta{Name: "first", Namespace: testNamespace}},
+		&ar.ApicurioRegistry{ObjectMeta: meta.ObjectMeta{Name: "second", Namespace: testNamespace}},
+		&ar.ApicurioRegistry{ObjectMeta: meta.ObjectMeta{Name: "other", Namespace: "other"}},
+	)
+	// Only the resources in the namespace are reconciled
+	requests := this.getRequests(go_ctx.TODO(), &ar.ApicurioRegistryList{}, testNamespace)
+	c.AssertEquals(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "first"}},
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "second"}},
+	}, requests)
+	c.AssertEquals(t, 0, len(this.getRequests(go_ctx.TODO(), &ar.ApicurioRegistryList{}, "missing")))
+}
+
+// The namespace has been unlabeled, while the ApicurioRegistry resource is being deleted
+func TestApicurioRegistryReconcilerRelease(t *testing.T) {
+	registry, _ := newTestRegistry("http")
+	now := meta.Now()
+	registry.Finalizers = []string{CleanupFinalizer}
+	registry.DeletionTimestamp = &now
+	content, _ := newTestRegistryContentSupport(t, registry)
+	key := testNamespace + "/" + testRegistryName
+	ctx := context.NewLoopContextMock()
+	this := &ApicurioRegistryReconciler{
+		log:            content.log,
+		clients:        content.clients,
+		testing:        c.NewTestSupport(content.log, false),
+		loops:          map[string]loop.ControlLoop{key: impl.NewControlLoopImpl(ctx, services.NewLoopServicesMock(ctx))},
+		scope:          newTestNamespaceScope(t, newTestNamespace(testNamespace, nil)),
+		operatorConfig: content.operatorConfig,
+	}
+
+	_, err := this.Reconcile(go_ctx.TODO(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testRegistryName},
+	})
+	c.AssertEquals(t, nil, err)
+	// The loop is deleted, and the resource can be deleted without the cleanup
+	_, exists := this.loops[key]
+	c.AssertEquals(t, false, exists)
+	registry, err = this.clients.CRD().GetApicurioRegistry(testNamespace, testRegistryName)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, 0, len(registry.Finalizers))
+}
+
+func TestRegistryContentOutOfScope(t *testing.T) {
+	registryMock := newArtifactRegistryMock(t)
+	artifact := &ar.ApicurioRegistryArtifact{}
+	artifact.Finalizers = []string{RegistryContentFinalizer}
+	this, _ := newTestArtifactReconciler(t, registryMock, artifact)
+	this.content.scope = newTestNamespaceScope(t, newTestNamespace(testNamespace, nil))
+
+	// The content is not synchronized
+	artifact = reconcileArtifact(t, this)
+	c.AssertEquals(t, 0, len(registryMock.requests))
+	c.AssertEquals(t, []string{RegistryContentFinalizer}, artifact.Finalizers)
+	c.AssertEquals(t, 0, len(artifact.Status.Conditions))
+
+	// The deletion is not blocked, and the content is not removed from Apicurio Registry
+	now := meta.Now()
+	artifact.DeletionTimestamp = &now
+	_, err := this.clients.CRD().UpdateApicurioRegistryArtifact(testNamespace, artifact)
+	c.AssertEquals(t, nil, err)
+	artifact = reconcileArtifact(t, this)
+	c.AssertEquals(t, 0, len(registryMock.requests))
+	c.AssertEquals(t, 0, len(artifact.Finalizers))
+}
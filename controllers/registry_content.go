@@ -0,0 +1,471 @@
+package controllers
+
+import (
+	go_ctx "context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/cf"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"go.uber.org/zap"
+	api_meta "k8s.io/apimachinery/pkg/api/meta"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	cr_client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strings"
+	"time"
+)
+
+// The content resources (ApicurioRegistryGlobalRule, ApicurioRegistryGroup, ApicurioRegistryRoleMapping)
+// are synchronized with Apicurio Registry using its REST API, through the Service managed by ServiceCF.
+
+// Removes the content from Apicurio Registry before the resource is deleted
+const RegistryContentFinalizer = "registry.apicur.io/content-cleanup"
+
+const CONTENT_CONDITION_TYPE_SYNCED = "Synced"
+
+// The OpenShift service CA certificate, used to verify the HTTPS certificate in the `openshift` certificate mode
+const openShiftServiceCaPath = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+
+type ContentConditionReason string
+
+const (
+	CONTENT_CONDITION_REASON_SYNCED                ContentConditionReason = "Synced"
+	CONTENT_CONDITION_REASON_REGISTRY_NOT_FOUND    ContentConditionReason = "RegistryNotFound"
+	CONTENT_CONDITION_REASON_REGISTRY_NOT_READY    ContentConditionReason = "RegistryNotReady"
+	CONTENT_CONDITION_REASON_AUTHENTICATION_FAILED ContentConditionReason = "AuthenticationFailed"
+	CONTENT_CONDITION_REASON_INVALID               ContentConditionReason = "InvalidConfiguration"
+	CONTENT_CONDITION_REASON_REQUEST_FAILED        ContentConditionReason = "RequestFailed"
+	CONTENT_CONDITION_REASON_CLEANUP_FAILED        ContentConditionReason = "CleanupFailed"
+)
+
+// Error with the reason reported in the Synced condition
+type contentError struct {
+	reason  ContentConditionReason
+	message string
+}
+
+func (this *contentError) Error() string {
+	return this.message
+}
+
+func newContentError(reason ContentConditionReason, format string, args ...interface{}) error {
+	return &contentError{
+		reason:  reason,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+func isContentError(err error, reason ContentConditionReason) bool {
+	var res *contentError
+	return errors.As(err, &res) && res.reason == reason
+}
+
+// A content resource, and the functions that manage it
+type registryContentResource struct {
+	object   cr_client.Object
+	registry ar.ApicurioRegistryReference
+	status   *ar.ApicurioRegistryContentStatus
+	// Updates the resource, used to add or remove the finalizer
+	update func() error
+	// Updates the status of the resource
+	updateStatus func() error
+	// Synchronizes the content with Apicurio Registry
+	sync func(registryClient *client.RegistryClient) error
+	// Removes the content from Apicurio Registry
+	cleanup func(registryClient *client.RegistryClient) error
+}
+
+type registryContentSupport struct {
+	log            *zap.Logger
+	clients        *client.Clients
+	scope          *NamespaceScope
+	operatorConfig *c.OperatorConfig
+	// Connects to the Apicurio Registry Service, the default dialer is used if nil
+	dialRegistry func(ctx go_ctx.Context, network string, address string) (net.Conn, error)
+}
+
+func newRegistryContentSupport(log *zap.Logger, clients *client.Clients, scope *NamespaceScope, operatorConfig *c.OperatorConfig) *registryContentSupport {
+	return &registryContentSupport{
+		log:            log,
+		clients:        clients,
+		scope:          scope,
+		operatorConfig: operatorConfig,
+	}
+}
+
+// Reconcile changes to the resource spec and its deletion, status updates do not change metadata.Generation
+func newContentPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+				!reflect.DeepEqual(e.ObjectOld.GetDeletionTimestamp(), e.ObjectNew.GetDeletionTimestamp())
+		},
+	}
+}
+
+func (this *registryContentSupport) reconcile(resource *registryContentResource) (reconcile.Result, error) {
+	log := this.log.Sugar().With("namespace", resource.object.GetNamespace(), "name", resource.object.GetName())
+	namespace := c.Namespace(resource.object.GetNamespace())
+
+	if err := this.operatorConfig.Refresh(); err != nil {
+		log.Errorw("could not reload the Operator configuration", "error", err)
+	}
+	// Correct the drift periodically, changes made in Apicurio Registry do not trigger a reconciliation
+	contentResyncDelay := this.operatorConfig.Get().ContentResyncInterval
+	contentRetryDelay := this.operatorConfig.Get().ContentRetryDelay
+
+	// The namespace is not in scope of the Operator, remove the finalizer so the deletion is not blocked
+	inScope, err := this.scope.Contains(namespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !inScope {
+		if resource.object.GetDeletionTimestamp() != nil && controllerutil.RemoveFinalizer(resource.object, RegistryContentFinalizer) {
+			return reconcile.Result{}, resource.update()
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// Resource is being deleted
+	if resource.object.GetDeletionTimestamp() != nil {
+		if !controllerutil.ContainsFinalizer(resource.object, RegistryContentFinalizer) {
+			return reconcile.Result{}, nil
+		}
+		registryClient, err := this.connect(namespace, resource.registry)
+		if err == nil {
+			err = resource.cleanup(registryClient)
+		} else if isContentError(err, CONTENT_CONDITION_REASON_REGISTRY_NOT_FOUND) {
+			// Nothing to clean up
+			err = nil
+		}
+		if err != nil {
+			log.Warnw("could not remove the content from Apicurio Registry", "error", err)
+			this.setSyncedCondition(resource, newContentError(CONTENT_CONDITION_REASON_CLEANUP_FAILED, "%s", err.Error()))
+			if err := resource.updateStatus(); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: contentRetryDelay}, nil
+		}
+		controllerutil.RemoveFinalizer(resource.object, RegistryContentFinalizer)
+		return reconcile.Result{}, resource.update()
+	}
+
+	if controllerutil.AddFinalizer(resource.object, RegistryContentFinalizer) {
+		if err := resource.update(); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Synchronize, the status can be changed by the sync function as well
+	previous := resource.object.DeepCopyObject()
+	registryClient, err := this.connect(namespace, resource.registry)
+	if err == nil {
+		err = resource.sync(registryClient)
+	}
+	if err != nil {
+		log.Warnw("could not synchronize the content with Apicurio Registry", "error", err)
+	}
+	this.setSyncedCondition(resource, err)
+	if !reflect.DeepEqual(previous, resource.object) {
+		if err := resource.updateStatus(); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
+		return reconcile.Result{RequeueAfter: contentRetryDelay}, nil
+	}
+	return reconcile.Result{RequeueAfter: contentResyncDelay}, nil
+}
+
+func (this *registryContentSupport) setSyncedCondition(resource *registryContentResource, err error) {
+	condition := meta.Condition{
+		Type:               CONTENT_CONDITION_TYPE_SYNCED,
+		Status:             meta.ConditionTrue,
+		Reason:             string(CONTENT_CONDITION_REASON_SYNCED),
+		Message:            "Resource has been synchronized with Apicurio Registry",
+		ObservedGeneration: resource.object.GetGeneration(),
+	}
+	if err != nil {
+		condition.Status = meta.ConditionFalse
+		condition.Reason = string(CONTENT_CONDITION_REASON_REQUEST_FAILED)
+		var contentErr *contentError
+		if errors.As(err, &contentErr) {
+			condition.Reason = string(contentErr.reason)
+		}
+		condition.Message = err.Error()
+	} else {
+		resource.status.ObservedGeneration = resource.object.GetGeneration()
+	}
+	api_meta.SetStatusCondition(&resource.status.Conditions, condition)
+}
+
+// Returns a client for the REST API of the Apicurio Registry instance
+func (this *registryContentSupport) connect(namespace c.Namespace, reference ar.ApicurioRegistryReference) (*client.RegistryClient, error) {
+	registry, err := this.clients.CRD().GetApicurioRegistry(namespace, c.Name(reference.Name))
+	if err != nil {
+		return nil, err
+	}
+	if registry == nil {
+		return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_FOUND,
+			"ApicurioRegistry %s not found", reference.Name)
+	}
+
+	// Find the Service
+	serviceName := ""
+	for _, resource := range registry.Status.ManagedResources {
+		if resource.Kind == "Service" {
+			serviceName = resource.Name
+		}
+	}
+	if serviceName == "" {
+		return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+			"Service of ApicurioRegistry %s has not been created yet", reference.Name)
+	}
+	service, err := this.clients.Kube().GetService(namespace, c.Name(serviceName))
+	if err != nil {
+		return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+			"could not get Service %s: %s", serviceName, err.Error())
+	}
+	scheme := "http"
+	port := int32(cf.HttpPort)
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Name == "https" {
+			scheme = "https"
+			port = servicePort.Port
+			break
+		}
+		if servicePort.Name == "http" {
+			port = servicePort.Port
+		}
+	}
+	serverName := fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	baseUrl := fmt.Sprintf("%s://%s:%d", scheme, serverName, port)
+
+	httpClient, err := this.newRegistryHttpClient(registry, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authenticate
+	token := ""
+	if cf.IsAuthenticationEnabled(&registry.Spec) {
+		keycloakHttpClient, err := this.newKeycloakHttpClient(registry)
+		if err != nil {
+			return nil, err
+		}
+		token, err = this.requestToken(registry, keycloakHttpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client.NewRegistryClient(this.log, httpClient, baseUrl, token), nil
+}
+
+// Returns a client that verifies the HTTPS certificate of Apicurio Registry,
+// and presents the Operator client certificate if client authentication is enabled
+func (this *registryContentSupport) newRegistryHttpClient(registry *ar.ApicurioRegistry, serverName string) (*http.Client, error) {
+	rootCAs, err := this.getRegistryRootCAs(registry)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    rootCAs,
+		ServerName: serverName,
+	}
+	// Present the Operator client certificate, see HttpsClientAuthCF
+	clientAuth := registry.Spec.Configuration.Security.Https.ClientAuth
+	if clientAuth != "" && clientAuth != ar.HttpsClientAuthNone {
+		secretName := factory.GetHttpsOperatorClientSecretName(registry.Name)
+		secret, err := this.clients.Kube().GetSecret(c.Namespace(registry.Namespace), c.Name(secretName), &meta.GetOptions{})
+		if err != nil {
+			return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+				"could not get the Operator client certificate Secret %s: %s", secretName, err.Error())
+		}
+		certificate, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+		if err != nil {
+			return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+				"could not load the Operator client certificate: %s", err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return newHttpClient(tlsConfig, this.dialRegistry), nil
+}
+
+// Returns the CA certificates used to verify the HTTPS certificate of Apicurio Registry, see HttpsCertificateCF
+func (this *registryContentSupport) getRegistryRootCAs(registry *ar.ApicurioRegistry) (*x509.CertPool, error) {
+	https := registry.Spec.Configuration.Security.Https
+	switch https.Certificate.Mode {
+	case ar.HttpsCertificateModeSelfSigned, ar.HttpsCertificateModeCertManager:
+		// Both the Operator and cert-manager store the CA certificate in the Secret
+		secretName := factory.GetHttpsSecretName(registry.Name)
+		caCertificate, err := this.getCaCertificate(c.Namespace(registry.Namespace), secretName)
+		if err != nil {
+			return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+				"could not get the HTTPS certificate Secret %s: %s", secretName, err.Error())
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCertificate) {
+			return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+				"HTTPS certificate Secret %s does not contain a valid CA certificate under the ca.crt key", secretName)
+		}
+		return rootCAs, nil
+	case ar.HttpsCertificateModeOpenShift:
+		// The service CA certificate is mounted into the Operator Pod together with the service account token
+		caCertificate, err := os.ReadFile(openShiftServiceCaPath)
+		if err != nil {
+			return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+				"could not read the OpenShift service CA certificate: %s", err.Error())
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCertificate) {
+			return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+				"%s does not contain a valid CA certificate", openShiftServiceCaPath)
+		}
+		return rootCAs, nil
+	}
+	// The provided certificate can be issued by a public CA, use the CA certificate from the Secret if present
+	rootCAs := getSystemCertPool()
+	if https.SecretName != "" {
+		caCertificate, err := this.getCaCertificate(c.Namespace(registry.Namespace), https.SecretName)
+		if err != nil {
+			return nil, newContentError(CONTENT_CONDITION_REASON_REGISTRY_NOT_READY,
+				"could not get the HTTPS certificate Secret %s: %s", https.SecretName, err.Error())
+		}
+		rootCAs.AppendCertsFromPEM(caCertificate)
+	}
+	return rootCAs, nil
+}
+
+// Returns a client that verifies the Keycloak server certificate.
+// The Operator client certificate is not presented, it is only trusted by Apicurio Registry.
+func (this *registryContentSupport) newKeycloakHttpClient(registry *ar.ApicurioRegistry) (*http.Client, error) {
+	rootCAs := getSystemCertPool()
+	if secretName := registry.Spec.Configuration.Security.Keycloak.OperatorClient.TruststoreSecretName; secretName != "" {
+		caCertificate, err := this.getCaCertificate(c.Namespace(registry.Namespace), secretName)
+		if err != nil {
+			return nil, newContentError(CONTENT_CONDITION_REASON_AUTHENTICATION_FAILED,
+				"could not get the Keycloak truststore Secret %s: %s", secretName, err.Error())
+		}
+		if !rootCAs.AppendCertsFromPEM(caCertificate) {
+			return nil, newContentError(CONTENT_CONDITION_REASON_AUTHENTICATION_FAILED,
+				"Keycloak truststore Secret %s does not contain a valid CA certificate under the ca.crt key", secretName)
+		}
+	}
+	return newHttpClient(&tls.Config{
+		RootCAs: rootCAs,
+	}, nil), nil
+}
+
+// Returns the PEM encoded CA certificates under the ca.crt key of the Secret
+func (this *registryContentSupport) getCaCertificate(namespace c.Namespace, secretName string) ([]byte, error) {
+	secret, err := this.clients.Kube().GetSecret(namespace, c.Name(secretName), &meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data["ca.crt"], nil
+}
+
+func getSystemCertPool() *x509.CertPool {
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		return x509.NewCertPool()
+	}
+	return rootCAs
+}
+
+func newHttpClient(tlsConfig *tls.Config, dialContext func(ctx go_ctx.Context, network string, address string) (net.Conn, error)) *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+			DialContext:     dialContext,
+		},
+	}
+}
+
+// Requests a token for the Keycloak client configured in spec.configuration.security.keycloak.operatorClient
+func (this *registryContentSupport) requestToken(registry *ar.ApicurioRegistry, httpClient *http.Client) (string, error) {
+	keycloak := registry.Spec.Configuration.Security.Keycloak
+	if keycloak.Url == "" || keycloak.Realm == "" || keycloak.OperatorClient.ClientId == "" {
+		return "", newContentError(CONTENT_CONDITION_REASON_AUTHENTICATION_FAILED,
+			"authentication is enabled in ApicurioRegistry %s, but spec.configuration.security.keycloak.operatorClient is not configured", registry.Name)
+	}
+	clientSecret := keycloak.OperatorClient.ClientSecret.Value
+	if secretKeyRef := keycloak.OperatorClient.ClientSecret.SecretKeyRef; secretKeyRef != nil {
+		secret, err := this.clients.Kube().GetSecret(c.Namespace(registry.Namespace), c.Name(secretKeyRef.Name), &meta.GetOptions{})
+		if err != nil {
+			return "", newContentError(CONTENT_CONDITION_REASON_AUTHENTICATION_FAILED,
+				"could not get the Operator client secret: %s", err.Error())
+		}
+		clientSecret = string(secret.Data[secretKeyRef.Key])
+	}
+	tokenUrl := strings.TrimSuffix(keycloak.Url, "/") + "/realms/" + keycloak.Realm + "/protocol/openid-connect/token"
+	token, err := client.RequestClientCredentialsToken(httpClient, tokenUrl, keycloak.OperatorClient.ClientId, clientSecret)
+	if err != nil {
+		return "", newContentError(CONTENT_CONDITION_REASON_AUTHENTICATION_FAILED,
+			"could not request a token for the Operator client: %s", err.Error())
+	}
+	return token, nil
+}
+
+// Synchronizes the rule, returns true if it has been changed
+func syncRule(get func() (*client.RegistryRule, error), create func() error, update func() error, target *client.RegistryRule) (bool, error) {
+	existing, err := get()
+	if client.IsRegistryApiNotFound(err) {
+		return true, create()
+	}
+	if err != nil {
+		return false, err
+	}
+	if existing.Config != target.Config {
+		return true, update()
+	}
+	return false, nil
+}
+
+func validateRule(rule ar.ApicurioRegistryRule) error {
+	valid := false
+	switch rule.Type {
+	case ar.RuleTypeValidity:
+		switch ar.ApicurioRegistryValidityRule(rule.Config) {
+		case ar.ValidityRuleNone, ar.ValidityRuleSyntaxOnly, ar.ValidityRuleFull:
+			valid = true
+		}
+	case ar.RuleTypeCompatibility:
+		switch ar.ApicurioRegistryCompatibilityRule(rule.Config) {
+		case ar.CompatibilityRuleNone, ar.CompatibilityRuleBackward, ar.CompatibilityRuleBackwardTransitive,
+			ar.CompatibilityRuleForward, ar.CompatibilityRuleForwardTransitive,
+			ar.CompatibilityRuleFull, ar.CompatibilityRuleFullTransitive:
+			valid = true
+		}
+	case ar.RuleTypeIntegrity:
+		// The integrity rule can be a combination of the values
+		valid = true
+		for _, value := range strings.Split(rule.Config, ",") {
+			switch ar.ApicurioRegistryIntegrityRule(strings.TrimSpace(value)) {
+			case ar.IntegrityRuleNone, ar.IntegrityRuleRefsExist, ar.IntegrityRuleAllRefsMapped,
+				ar.IntegrityRuleNoDuplicates, ar.IntegrityRuleFull:
+			default:
+				valid = false
+			}
+		}
+	}
+	if !valid {
+		return newContentError(CONTENT_CONDITION_REASON_INVALID,
+			"invalid configuration %s of the %s rule", rule.Config, rule.Type)
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+package controllers
+
+import (
+	go_ctx "context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/factory"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	api_meta "k8s.io/apimachinery/pkg/api/meta"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testNamespace = "test"
+const testRegistryName = "registry"
+const testServiceName = "registry-service"
+
+// Serves the resources of the CRD client, keyed by the resource and name, e.g. "apicurioregistries/registry".
+// The updated resources are stored, including the status.
+type apiServerMock struct {
+	t       *testing.T
+	objects map[string]interface{}
+}
+
+func newApiServerMock(t *testing.T) (*apiServerMock, *rest.Config) {
+	this := &apiServerMock{
+		t:       t,
+		objects: make(map[string]interface{}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(this.handle))
+	t.Cleanup(server.Close)
+	return this, &rest.Config{Host: server.URL}
+}
+
+func (this *apiServerMock) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	key := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/apis/"+ar.GroupVersion.String()+"/namespaces/"+testNamespace+"/"), "/status")
+	if r.Method == http.MethodPut {
+		object := make(map[string]interface{})
+		c.AssertEquals(this.t, nil, json.NewDecoder(r.Body).Decode(&object))
+		this.objects[key] = object
+	}
+	object, exists := this.objects[key]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+		return
+	}
+	data, err := json.Marshal(object)
+	c.AssertEquals(this.t, nil, err)
+	_, _ = w.Write(data)
+}
+
+// The object must have the API version and kind set
+func (this *apiServerMock) set(resource string, name string, object runtime.Object) {
+	this.objects[resource+"/"+name] = object
+}
+
+func (this *apiServerMock) remove(resource string, name string) {
+	delete(this.objects, resource+"/"+name)
+}
+
+func newTestRegistry(servicePortName string) (*ar.ApicurioRegistry, *core.Service) {
+	registry := &ar.ApicurioRegistry{
+		TypeMeta:   meta.TypeMeta{APIVersion: ar.GroupVersion.String(), Kind: "ApicurioRegistry"},
+		ObjectMeta: meta.ObjectMeta{Name: testRegistryName, Namespace: testNamespace},
+		Status: ar.ApicurioRegistryStatus{
+			ManagedResources: []ar.ApicurioRegistryStatusManagedResource{
+				{Kind: "Service", Name: testServiceName, Namespace: testNamespace},
+			},
+		},
+	}
+	service := &core.Service{
+		ObjectMeta: meta.ObjectMeta{Name: testServiceName, Namespace: testNamespace},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{Name: servicePortName, Port: 8443}},
+		},
+	}
+	return registry, service
+}
+
+func newTestRegistryContentSupport(t *testing.T, registry *ar.ApicurioRegistry, kubeObjects ...runtime.Object) (*registryContentSupport, *apiServerMock) {
+	log := zap.NewNop()
+	scheme := runtime.NewScheme()
+	c.AssertEquals(t, nil, ar.AddToScheme(scheme))
+	clients := client.NewClientsMock(log, scheme, kubeObjects...)
+	apiServer, config := newApiServerMock(t)
+	apiServer.set("apicurioregistries", registry.Name, registry)
+	clients.SetCRDClientMock(config)
+	return newRegistryContentSupport(log, clients, NewNamespaceScope(log, nil, nil), c.NewOperatorConfig(log, nil, "", "")), apiServer
+}
+
+// Connects to the server, regardless of the Service address
+func dialServer(server *httptest.Server) func(ctx go_ctx.Context, network string, address string) (net.Conn, error) {
+	return func(ctx go_ctx.Context, network string, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+}
+
+func newTestSecret(name string, data map[string][]byte) *core.Secret {
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       data,
+	}
+}
+
+func TestSyncRule(t *testing.T) {
+	target := &client.RegistryRule{Type: "VALIDITY", Config: "FULL"}
+	notFound := &client.RegistryApiError{StatusCode: http.StatusNotFound}
+	failed := &client.RegistryApiError{StatusCode: http.StatusInternalServerError}
+	tests := []struct {
+		name            string
+		existing        *client.RegistryRule
+		getErr          error
+		expectedChanged bool
+		expectedErr     error
+		expectedCalls   string
+	}{
+		{name: "not found", getErr: notFound, expectedChanged: true, expectedCalls: "get,create"},
+		{name: "up to date", existing: &client.RegistryRule{Type: "VALIDITY", Config: "FULL"}, expectedCalls: "get"},
+		{name: "changed", existing: &client.RegistryRule{Type: "VALIDITY", Config: "SYNTAX_ONLY"}, expectedChanged: true, expectedCalls: "get,update"},
+		{name: "get failed", getErr: failed, expectedErr: failed, expectedCalls: "get"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := make([]string, 0)
+			changed, err := syncRule(
+				func() (*client.RegistryRule, error) {
+					calls = append(calls, "get")
+					return test.existing, test.getErr
+				},
+				func() error {
+					calls = append(calls, "create")
+					return nil
+				},
+				func() error {
+					calls = append(calls, "update")
+					return nil
+				},
+				target)
+			c.AssertEquals(t, test.expectedChanged, changed)
+			c.AssertEquals(t, test.expectedErr, err)
+			c.AssertEquals(t, test.expectedCalls, strings.Join(calls, ","))
+		})
+	}
+
+	// The error of the create or update request is returned
+	changed, err := syncRule(
+		func() (*client.RegistryRule, error) { return nil, notFound },
+		func() error { return failed },
+		func() error { return nil },
+		target)
+	c.AssertEquals(t, true, changed)
+	c.AssertEquals(t, failed, err)
+}
+
+func TestValidateRule(t *testing.T) {
+	c.AssertEquals(t, nil, validateRule(ar.ApicurioRegistryRule{Type: ar.RuleTypeValidity, Config: "FULL"}))
+	c.AssertEquals(t, nil, validateRule(ar.ApicurioRegistryRule{Type: ar.RuleTypeIntegrity, Config: "REFS_EXIST, NO_DUPLICATES"}))
+	err := validateRule(ar.ApicurioRegistryRule{Type: ar.RuleTypeCompatibility, Config: "SYNTAX_ONLY"})
+	c.AssertEquals(t, true, isContentError(err, CONTENT_CONDITION_REASON_INVALID))
+}
+
+func TestRegistryContentConnectTls(t *testing.T) {
+	registry, service := newTestRegistry("https")
+	registry.Spec.Configuration.Security.Https.Certificate.Mode = ar.HttpsCertificateModeSelfSigned
+	registry.Spec.Configuration.Security.Https.ClientAuth = ar.HttpsClientAuthRequired
+	registry.Spec.Configuration.Security.Keycloak.Url = "https://keycloak"
+	registry.Spec.Configuration.Security.Keycloak.Realm = "registry"
+	registry.Spec.Configuration.Security.Keycloak.OperatorClient.ClientId = "operator"
+	registry.Spec.Configuration.Security.Keycloak.OperatorClient.ClientSecret.Value = "secret"
+	registry.Spec.Configuration.Security.Keycloak.OperatorClient.TruststoreSecretName = "keycloak-ca"
+
+	// The registry requires the Operator client certificate
+	now := time.Now()
+	caCertificate, certificate, key, err := factory.GenerateSelfSignedCertificate(testRegistryName,
+		[]string{testServiceName + "." + testNamespace + ".svc"}, now)
+	c.AssertEquals(t, nil, err)
+	serverCertificate, err := tls.X509KeyPair(certificate, key)
+	c.AssertEquals(t, nil, err)
+	otherCaCertificate, _, _, err := factory.GenerateSelfSignedCertificate("other", []string{"other"}, now)
+	c.AssertEquals(t, nil, err)
+	_, clientCertificate, clientKey, err := factory.GenerateClientCertificate(testRegistryName+"-operator", now)
+	c.AssertEquals(t, nil, err)
+	registryServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.AssertEquals(t, "Bearer token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	registryServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCertificate},
+		ClientAuth:   tls.RequireAnyClientCert,
+	}
+	registryServer.StartTLS()
+	t.Cleanup(registryServer.Close)
+
+	// Keycloak must not receive the Operator client certificate
+	keycloakClientCertificates := -1
+	keycloakServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keycloakClientCertificates = len(r.TLS.PeerCertificates)
+		c.AssertEquals(t, "/realms/registry/protocol/openid-connect/token", r.URL.Path)
+		_, _ = w.Write([]byte(`{"access_token":"token"}`))
+	}))
+	keycloakServer.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	keycloakServer.StartTLS()
+	t.Cleanup(keycloakServer.Close)
+	registry.Spec.Configuration.Security.Keycloak.Url = keycloakServer.URL
+
+	httpsSecret := newTestSecret(factory.GetHttpsSecretName(testRegistryName), map[string][]byte{
+		"tls.crt": certificate, "tls.key": key, "ca.crt": caCertificate,
+	})
+	operatorClientSecret := newTestSecret(factory.GetHttpsOperatorClientSecretName(testRegistryName), map[string][]byte{
+		"tls.crt": clientCertificate, "tls.key": clientKey,
+	})
+	keycloakCaSecret := newTestSecret("keycloak-ca", map[string][]byte{
+		"ca.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: keycloakServer.Certificate().Raw}),
+	})
+	this, _ := newTestRegistryContentSupport(t, registry, service, httpsSecret, operatorClientSecret, keycloakCaSecret)
+	this.dialRegistry = dialServer(registryServer)
+
+	registryClient, err := this.connect(testNamespace, ar.ApicurioRegistryReference{Name: testRegistryName})
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, nil, registryClient.DeleteGlobalRule("VALIDITY"))
+	c.AssertEquals(t, 0, keycloakClientCertificates)
+
+	// The registry certificate is not issued by the CA in the Secret
+	httpsSecret.Data["ca.crt"] = otherCaCertificate
+	_, err = this.clients.Kube().UpdateSecret(testNamespace, httpsSecret)
+	c.AssertEquals(t, nil, err)
+	registryClient, err = this.connect(testNamespace, ar.ApicurioRegistryReference{Name: testRegistryName})
+	c.AssertEquals(t, nil, err)
+	err = registryClient.DeleteGlobalRule("VALIDITY")
+	c.AssertEquals(t, true, err != nil && strings.Contains(err.Error(), "certificate signed by unknown authority"))
+
+	// Keycloak is not trusted without the CA certificate
+	registry.Spec.Configuration.Security.Keycloak.OperatorClient.TruststoreSecretName = ""
+	_, err = this.connect(testNamespace, ar.ApicurioRegistryReference{Name: testRegistryName})
+	c.AssertEquals(t, true, isContentError(err, CONTENT_CONDITION_REASON_AUTHENTICATION_FAILED))
+}
+
+func TestRegistryContentReconcile(t *testing.T) {
+	status := http.StatusNoContent
+	requests := make([]string, 0)
+	registryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(registryServer.Close)
+	registry, service := newTestRegistry("http")
+	this, apiServer := newTestRegistryContentSupport(t, registry, service)
+	this.dialRegistry = dialServer(registryServer)
+
+	rule := &ar.ApicurioRegistryGlobalRule{
+		ObjectMeta: meta.ObjectMeta{Name: "rule", Namespace: testNamespace, Generation: 1},
+		Spec: ar.ApicurioRegistryGlobalRuleSpec{
+			Registry:             ar.ApicurioRegistryReference{Name: testRegistryName},
+			ApicurioRegistryRule: ar.ApicurioRegistryRule{Type: ar.RuleTypeValidity, Config: "FULL"},
+		},
+	}
+	updates := 0
+	statusUpdates := 0
+	resource := &registryContentResource{
+		object:   rule,
+		registry: rule.Spec.Registry,
+		status:   &rule.Status,
+		update: func() error {
+			updates++
+			return nil
+		},
+		updateStatus: func() error {
+			statusUpdates++
+			return nil
+		},
+		sync: func(registryClient *client.RegistryClient) error {
+			return registryClient.UpdateGlobalRule(&client.RegistryRule{Type: "VALIDITY", Config: "FULL"})
+		},
+		cleanup: func(registryClient *client.RegistryClient) error {
+			return registryClient.DeleteGlobalRule("VALIDITY")
+		},
+	}
+
+	// The finalizer is added before the content is synchronized
+	result, err := this.reconcile(resource)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, 5*time.Minute, result.RequeueAfter)
+	c.AssertEquals(t, []string{RegistryContentFinalizer}, rule.Finalizers)
+	c.AssertEquals(t, 1, updates)
+	c.AssertEquals(t, 1, statusUpdates)
+	c.AssertEquals(t, []string{"PUT /apis/registry/v2/admin/rules/VALIDITY"}, requests)
+	c.AssertEquals(t, true, api_meta.IsStatusConditionTrue(rule.Status.Conditions, CONTENT_CONDITION_TYPE_SYNCED))
+	c.AssertEquals(t, int64(1), rule.Status.ObservedGeneration)
+
+	// The content cannot be removed, the finalizer is kept
+	now := meta.Now()
+	rule.DeletionTimestamp = &now
+	status = http.StatusInternalServerError
+	requests = make([]string, 0)
+	result, err = this.reconcile(resource)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, 30*time.Second, result.RequeueAfter)
+	c.AssertEquals(t, []string{RegistryContentFinalizer}, rule.Finalizers)
+	c.AssertEquals(t, 1, updates)
+	c.AssertEquals(t, 2, statusUpdates)
+	c.AssertEquals(t, []string{"DELETE /apis/registry/v2/admin/rules/VALIDITY"}, requests)
+	condition := api_meta.FindStatusCondition(rule.Status.Conditions, CONTENT_CONDITION_TYPE_SYNCED)
+	c.AssertEquals(t, meta.ConditionFalse, condition.Status)
+	c.AssertEquals(t, string(CONTENT_CONDITION_REASON_CLEANUP_FAILED), condition.Reason)
+
+	// The content is removed, then the finalizer
+	status = http.StatusNoContent
+	result, err = this.reconcile(resource)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, time.Duration(0), result.RequeueAfter)
+	c.AssertEquals(t, 0, len(rule.Finalizers))
+	c.AssertEquals(t, 2, updates)
+
+	// Without the finalizer, there is nothing to do
+	requests = make([]string, 0)
+	_, err = this.reconcile(resource)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, 0, len(requests))
+	c.AssertEquals(t, 2, updates)
+
+	// The registry has been deleted, the finalizer is removed without a request
+	rule.Finalizers = []string{RegistryContentFinalizer}
+	apiServer.remove("apicurioregistries", testRegistryName)
+	_, err = this.reconcile(resource)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, 0, len(rule.Finalizers))
+	c.AssertEquals(t, 3, updates)
+	c.AssertEquals(t, 0, len(requests))
+
+	// The update of the resource has failed
+	rule.Finalizers = []string{RegistryContentFinalizer}
+	resource.update = func() error {
+		return errors.New("conflict")
+	}
+	_, err = this.reconcile(resource)
+	c.AssertEquals(t, errors.New("conflict"), err)
+}
@@ -6,6 +6,9 @@ import (
 
 type Priority int
 
+// Variables with a higher priority overwrite the variables with the same name and lower priority.
+// Variables from spec.configuration.envFrom are not stored in the cache, they are applied by EnvFromCF,
+// and any variable in the cache takes precedence over them, regardless of the priority.
 const (
 	// TODO DEPRECATION:
 	// - Remove support for variables from Deployment
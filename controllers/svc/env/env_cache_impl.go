@@ -138,6 +138,8 @@ func (this *envCache) Set(value EnvCacheEntry) {
 		}
 	} else {
 		this.cache[value.GetName()] = value
+		// The entry might have been deleted in this period, and set again (e.g. by a different CF)
+		delete(this.deleted, value.GetName())
 		this.changed = true
 	}
 }
@@ -0,0 +1,314 @@
+package factory
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"math/big"
+	"time"
+)
+
+// Validity of the self-signed certificates
+const SELF_SIGNED_CERTIFICATE_VALIDITY = 365 * 24 * time.Hour
+
+// Self-signed certificates are renewed when they expire within this period
+const SELF_SIGNED_CERTIFICATE_RENEW_BEFORE = 30 * 24 * time.Hour
+
+// Validity of the self-signed CA certificates.
+// The CA is kept when the serving certificate is renewed, so the clients that trust it are not affected.
+const SELF_SIGNED_CA_VALIDITY = 5 * 365 * 24 * time.Hour
+
+// Self-signed CA certificates are renewed when they expire within this period
+const SELF_SIGNED_CA_RENEW_BEFORE = 90 * 24 * time.Hour
+
+// Key of the self-signed CA private key in the HTTPS certificate Secret, it is not mounted to the Apicurio Registry pods
+const SELF_SIGNED_CA_KEY_KEY = "ca.key"
+
+const DEFAULT_CERT_MANAGER_ISSUER_KIND = "Issuer"
+
+type CertificateFactory struct {
+	ctx         context.LoopContext
+	kubeFactory *KubeFactory
+}
+
+func NewCertificateFactory(ctx context.LoopContext, kubeFactory *KubeFactory) *CertificateFactory {
+	return &CertificateFactory{
+		ctx,
+		kubeFactory,
+	}
+}
+
+// Name of the Secret with the HTTPS certificate generated by the Operator or cert-manager
+func (this *CertificateFactory) GetHttpsSecretName() string {
+	return GetHttpsSecretName(this.ctx.GetAppName().Str())
+}
+
+// Name of the Secret with the HTTPS certificate, for the given ApicurioRegistry name
+func GetHttpsSecretName(appName string) string {
+	return appName + "-https-tls"
+}
+
+// DNS names under which the given Service is reachable from within the cluster
+func (this *CertificateFactory) GetServiceDnsNames(serviceName string) []string {
+	namespace := this.ctx.GetAppNamespace().Str()
+	return []string{
+		serviceName,
+		serviceName + "." + namespace,
+		serviceName + "." + namespace + ".svc",
+		serviceName + "." + namespace + ".svc.cluster.local",
+	}
+}
+
+func (this *CertificateFactory) NewCertManagerCertificate(dnsNames []string, issuerName string, issuerKind string) *unstructured.Unstructured {
+	if issuerKind == "" {
+		issuerKind = DEFAULT_CERT_MANAGER_ISSUER_KIND
+	}
+	names := make([]interface{}, len(dnsNames))
+	for i, n := range dnsNames {
+		names[i] = n
+	}
+	res := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"secretName": this.GetHttpsSecretName(),
+				"dnsNames":   names,
+				"issuerRef": map[string]interface{}{
+					"name":  issuerName,
+					"kind":  issuerKind,
+					"group": "cert-manager.io",
+				},
+			},
+		},
+	}
+	res.SetAPIVersion(client.CERT_MANAGER_API_GROUP_VERSION)
+	res.SetKind("Certificate")
+	res.SetName(this.GetHttpsSecretName())
+	res.SetNamespace(this.ctx.GetAppNamespace().Str())
+	res.SetLabels(this.kubeFactory.GetLabels())
+	return res
+}
+
+// Name of the Secret with the client certificate the Operator presents when HTTPS client authentication is enabled
+func (this *CertificateFactory) GetHttpsOperatorClientSecretName() string {
+	return GetHttpsOperatorClientSecretName(this.ctx.GetAppName().Str())
+}
+
+// Name of the Secret with the Operator client certificate, for the given ApicurioRegistry name
+func GetHttpsOperatorClientSecretName(appName string) string {
+	return appName + "-https-operator-client"
+}
+
+// Name of the Secret with the CA certificates used by Apicurio Registry to verify HTTPS client certificates
+func (this *CertificateFactory) GetHttpsTruststoreSecretName() string {
+	return this.ctx.GetAppName().Str() + "-https-truststore"
+}
+
+// Generate a serving certificate signed by the CA from the existing Secret, or by a new CA if the existing one is missing or expires soon.
+// The Secret has the same format as the Secrets created by cert-manager, with the addition of the CA private key.
+func (this *CertificateFactory) NewSelfSignedSecret(dnsNames []string, existing *core.Secret) (*core.Secret, error) {
+	now := time.Now()
+	commonName := this.ctx.GetAppName().Str()
+	var caCertificate, caKey []byte
+	if existing != nil && !IsCaRenewalNeeded(existing.Data["ca.crt"], existing.Data[SELF_SIGNED_CA_KEY_KEY], now) {
+		caCertificate = existing.Data["ca.crt"]
+		caKey = existing.Data[SELF_SIGNED_CA_KEY_KEY]
+	} else {
+		var err error
+		if caCertificate, caKey, err = GenerateCa(commonName, now); err != nil {
+			return nil, err
+		}
+	}
+	certificate, key, err := signCertificate(caCertificate, caKey, commonName, dnsNames, x509.ExtKeyUsageServerAuth, now)
+	if err != nil {
+		return nil, err
+	}
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      this.GetHttpsSecretName(),
+			Namespace: this.ctx.GetAppNamespace().Str(),
+			Labels:    this.kubeFactory.GetLabels(),
+		},
+		Type: core.SecretTypeTLS,
+		Data: map[string][]byte{
+			"tls.crt":              certificate,
+			"tls.key":              key,
+			"ca.crt":               caCertificate,
+			SELF_SIGNED_CA_KEY_KEY: caKey,
+		},
+	}, nil
+}
+
+// Generate a CA and a client certificate signed by it, which the Operator uses to make requests to Apicurio Registry.
+// The CA certificate is added to the Apicurio Registry truststore.
+func (this *CertificateFactory) NewOperatorClientSecret() (*core.Secret, error) {
+	caCertificate, certificate, key, err := GenerateClientCertificate(this.ctx.GetAppName().Str()+"-operator", time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      this.GetHttpsOperatorClientSecretName(),
+			Namespace: this.ctx.GetAppNamespace().Str(),
+			Labels:    this.kubeFactory.GetLabels(),
+		},
+		Type: core.SecretTypeTLS,
+		Data: map[string][]byte{
+			"tls.crt": certificate,
+			"tls.key": key,
+			"ca.crt":  caCertificate,
+		},
+	}, nil
+}
+
+// Returns the PEM encoded CA certificate, serving certificate, and the serving certificate private key in PKCS#8 format
+func GenerateSelfSignedCertificate(commonName string, dnsNames []string, now time.Time) ([]byte, []byte, []byte, error) {
+	return generateCertificate(commonName, dnsNames, x509.ExtKeyUsageServerAuth, now)
+}
+
+// Returns the PEM encoded CA certificate, client certificate, and the client certificate private key in PKCS#8 format
+func GenerateClientCertificate(commonName string, now time.Time) ([]byte, []byte, []byte, error) {
+	return generateCertificate(commonName, nil, x509.ExtKeyUsageClientAuth, now)
+}
+
+func generateCertificate(commonName string, dnsNames []string, extKeyUsage x509.ExtKeyUsage, now time.Time) ([]byte, []byte, []byte, error) {
+	caCertificate, caKey, err := GenerateCa(commonName, now)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	certificate, key, err := signCertificate(caCertificate, caKey, commonName, dnsNames, extKeyUsage, now)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return caCertificate, certificate, key, nil
+}
+
+// Returns the PEM encoded CA certificate, and the CA private key in PKCS#8 format
+func GenerateCa(commonName string, now time.Time) ([]byte, []byte, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName + "-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(SELF_SIGNED_CA_VALIDITY),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	caKeyDer, err := x509.MarshalPKCS8PrivateKey(caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDer}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: caKeyDer}),
+		nil
+}
+
+// Returns the PEM encoded certificate signed by the CA, and its private key in PKCS#8 format.
+// The certificate does not outlive the CA.
+func signCertificate(caCertificate []byte, caKey []byte, commonName string, dnsNames []string, extKeyUsage x509.ExtKeyUsage,
+	now time.Time) ([]byte, []byte, error) {
+	ca, caSigner, err := parseCa(caCertificate, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	notAfter := now.Add(SELF_SIGNED_CERTIFICATE_VALIDITY)
+	if notAfter.After(ca.NotAfter) {
+		notAfter = ca.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano() + 1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caSigner)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}),
+		nil
+}
+
+// Parses the PEM encoded CA certificate and its private key in PKCS#8 format, and checks that they match
+func parseCa(caCertificate []byte, caKey []byte) (*x509.Certificate, crypto.Signer, error) {
+	certificateBlock, _ := pem.Decode(caCertificate)
+	if certificateBlock == nil {
+		return nil, nil, errors.New("could not decode the CA certificate")
+	}
+	ca, err := x509.ParseCertificate(certificateBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBlock, _ := pem.Decode(caKey)
+	if keyBlock == nil {
+		return nil, nil, errors.New("could not decode the CA private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("the CA private key is not supported")
+	}
+	if publicKey, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !publicKey.Equal(ca.PublicKey) {
+		return nil, nil, errors.New("the CA private key does not match the CA certificate")
+	}
+	return ca, signer, nil
+}
+
+// Returns true if the PEM encoded CA certificate or private key is invalid, or if the CA expires soon
+func IsCaRenewalNeeded(caCertificate []byte, caKey []byte, now time.Time) bool {
+	ca, _, err := parseCa(caCertificate, caKey)
+	return err != nil || !ca.IsCA || now.Add(SELF_SIGNED_CA_RENEW_BEFORE).After(ca.NotAfter)
+}
+
+// Returns true if the PEM encoded certificate is invalid, expires soon, or does not contain all DNS names
+func IsCertificateRenewalNeeded(certificate []byte, dnsNames []string, now time.Time) bool {
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return true
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	if now.Add(SELF_SIGNED_CERTIFICATE_RENEW_BEFORE).After(parsed.NotAfter) {
+		return true
+	}
+	for _, name := range dnsNames {
+		if parsed.VerifyHostname(name) != nil {
+			return true
+		}
+	}
+	return false
+}
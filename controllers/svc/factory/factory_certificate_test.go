@@ -0,0 +1,127 @@
+package factory
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"testing"
+	"time"
+)
+
+func TestGenerateSelfSignedCertificate(t *testing.T) {
+	now := time.Now()
+	dnsNames := []string{"registry-service", "registry-service.test.svc"}
+	caCertificate, certificate, key, err := GenerateSelfSignedCertificate("registry", dnsNames, now)
+	c.AssertEquals(t, nil, err)
+
+	// The key matches the certificate
+	_, err = tls.X509KeyPair(certificate, key)
+	c.AssertEquals(t, nil, err)
+
+	// The certificate is signed by the CA
+	pool := x509.NewCertPool()
+	c.AssertEquals(t, true, pool.AppendCertsFromPEM(caCertificate))
+	block, _ := pem.Decode(certificate)
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	c.AssertEquals(t, nil, err)
+	_, err = parsed.Verify(x509.VerifyOptions{
+		DNSName:     "registry-service.test.svc",
+		Roots:       pool,
+		CurrentTime: now,
+	})
+	c.AssertEquals(t, nil, err)
+
+	c.AssertEquals(t, false, IsCertificateRenewalNeeded(certificate, dnsNames, now))
+	c.AssertEquals(t, true, IsCertificateRenewalNeeded(certificate, append(dnsNames, "registry.example.com"), now))
+	c.AssertEquals(t, true, IsCertificateRenewalNeeded(certificate, dnsNames,
+		now.Add(SELF_SIGNED_CERTIFICATE_VALIDITY-SELF_SIGNED_CERTIFICATE_RENEW_BEFORE+time.Hour)))
+	c.AssertEquals(t, true, IsCertificateRenewalNeeded([]byte("invalid"), dnsNames, now))
+}
+
+func TestGenerateClientCertificate(t *testing.T) {
+	now := time.Now()
+	caCertificate, certificate, key, err := GenerateClientCertificate("registry-operator", now)
+	c.AssertEquals(t, nil, err)
+
+	_, err = tls.X509KeyPair(certificate, key)
+	c.AssertEquals(t, nil, err)
+
+	// The certificate can be used for client authentication
+	pool := x509.NewCertPool()
+	c.AssertEquals(t, true, pool.AppendCertsFromPEM(caCertificate))
+	block, _ := pem.Decode(certificate)
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	c.AssertEquals(t, nil, err)
+	_, err = parsed.Verify(x509.VerifyOptions{
+		Roots:       pool,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	c.AssertEquals(t, nil, err)
+
+	c.AssertEquals(t, false, IsCertificateRenewalNeeded(certificate, nil, now))
+}
+
+func parseTestCertificate(t *testing.T, certificate []byte) *x509.Certificate {
+	block, _ := pem.Decode(certificate)
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	c.AssertEquals(t, nil, err)
+	return parsed
+}
+
+func TestNewSelfSignedSecretKeepsCa(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	certificateFactory := NewCertificateFactory(ctx, NewKubeFactory(ctx))
+	now := time.Now()
+	dnsNames := []string{"registry-service", "registry-service.test.svc"}
+	secret, err := certificateFactory.NewSelfSignedSecret(dnsNames, nil)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, false, IsCaRenewalNeeded(secret.Data["ca.crt"], secret.Data[SELF_SIGNED_CA_KEY_KEY], now))
+
+	// The serving certificate is renewed with the same CA, e.g. when the DNS names change
+	dnsNames = append(dnsNames, "registry-service.test.svc.cluster.local")
+	renewed, err := certificateFactory.NewSelfSignedSecret(dnsNames, secret)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, secret.Data["ca.crt"], renewed.Data["ca.crt"])
+	c.AssertEquals(t, secret.Data[SELF_SIGNED_CA_KEY_KEY], renewed.Data[SELF_SIGNED_CA_KEY_KEY])
+	c.AssertEquals(t, false, string(secret.Data["tls.crt"]) == string(renewed.Data["tls.crt"]))
+	pool := x509.NewCertPool()
+	c.AssertEquals(t, true, pool.AppendCertsFromPEM(secret.Data["ca.crt"]))
+	_, err = parseTestCertificate(t, renewed.Data["tls.crt"]).Verify(x509.VerifyOptions{
+		DNSName:     "registry-service.test.svc.cluster.local",
+		Roots:       pool,
+		CurrentTime: now,
+	})
+	c.AssertEquals(t, nil, err)
+
+	// A new CA is generated if the existing Secret does not contain the CA private key
+	delete(renewed.Data, SELF_SIGNED_CA_KEY_KEY)
+	c.AssertEquals(t, true, IsCaRenewalNeeded(renewed.Data["ca.crt"], nil, now))
+	replaced, err := certificateFactory.NewSelfSignedSecret(dnsNames, renewed)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, false, string(secret.Data["ca.crt"]) == string(replaced.Data["ca.crt"]))
+
+	// The CA private key must match the CA certificate
+	c.AssertEquals(t, true, IsCaRenewalNeeded(secret.Data["ca.crt"], replaced.Data[SELF_SIGNED_CA_KEY_KEY], now))
+}
+
+func TestCaRenewal(t *testing.T) {
+	now := time.Now()
+	caCertificate, caKey, err := GenerateCa("registry", now)
+	c.AssertEquals(t, nil, err)
+	ca := parseTestCertificate(t, caCertificate)
+
+	// The CA is renewed on its own schedule
+	c.AssertEquals(t, false, IsCaRenewalNeeded(caCertificate, caKey,
+		now.Add(SELF_SIGNED_CERTIFICATE_VALIDITY)))
+	c.AssertEquals(t, true, IsCaRenewalNeeded(caCertificate, caKey,
+		now.Add(SELF_SIGNED_CA_VALIDITY-SELF_SIGNED_CA_RENEW_BEFORE+time.Hour)))
+
+	// The serving certificate does not outlive the CA
+	later := now.Add(SELF_SIGNED_CA_VALIDITY - SELF_SIGNED_CA_RENEW_BEFORE)
+	certificate, _, err := signCertificate(caCertificate, caKey, "registry", []string{"registry-service"}, x509.ExtKeyUsageServerAuth, later)
+	c.AssertEquals(t, nil, err)
+	c.AssertEquals(t, ca.NotAfter, parseTestCertificate(t, certificate).NotAfter)
+}
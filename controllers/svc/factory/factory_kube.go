@@ -1,7 +1,10 @@
 package factory
 
 import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
@@ -10,7 +13,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"os"
 )
 
 type KubeFactory struct {
@@ -25,8 +27,8 @@ func NewKubeFactory(ctx context.LoopContext) *KubeFactory {
 
 const REGISTRY_CONTAINER_NAME = "registry"
 
-const ENV_REGISTRY_VERSION = "REGISTRY_VERSION"
-const ENV_OPERATOR_NAME = "OPERATOR_NAME"
+const ENV_REGISTRY_VERSION = common.ENV_REGISTRY_VERSION
+const ENV_OPERATOR_NAME = common.ENV_OPERATOR_NAME
 
 var intstr1 = intstr.FromInt(1)
 
@@ -34,31 +36,63 @@ var boolFalse = false
 var boolTrue = true
 
 // MUST NOT be used directly as selector labels, because some of them may change.
+// The default labels from the Operator configuration and the managed resources labels from the spec are included,
+// but they cannot override the labels set by the Operator.
 func (this *KubeFactory) GetLabels() map[string]string {
 
-	registryVersion := os.Getenv(ENV_REGISTRY_VERSION)
-	if registryVersion == "" {
-		panic("Could not determine Registry version. Environment variable '" + ENV_REGISTRY_VERSION + "' is empty.")
+	config := this.ctx.GetOperatorConfig().Get()
+	app := this.ctx.GetAppName().Str()
+
+	labels := make(map[string]string, len(config.DefaultLabels)+9)
+	for k, v := range config.DefaultLabels {
+		labels[k] = v
 	}
-	operatorName := os.Getenv(ENV_OPERATOR_NAME)
-	if operatorName == "" {
-		panic("Could not determine Operator name. Environment variable '" + ENV_OPERATOR_NAME + "' is empty.")
+	for k, v := range this.getManagedResourcesMetadata().Labels {
+		labels[k] = v
 	}
-	app := this.ctx.GetAppName().Str()
 
-	return map[string]string{
-		"app": app,
+	labels["app"] = app
+
+	labels["apicur.io/type"] = "apicurio-registry"
+	labels["apicur.io/name"] = app
+
+	labels["app.kubernetes.io/name"] = "apicurio-registry"
+	labels["app.kubernetes.io/instance"] = app
+
+	labels["app.kubernetes.io/managed-by"] = config.OperatorName
+
+	// The version is unknown if neither the environment variable nor the Operator configuration provide it
+	if config.RegistryVersion != "" {
+		labels["apicur.io/version"] = config.RegistryVersion
+		labels["app.kubernetes.io/version"] = config.RegistryVersion
+	}
+
+	return labels
+}
 
-		"apicur.io/type":    "apicurio-registry",
-		"apicur.io/name":    app,
-		"apicur.io/version": registryVersion,
+// Annotations of the managed resources, from the Operator configuration and the spec.
+// Pod annotations are managed separately.
+func (this *KubeFactory) GetAnnotations() map[string]string {
+	annotations := make(map[string]string)
+	common.LabelsUpdate(&annotations, this.ctx.GetOperatorConfig().Get().DefaultAnnotations)
+	common.LabelsUpdate(&annotations, this.getManagedResourcesMetadata().Annotations)
+	return annotations
+}
 
-		"app.kubernetes.io/name":     "apicurio-registry",
-		"app.kubernetes.io/instance": app,
-		"app.kubernetes.io/version":  registryVersion,
+// Annotations of the Service, the values from spec.deployment.service.annotations take precedence
+func (this *KubeFactory) GetServiceAnnotations() map[string]string {
+	annotations := this.GetAnnotations()
+	if specEntry, exists := this.ctx.GetResourceCache().Get(resources.RC_KEY_SPEC); exists {
+		common.LabelsUpdate(&annotations, specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Service.Annotations)
+	}
+	return annotations
+}
 
-		"app.kubernetes.io/managed-by": operatorName,
+func (this *KubeFactory) getManagedResourcesMetadata() ar.ApicurioRegistrySpecDeploymentManagedResourcesMetadata {
+	if specEntry, exists := this.ctx.GetResourceCache().Get(resources.RC_KEY_SPEC); exists {
+		return specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.ManagedResourcesMetadata
 	}
+	return ar.ApicurioRegistrySpecDeploymentManagedResourcesMetadata{}
 }
 
 // Selector labels MUST be static/constant in the life of the application.
@@ -71,9 +105,10 @@ func (this *KubeFactory) GetSelectorLabels() map[string]string {
 
 func (this *KubeFactory) createObjectMeta(typeTag string) meta.ObjectMeta {
 	return meta.ObjectMeta{
-		Name:      this.ctx.GetAppName().Str() + "-" + typeTag,
-		Namespace: this.ctx.GetAppNamespace().Str(),
-		Labels:    this.GetLabels(),
+		Name:        this.ctx.GetAppName().Str() + "-" + typeTag,
+		Namespace:   this.ctx.GetAppNamespace().Str(),
+		Labels:      this.GetLabels(),
+		Annotations: this.GetAnnotations(),
 	}
 }
 
@@ -81,7 +116,7 @@ func (this *KubeFactory) CreateDeployment() *apps.Deployment {
 	var terminationGracePeriodSeconds int64 = 30
 	var replicas int32 = 1
 
-	return &apps.Deployment{
+	deployment := &apps.Deployment{
 		ObjectMeta: this.createObjectMeta("deployment"),
 		Spec: apps.DeploymentSpec{
 			Replicas: &replicas,
@@ -166,6 +201,10 @@ func (this *KubeFactory) CreateDeployment() *apps.Deployment {
 			},
 		},
 	}
+	if defaultResources := this.ctx.GetOperatorConfig().Get().DefaultResources; defaultResources != nil {
+		deployment.Spec.Template.Spec.Containers[0].Resources = *defaultResources.DeepCopy()
+	}
+	return deployment
 }
 
 func (this *KubeFactory) CreateService() *core.Service {
@@ -183,11 +222,11 @@ func (this *KubeFactory) CreateIngress(serviceName string) *networking.Ingress {
 		panic("Required argument, Ingress name, is empty.")
 	}
 	metaData := this.createObjectMeta("ingress")
-	metaData.Annotations = map[string]string{
+	common.LabelsUpdate(&metaData.Annotations, map[string]string{
 		"nginx.ingress.kubernetes.io/force-ssl-redirect": "false",
 		"nginx.ingress.kubernetes.io/rewrite-target":     "/",
 		"nginx.ingress.kubernetes.io/ssl-redirect":       "false",
-	}
+	})
 	pathTypePrefix := networking.PathTypePrefix
 	res := &networking.Ingress{
 		ObjectMeta: metaData,
@@ -0,0 +1,39 @@
+package factory
+
+import (
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
+	"testing"
+)
+
+func TestManagedResourcesMetadata(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	spec := &ar.ApicurioRegistry{}
+	spec.Spec.Deployment.ManagedResourcesMetadata = ar.ApicurioRegistrySpecDeploymentManagedResourcesMetadata{
+		Labels: map[string]string{
+			"cost-center": "1234",
+			"app":         "other",
+		},
+		Annotations: map[string]string{
+			"example.com/owner": "registry-team",
+		},
+	}
+	ctx.GetResourceCache().Set(resources.RC_KEY_SPEC, resources.NewResourceCacheEntry(c.Name("mock"), spec))
+	factory := NewKubeFactory(ctx)
+
+	// The selector labels cannot be overridden
+	labels := factory.GetLabels()
+	c.AssertEquals(t, "1234", labels["cost-center"])
+	c.AssertEquals(t, "mock", labels["app"])
+
+	service := factory.CreateService()
+	c.AssertEquals(t, "1234", service.Labels["cost-center"])
+	c.AssertEquals(t, factory.GetSelectorLabels(), service.Spec.Selector)
+	c.AssertEquals(t, "registry-team", service.Annotations["example.com/owner"])
+
+	ingress := factory.CreateIngress("mock-service")
+	c.AssertEquals(t, "registry-team", ingress.Annotations["example.com/owner"])
+	c.AssertEquals(t, "/", ingress.Annotations["nginx.ingress.kubernetes.io/rewrite-target"])
+}
@@ -33,9 +33,10 @@ func (this *MonitoringFactory) NewServiceMonitor(service *core.Service) *monitor
 
 	return &monitoring.ServiceMonitor{
 		ObjectMeta: meta.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Labels:    this.GetLabels(),
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      this.GetLabels(),
+			Annotations: this.kubeFactory.GetAnnotations(),
 		},
 		Spec: monitoring.ServiceMonitorSpec{
 			Endpoints: []monitoring.Endpoint{
@@ -0,0 +1,57 @@
+package factory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	ocp_route "github.com/openshift/api/route/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type OCPFactory struct {
+	ctx         context.LoopContext
+	kubeFactory *KubeFactory
+}
+
+func NewOCPFactory(ctx context.LoopContext, kubeFactory *KubeFactory) *OCPFactory {
+	return &OCPFactory{
+		ctx,
+		kubeFactory,
+	}
+}
+
+// There is a Route for each hostname, the name is derived from the hostname so it does not change
+// when the other hostnames are added or removed.
+func (this *OCPFactory) GetRouteName(host string) string {
+	hash := sha256.Sum256([]byte(host))
+	return this.ctx.GetAppName().Str() + "-route-" + hex.EncodeToString(hash[:])[:8]
+}
+
+func (this *OCPFactory) CreateRoute(host string, serviceName string, targetPort int, tls *ocp_route.TLSConfig) *ocp_route.Route {
+	if serviceName == "" {
+		panic("Required argument, Service name, is empty.")
+	}
+	weight := int32(100)
+	return &ocp_route.Route{
+		ObjectMeta: meta.ObjectMeta{
+			Name:        this.GetRouteName(host),
+			Namespace:   this.ctx.GetAppNamespace().Str(),
+			Labels:      this.kubeFactory.GetLabels(),
+			Annotations: this.kubeFactory.GetAnnotations(),
+		},
+		Spec: ocp_route.RouteSpec{
+			Host: host,
+			To: ocp_route.RouteTargetReference{
+				Kind:   "Service",
+				Name:   serviceName,
+				Weight: &weight,
+			},
+			Port: &ocp_route.RoutePort{
+				TargetPort: intstr.FromInt(targetPort),
+			},
+			TLS:            tls,
+			WildcardPolicy: ocp_route.WildcardPolicyNone,
+		},
+	}
+}
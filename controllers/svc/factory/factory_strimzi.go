@@ -0,0 +1,95 @@
+package factory
+
+import (
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
+)
+
+const STRIMZI_CLUSTER_LABEL = "strimzi.io/cluster"
+
+// Upper bound of the replication factor of the journal topic
+const STRIMZI_JOURNAL_TOPIC_MAX_REPLICAS = 3
+
+type StrimziFactory struct {
+	ctx         context.LoopContext
+	kubeFactory *KubeFactory
+}
+
+func NewStrimziFactory(ctx context.LoopContext, kubeFactory *KubeFactory) *StrimziFactory {
+	return &StrimziFactory{
+		ctx,
+		kubeFactory,
+	}
+}
+
+func (this *StrimziFactory) GetLabels(clusterName string) map[string]string {
+	labels := this.kubeFactory.GetLabels()
+	labels[STRIMZI_CLUSTER_LABEL] = clusterName
+	return labels
+}
+
+func (this *StrimziFactory) GetKafkaUserName() string {
+	return this.ctx.GetAppName().Str() + "-kafkasql"
+}
+
+// The KafkaUser has no authorization section. If the Kafka cluster uses authorization,
+// the ACLs for the journal topic and the consumer group of Apicurio Registry must be granted separately,
+// because the consumer group can be changed using the Kafka client properties.
+func (this *StrimziFactory) NewKafkaUser(clusterName string, authentication string) *unstructured.Unstructured {
+	res := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"authentication": map[string]interface{}{
+					"type": authentication,
+				},
+			},
+		},
+	}
+	res.SetAPIVersion(client.STRIMZI_API_GROUP_VERSION)
+	res.SetKind("KafkaUser")
+	res.SetName(this.GetKafkaUserName())
+	res.SetNamespace(this.ctx.GetAppNamespace().Str())
+	res.SetLabels(this.GetLabels(clusterName))
+	return res
+}
+
+// Default name of the topic used by the kafkasql storage.
+// The name is unique for each ApicurioRegistry in the namespace, so the registries do not share their data.
+func (this *StrimziFactory) GetDefaultKafkaTopicName() string {
+	return this.ctx.GetAppName().Str() + "-kafkasql-journal"
+}
+
+// Kafka topic names may contain characters that are not allowed in Kubernetes resource names
+func (this *StrimziFactory) GetKafkaTopicName(topicName string) string {
+	return strings.ReplaceAll(strings.ToLower(topicName), "_", "-")
+}
+
+// The replicas value is omitted if it is not positive, so the cluster default is used.
+func (this *StrimziFactory) NewKafkaTopic(clusterName string, topicName string, replicas int64) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"topicName":  topicName,
+		"partitions": int64(1),
+		"config": map[string]interface{}{
+			"cleanup.policy": "compact",
+		},
+	}
+	if replicas > 0 {
+		if replicas > STRIMZI_JOURNAL_TOPIC_MAX_REPLICAS {
+			replicas = STRIMZI_JOURNAL_TOPIC_MAX_REPLICAS
+		}
+		spec["replicas"] = replicas
+	}
+	res := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	res.SetAPIVersion(client.STRIMZI_API_GROUP_VERSION)
+	res.SetKind("KafkaTopic")
+	res.SetName(this.GetKafkaTopicName(topicName))
+	res.SetNamespace(this.ctx.GetAppNamespace().Str())
+	res.SetLabels(this.GetLabels(clusterName))
+	return res
+}
@@ -0,0 +1,51 @@
+package factory
+
+import (
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"testing"
+)
+
+func TestNewKafkaUser(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	factory := NewStrimziFactory(ctx, NewKubeFactory(ctx))
+
+	user := factory.NewKafkaUser("my-cluster", "tls")
+	c.AssertEquals(t, "KafkaUser", user.GetKind())
+	c.AssertEquals(t, ctx.GetAppName().Str()+"-kafkasql", user.GetName())
+	c.AssertEquals(t, ctx.GetAppNamespace().Str(), user.GetNamespace())
+	c.AssertEquals(t, "my-cluster", user.GetLabels()[STRIMZI_CLUSTER_LABEL])
+	authentication, _, _ := unstructured.NestedString(user.Object, "spec", "authentication", "type")
+	c.AssertEquals(t, "tls", authentication)
+}
+
+func TestNewKafkaTopic(t *testing.T) {
+	ctx := context.NewLoopContextMock()
+	factory := NewStrimziFactory(ctx, NewKubeFactory(ctx))
+
+	// The default topic is not shared with other registries in the namespace
+	c.AssertEquals(t, ctx.GetAppName().Str()+"-kafkasql-journal", factory.GetDefaultKafkaTopicName())
+
+	topic := factory.NewKafkaTopic("my-cluster", "Registry_Journal", 5)
+	c.AssertEquals(t, "KafkaTopic", topic.GetKind())
+	c.AssertEquals(t, "registry-journal", topic.GetName())
+	c.AssertEquals(t, "my-cluster", topic.GetLabels()[STRIMZI_CLUSTER_LABEL])
+	topicName, _, _ := unstructured.NestedString(topic.Object, "spec", "topicName")
+	c.AssertEquals(t, "Registry_Journal", topicName)
+	partitions, _, _ := unstructured.NestedInt64(topic.Object, "spec", "partitions")
+	c.AssertEquals(t, int64(1), partitions)
+	policy, _, _ := unstructured.NestedString(topic.Object, "spec", "config", "cleanup.policy")
+	c.AssertEquals(t, "compact", policy)
+	replicas, _, _ := unstructured.NestedInt64(topic.Object, "spec", "replicas")
+	c.AssertEquals(t, int64(STRIMZI_JOURNAL_TOPIC_MAX_REPLICAS), replicas)
+
+	topic = factory.NewKafkaTopic("my-cluster", "journal", 2)
+	replicas, _, _ = unstructured.NestedInt64(topic.Object, "spec", "replicas")
+	c.AssertEquals(t, int64(2), replicas)
+
+	// The cluster default is used
+	topic = factory.NewKafkaTopic("my-cluster", "journal", 0)
+	_, found, _ := unstructured.NestedInt64(topic.Object, "spec", "replicas")
+	c.AssertEquals(t, false, found)
+}
@@ -1,7 +1,7 @@
 package patcher
 
 import (
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
@@ -44,7 +44,7 @@ func (this *OCPPatcher) reloadRoute() {
 		if e == nil {
 			existingHost := ""
 			if specEntry, exists := this.ctx.GetResourceCache().Get(resources.RC_KEY_SPEC); exists {
-				existingHost = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Host
+				existingHost = specEntry.GetValue().(*ar.ApicurioRegistry).Spec.Deployment.Hosts.Primary
 			}
 			for _, r := range rs.Items {
 				if r.GetObjectMeta().GetDeletionTimestamp() == nil && r.Spec.Host == existingHost {
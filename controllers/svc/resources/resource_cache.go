@@ -12,6 +12,9 @@ const RC_KEY_ROUTE_OCP = "ROUTE_OCP"
 const RC_KEY_POD_DISRUPTION_BUDGET_V1BETA1 = "POD_DISRUPTION_BUDGET_V1BETA1"
 const RC_KEY_POD_DISRUPTION_BUDGET_V1 = "POD_DISRUPTION_BUDGET_V1"
 
+// Kafka connection details resolved from the Strimzi resources, not a Kubernetes resource
+const RC_KEY_KAFKASQL_STRIMZI_CONNECTION = "KAFKASQL_STRIMZI_CONNECTION"
+
 const RC_NOT_CREATED_NAME_EMPTY = ""
 
 // ===
@@ -0,0 +1,37 @@
+package state
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Suffix of the name of the ConfigMap that stores the state of the control loop
+const STATE_CONFIG_MAP_NAME_SUFFIX = "-operator-state"
+
+// Keys of the persisted values
+const STATE_KEY_HOST_INIT_DONE = "HOST_INIT_DONE"
+const STATE_KEY_UPGRADE_CONTAINER_NAME_DONE = "UPGRADE_CONTAINER_NAME_DONE"
+const STATE_KEY_ENV_PREVIOUS_TARGET = "ENV_PREVIOUS_TARGET"
+const STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_BASE = "POD_TEMPLATE_SPEC_PREVIOUS_BASE"
+const STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEPLOYMENT = "POD_TEMPLATE_SPEC_PREVIOUS_DEPLOYMENT"
+const STATE_KEY_POD_TEMPLATE_SPEC_PREVIOUS_DEFAULT_RESOURCES = "POD_TEMPLATE_SPEC_PREVIOUS_DEFAULT_RESOURCES"
+const STATE_KEY_IMAGE_ROLLBACK_CURRENT_IMAGE = "IMAGE_ROLLBACK_CURRENT_IMAGE"
+const STATE_KEY_SERVICE_CONFIG_PREVIOUS_ANNOTATIONS = "SERVICE_CONFIG_PREVIOUS_ANNOTATIONS"
+
+// State of the control functions that has to survive an Operator restart.
+// The values are stored as JSON in a ConfigMap owned by the ApicurioRegistry resource.
+type LoopState interface {
+	// Decodes the value into the target, and returns false if the value does not exist
+	Get(key string, target interface{}) bool
+
+	Set(key string, value interface{})
+
+	Remove(key string)
+
+	// Reads the values from the cluster, if they have not been read yet
+	Load()
+
+	// Writes the values to the cluster, if they have changed
+	Save(owner meta.Object)
+
+	IsChanged() bool
+}
@@ -0,0 +1,138 @@
+package state
+
+import (
+	"encoding/json"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	"go.uber.org/zap"
+	core "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ LoopState = &loopState{}
+
+type loopState struct {
+	log          *zap.SugaredLogger
+	clients      *client.Clients
+	appName      c.Name
+	appNamespace c.Namespace
+	values       map[string]string
+	// The ConfigMap that has been read or written last, nil if it does not exist
+	configMap *core.ConfigMap
+	loaded    bool
+	changed   bool
+}
+
+// The clients may be nil, in which case the values are kept in memory only
+func NewLoopState(log *zap.Logger, clients *client.Clients, appName c.Name, appNamespace c.Namespace) LoopState {
+	return &loopState{
+		log:          log.Sugar().Named("state"),
+		clients:      clients,
+		appName:      appName,
+		appNamespace: appNamespace,
+		values:       make(map[string]string),
+		configMap:    nil,
+		loaded:       false,
+		changed:      false,
+	}
+}
+
+func (this *loopState) Get(key string, target interface{}) bool {
+	value, exists := this.values[key]
+	if !exists {
+		return false
+	}
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		this.log.Warnw("could not decode the persisted value, ignoring it", "key", key, "error", err)
+		return false
+	}
+	return true
+}
+
+func (this *loopState) Set(key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic("Could not encode the value of " + key + ": " + err.Error())
+	}
+	if previous, exists := this.values[key]; !exists || previous != string(data) {
+		this.values[key] = string(data)
+		this.changed = true
+	}
+}
+
+func (this *loopState) Remove(key string) {
+	if _, exists := this.values[key]; exists {
+		delete(this.values, key)
+		this.changed = true
+	}
+}
+
+func (this *loopState) Load() {
+	if this.loaded || this.clients == nil {
+		return
+	}
+	configMap, err := this.clients.Kube().GetConfigMap(this.appNamespace, this.getName(), &meta.GetOptions{})
+	if err != nil {
+		if !api_errors.IsNotFound(err) {
+			// Retry in the next reconciliation, the values set in the meantime are kept
+			this.log.Errorw("could not read the persisted state", "error", err)
+			return
+		}
+		configMap = nil
+	}
+	this.configMap = configMap
+	if configMap != nil {
+		for key, value := range configMap.Data {
+			// Values set before the state could be read take precedence
+			if _, exists := this.values[key]; !exists {
+				this.values[key] = value
+			}
+		}
+	}
+	this.loaded = true
+}
+
+func (this *loopState) Save(owner meta.Object) {
+	// Do not overwrite the persisted state before it has been read
+	if !this.changed || !this.loaded || this.clients == nil {
+		return
+	}
+	data := make(map[string]string, len(this.values))
+	for key, value := range this.values {
+		data[key] = value
+	}
+	var err error
+	if this.configMap == nil {
+		this.configMap, err = this.clients.Kube().CreateConfigMap(owner, this.appNamespace, &core.ConfigMap{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      this.getName().Str(),
+				Namespace: this.appNamespace.Str(),
+				Labels: map[string]string{
+					"app": this.appName.Str(),
+				},
+			},
+			Data: data,
+		})
+	} else {
+		configMap := this.configMap.DeepCopy()
+		configMap.Data = data
+		this.configMap, err = this.clients.Kube().UpdateConfigMap(this.appNamespace, configMap)
+	}
+	if err != nil {
+		this.configMap = nil
+		// Read the state again, e.g. in case of a conflict
+		this.loaded = false
+		this.log.Errorw("could not persist the state, the values are kept in memory", "error", err)
+		return
+	}
+	this.changed = false
+}
+
+func (this *loopState) IsChanged() bool {
+	return this.changed
+}
+
+func (this *loopState) getName() c.Name {
+	return c.Name(this.appName.Str() + STATE_CONFIG_MAP_NAME_SUFFIX)
+}
@@ -0,0 +1,35 @@
+package state
+
+import (
+	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
+	core "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestLoopState(t *testing.T) {
+	state := NewLoopState(c.GetRootLogger(true), nil, c.Name("test"), c.Namespace("test"))
+
+	done := false
+	c.AssertEquals(t, false, state.Get(STATE_KEY_HOST_INIT_DONE, &done))
+	c.AssertEquals(t, false, state.IsChanged())
+
+	state.Set(STATE_KEY_HOST_INIT_DONE, true)
+	c.AssertEquals(t, true, state.Get(STATE_KEY_HOST_INIT_DONE, &done))
+	c.AssertEquals(t, true, done)
+	c.AssertEquals(t, true, state.IsChanged())
+
+	env := []core.EnvVar{
+		{Name: "FOO", Value: "foo"},
+		{Name: "BAR", ValueFrom: &core.EnvVarSource{SecretKeyRef: &core.SecretKeySelector{
+			LocalObjectReference: core.LocalObjectReference{Name: "secret"},
+			Key:                  "bar",
+		}}},
+	}
+	state.Set(STATE_KEY_ENV_PREVIOUS_TARGET, env)
+	decoded := make([]core.EnvVar, 0)
+	c.AssertEquals(t, true, state.Get(STATE_KEY_ENV_PREVIOUS_TARGET, &decoded))
+	c.AssertEquals(t, env, decoded)
+
+	state.Remove(STATE_KEY_ENV_PREVIOUS_TARGET)
+	c.AssertEquals(t, false, state.Get(STATE_KEY_ENV_PREVIOUS_TARGET, &decoded))
+}
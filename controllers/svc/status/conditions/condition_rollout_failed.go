@@ -0,0 +1,30 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RolloutFailedCondition struct {
+	condition
+}
+
+var _ Condition = &RolloutFailedCondition{}
+
+func NewRolloutFailedCondition() *RolloutFailedCondition {
+	this := &RolloutFailedCondition{}
+	this.SetType(CONDITION_TYPE_ROLLOUT_FAILED)
+	this.Reset()
+	return this
+}
+
+func (this *RolloutFailedCondition) IsActive() bool {
+	return this.data.Status == metav1.ConditionTrue
+}
+
+// Transitions in decreasing order of priority
+
+func (this *RolloutFailedCondition) TransitionProgressDeadlineExceeded(replicaSetName string) {
+	this.data.Status = metav1.ConditionTrue
+	this.data.Reason = string(ROLLOUT_FAILED_REASON_PROGRESS_DEADLINE_EXCEEDED)
+	this.data.Message = "ReplicaSet " + replicaSetName + " has timed out progressing. Please check the events and the pod logs."
+}
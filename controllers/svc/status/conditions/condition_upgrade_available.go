@@ -0,0 +1,31 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type UpgradeAvailableCondition struct {
+	condition
+}
+
+var _ Condition = &UpgradeAvailableCondition{}
+
+func NewUpgradeAvailableCondition() *UpgradeAvailableCondition {
+	this := &UpgradeAvailableCondition{}
+	this.SetType(CONDITION_TYPE_UPGRADE_AVAILABLE)
+	this.Reset()
+	return this
+}
+
+func (this *UpgradeAvailableCondition) IsActive() bool {
+	return this.data.Status == metav1.ConditionTrue
+}
+
+// Transitions in decreasing order of priority
+
+func (this *UpgradeAvailableCondition) TransitionApprovalRequired(image string, approvalAnnotation string) {
+	this.data.Status = metav1.ConditionTrue
+	this.data.Reason = string(UPGRADE_AVAILABLE_REASON_APPROVAL_REQUIRED)
+	this.data.Message = "Image " + image + " is available. To roll it out, set the " + approvalAnnotation +
+		" annotation to the image, or pin the version in spec.deployment.version."
+}
@@ -0,0 +1,32 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type UpgradeRolledBackCondition struct {
+	condition
+}
+
+var _ Condition = &UpgradeRolledBackCondition{}
+
+func NewUpgradeRolledBackCondition() *UpgradeRolledBackCondition {
+	this := &UpgradeRolledBackCondition{}
+	this.SetType(CONDITION_TYPE_UPGRADE_ROLLED_BACK)
+	this.Reset()
+	return this
+}
+
+func (this *UpgradeRolledBackCondition) IsActive() bool {
+	return this.data.Status == metav1.ConditionTrue
+}
+
+// Transitions in decreasing order of priority
+
+func (this *UpgradeRolledBackCondition) TransitionRolledBack(failedImage string, lastKnownGoodImage string) {
+	this.data.Status = metav1.ConditionTrue
+	this.data.Reason = string(UPGRADE_ROLLED_BACK_REASON_AUTO_ROLLBACK)
+	this.data.Message = "Image " + failedImage + " did not become ready, " +
+		"the Deployment has been reverted to the last known good image " + lastKnownGoodImage + ". " +
+		"The image will not be applied again until it is changed."
+}
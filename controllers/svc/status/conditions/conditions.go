@@ -10,6 +10,9 @@ const (
 	CONDITION_TYPE_READY                   ConditionType = "Ready"
 	CONDITION_TYPE_CONFIGURATION_ERROR     ConditionType = "ConfigurationError"
 	CONDITION_TYPE_APPLICATION_NOT_HEALTHY ConditionType = "ApplicationNotHealthy"
+	CONDITION_TYPE_ROLLOUT_FAILED          ConditionType = "RolloutFailed"
+	CONDITION_TYPE_UPGRADE_ROLLED_BACK     ConditionType = "UpgradeRolledBack"
+	CONDITION_TYPE_UPGRADE_AVAILABLE       ConditionType = "UpgradeAvailable"
 	// CONDITION_TYPE_OPERATOR_ERROR ConditionType = "OperatorError" // General error
 )
 
@@ -64,6 +67,33 @@ const (
 	APPLICATION_NOT_HEALTHY_REASON_LIVENESS  ApplicationNotHealthyConditionReason = "LivenessProbeFailed"
 )
 
+// ========== RolloutFailedCondition ==========
+
+type RolloutFailedConditionReason string
+
+const (
+	// Priority ordered
+	ROLLOUT_FAILED_REASON_PROGRESS_DEADLINE_EXCEEDED RolloutFailedConditionReason = "ProgressDeadlineExceeded"
+)
+
+// ========== UpgradeRolledBackCondition ==========
+
+type UpgradeRolledBackConditionReason string
+
+const (
+	// Priority ordered
+	UPGRADE_ROLLED_BACK_REASON_AUTO_ROLLBACK UpgradeRolledBackConditionReason = "AutoRollback"
+)
+
+// ========== UpgradeAvailableCondition ==========
+
+type UpgradeAvailableConditionReason string
+
+const (
+	// Priority ordered
+	UPGRADE_AVAILABLE_REASON_APPROVAL_REQUIRED UpgradeAvailableConditionReason = "ApprovalRequired"
+)
+
 // ========== ConditionManager ==========
 
 type ConditionManager interface {
@@ -73,6 +103,12 @@ type ConditionManager interface {
 
 	GetApplicationNotHealthyCondition() *ApplicationNotHealthyCondition
 
+	GetRolloutFailedCondition() *RolloutFailedCondition
+
+	GetUpgradeRolledBackCondition() *UpgradeRolledBackCondition
+
+	GetUpgradeAvailableCondition() *UpgradeAvailableCondition
+
 	// Runs after the control loop is stable
 	AfterLoop()
 
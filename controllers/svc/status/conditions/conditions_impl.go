@@ -54,12 +54,15 @@ var _ ConditionManager = &conditionManager{}
 
 func NewConditionManager(ctx context.LoopContext) ConditionManager {
 	this := &conditionManager{
-		conditionMap: make(map[ConditionType]Condition, 3),
+		conditionMap: make(map[ConditionType]Condition, 6),
 		ctx:          ctx,
 	}
 	this.conditionMap[CONDITION_TYPE_READY] = NewReadyCondition()
 	this.conditionMap[CONDITION_TYPE_CONFIGURATION_ERROR] = NewConfigurationErrorCondition()
 	this.conditionMap[CONDITION_TYPE_APPLICATION_NOT_HEALTHY] = NewApplicationNotHealthyCondition()
+	this.conditionMap[CONDITION_TYPE_ROLLOUT_FAILED] = NewRolloutFailedCondition()
+	this.conditionMap[CONDITION_TYPE_UPGRADE_ROLLED_BACK] = NewUpgradeRolledBackCondition()
+	this.conditionMap[CONDITION_TYPE_UPGRADE_AVAILABLE] = NewUpgradeAvailableCondition()
 	return this
 }
 
@@ -75,6 +78,18 @@ func (this *conditionManager) GetApplicationNotHealthyCondition() *ApplicationNo
 	return this.conditionMap[CONDITION_TYPE_APPLICATION_NOT_HEALTHY].(*ApplicationNotHealthyCondition)
 }
 
+func (this *conditionManager) GetRolloutFailedCondition() *RolloutFailedCondition {
+	return this.conditionMap[CONDITION_TYPE_ROLLOUT_FAILED].(*RolloutFailedCondition)
+}
+
+func (this *conditionManager) GetUpgradeRolledBackCondition() *UpgradeRolledBackCondition {
+	return this.conditionMap[CONDITION_TYPE_UPGRADE_ROLLED_BACK].(*UpgradeRolledBackCondition)
+}
+
+func (this *conditionManager) GetUpgradeAvailableCondition() *UpgradeAvailableCondition {
+	return this.conditionMap[CONDITION_TYPE_UPGRADE_AVAILABLE].(*UpgradeAvailableCondition)
+}
+
 // Mark the status as `Reconciling` if there was a CF execution, (and reschedule) otherwise
 // mask as `Reconciled`
 func (this *conditionManager) AfterLoop() {
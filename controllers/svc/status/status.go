@@ -3,7 +3,7 @@ package status
 import (
 	"strconv"
 
-	api "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	api "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/loop/context"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/resources"
 	"github.com/Apicurio/apicurio-registry-operator/controllers/svc/status/conditions"
@@ -15,12 +15,16 @@ const CFG_STA_IMAGE = "CFG_STA_IMAGE"
 const CFG_STA_DEPLOYMENT_NAME = "CFG_STA_DEPLOYMENT_NAME"
 const CFG_STA_SERVICE_NAME = "CFG_STA_SERVICE_NAME"
 const CFG_STA_INGRESS_NAME = "CFG_STA_INGRESS_NAME"
+const CFG_STA_ROUTE_NAME = "CFG_STA_ROUTE_NAME"
 const CFG_STA_NETWORK_POLICY_NAME = "CFG_STA_NETWORK_POLICY_NAME"
 const CFG_STA_POD_DISRUPTION_BUDGET_NAME = "CFG_STA_POD_DISRUPTION_BUDGET_NAME"
 
 const CFG_STA_REPLICA_COUNT = "CFG_STA_REPLICA_COUNT"
 const CFG_STA_ROUTE = "CFG_STA_ROUTE"
 
+const CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE = "CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE"
+const CFG_STA_UPGRADE_FAILED_IMAGE = "CFG_STA_UPGRADE_FAILED_IMAGE"
+
 type Status struct {
 	config     map[string]string
 	ctx        context.LoopContext
@@ -46,11 +50,15 @@ func (this *Status) init() {
 	this.set(this.config, CFG_STA_DEPLOYMENT_NAME, "")
 	this.set(this.config, CFG_STA_SERVICE_NAME, "")
 	this.set(this.config, CFG_STA_INGRESS_NAME, "")
+	this.set(this.config, CFG_STA_ROUTE_NAME, "")
 	this.set(this.config, CFG_STA_NETWORK_POLICY_NAME, "")
 	this.set(this.config, CFG_STA_POD_DISRUPTION_BUDGET_NAME, "")
 
 	this.set(this.config, CFG_STA_REPLICA_COUNT, "")
 	this.set(this.config, CFG_STA_ROUTE, "")
+
+	this.set(this.config, CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE, "")
+	this.set(this.config, CFG_STA_UPGRADE_FAILED_IMAGE, "")
 }
 
 // =====
@@ -100,6 +108,9 @@ func (this *Status) ComputeStatus() {
 		entry.ApplyPatch(func(value interface{}) interface{} {
 			status := value.(*api.ApicurioRegistryStatus).DeepCopy()
 
+			// Phase, the cleanup phases are reported by the reconciler, when the loop is not executed
+			status.Phase = api.PhaseActive
+
 			// Info
 			status.Info.Host = this.GetConfig(CFG_STA_ROUTE)
 
@@ -130,6 +141,13 @@ func (this *Status) ComputeStatus() {
 					Name:      this.GetConfig(CFG_STA_INGRESS_NAME),
 				})
 			}
+			if this.GetConfig(CFG_STA_ROUTE_NAME) != "" {
+				res = append(res, api.ApicurioRegistryStatusManagedResource{
+					Kind:      "Route",
+					Namespace: this.ctx.GetAppNamespace().Str(),
+					Name:      this.GetConfig(CFG_STA_ROUTE_NAME),
+				})
+			}
 			if this.GetConfig(CFG_STA_NETWORK_POLICY_NAME) != "" {
 				res = append(res, api.ApicurioRegistryStatusManagedResource{
 					Kind:      "NetworkPolicy",
@@ -146,6 +164,10 @@ func (this *Status) ComputeStatus() {
 			}
 			status.ManagedResources = res
 
+			// Upgrade
+			status.Upgrade.LastKnownGoodImage = this.GetConfig(CFG_STA_UPGRADE_LAST_KNOWN_GOOD_IMAGE)
+			status.Upgrade.FailedImage = this.GetConfig(CFG_STA_UPGRADE_FAILED_IMAGE)
+
 			return status
 		})
 	}
@@ -28,12 +28,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"strings"
 
-	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar_v1 "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers"
+	"github.com/Apicurio/apicurio-registry-operator/controllers/client"
 	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/go-logr/zapr"
 	ocp_apps "github.com/openshift/api/apps/v1"
+	ocp_route "github.com/openshift/api/route/v1"
 	monitoring "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -41,6 +47,7 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	cr_client "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	// +kubebuilder:scaffold:imports
@@ -53,20 +60,61 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ar_v1.AddToScheme(scheme))
 	utilruntime.Must(ar.AddToScheme(scheme))
 	utilruntime.Must(ocp_apps.AddToScheme(scheme))
+	utilruntime.Must(ocp_route.AddToScheme(scheme))
 	utilruntime.Must(monitoring.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
-func initControllers(mgr manager.Manager) error {
+func initControllers(mgr manager.Manager, namespaceSelector labels.Selector) error {
 
 	rootLog := c.GetRootLogger(false)
-	if _, err := controllers.NewApicurioRegistryReconciler(mgr, rootLog, common.NewTestSupport(rootLog, false)); err != nil {
+	scope := controllers.NewNamespaceScope(rootLog, mgr.GetClient(), namespaceSelector)
+	operatorConfig := c.NewOperatorConfigFromEnv(rootLog, mgr.GetClient())
+	if namespaceSelector != nil {
+		setupLog.Info("watching the namespaces selected by " + namespaceSelector.String())
+	}
+	if _, err := controllers.NewApicurioRegistryReconciler(mgr, rootLog, common.NewTestSupport(rootLog, false), scope, operatorConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ApicurioRegistry")
 		return errors.New("unable to create ApicurioRegistry controller")
 	}
 
+	// Apicurio Registry content
+	clients := client.NewClients(rootLog.Named("clients"), mgr.GetScheme(), mgr.GetConfig())
+	if _, err := controllers.NewApicurioRegistryGlobalRuleReconciler(mgr, rootLog, clients, scope, operatorConfig); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApicurioRegistryGlobalRule")
+		return errors.New("unable to create ApicurioRegistryGlobalRule controller")
+	}
+	if _, err := controllers.NewApicurioRegistryGroupReconciler(mgr, rootLog, clients, scope, operatorConfig); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApicurioRegistryGroup")
+		return errors.New("unable to create ApicurioRegistryGroup controller")
+	}
+	if _, err := controllers.NewApicurioRegistryRoleMappingReconciler(mgr, rootLog, clients, scope, operatorConfig); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApicurioRegistryRoleMapping")
+		return errors.New("unable to create ApicurioRegistryRoleMapping controller")
+	}
+	if _, err := controllers.NewApicurioRegistryArtifactReconciler(mgr, rootLog, clients, scope, operatorConfig); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApicurioRegistryArtifact")
+		return errors.New("unable to create ApicurioRegistryArtifact controller")
+	}
+
+	return nil
+}
+
+func initWebhooks(mgr manager.Manager) error {
+
+	// Webhooks can be disabled when running the Operator outside of the cluster
+	if os.Getenv("ENABLE_WEBHOOKS") == "false" {
+		setupLog.Info("webhooks are disabled")
+		return nil
+	}
+	if err := (&ar.ApicurioRegistry{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ApicurioRegistry")
+		return errors.New("unable to create ApicurioRegistry webhook")
+	}
+
 	return nil
 }
 
@@ -89,11 +137,19 @@ func main() {
 	logger := common.GetRootLogger(false)
 	ctrl.SetLogger(zapr.NewLogger(logger))
 
-	namespaces, err := getWatchNamespace()
+	namespaceSelector, err := getWatchNamespaceSelector()
 	if err != nil {
-		setupLog.Error(err, "Failed to get watch namespaces.")
+		setupLog.Error(err, "Failed to parse the watch namespace selector.")
 		os.Exit(1)
 	}
+	namespaces := "" // Empty = all, the namespaces are selected by their labels
+	if namespaceSelector == nil {
+		namespaces, err = getWatchNamespace()
+		if err != nil {
+			setupLog.Error(err, "Failed to get watch namespaces.")
+			os.Exit(1)
+		}
+	}
 
 	newCache := func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
 		namespaces := strings.Split(namespaces, ",") // Empty = all
@@ -101,6 +157,15 @@ func main() {
 		for _, n := range namespaces {
 			opts.DefaultNamespaces[n] = cache.Config{}
 		}
+		// Only the Operator configuration ConfigMap is watched, and it may be outside of the watched namespaces
+		if configNamespace, configName := c.GetOperatorConfigMapFromEnv(); configNamespace != "" {
+			opts.ByObject = map[cr_client.Object]cache.ByObject{
+				&core.ConfigMap{}: {
+					Namespaces: map[string]cache.Config{configNamespace.Str(): {}},
+					Field:      fields.OneTermEqualSelector("metadata.name", configName.Str()),
+				},
+			}
+		}
 		return cache.New(config, opts)
 	}
 
@@ -143,10 +208,15 @@ func main() {
 	}
 
 	// Controller(s)
-	if err := initControllers(mgr); err != nil {
+	if err := initControllers(mgr, namespaceSelector); err != nil {
 		setupLog.Error(err, "unable to create controllers")
 		os.Exit(1)
 	}
+	// Webhook(s)
+	if err := initWebhooks(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhooks")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
@@ -173,3 +243,12 @@ func getWatchNamespace() (string, error) {
 	}
 	return ns, nil
 }
+
+// Returns nil if the namespaces are not selected by their labels
+func getWatchNamespaceSelector() (labels.Selector, error) {
+	selector, found := os.LookupEnv(controllers.ENV_WATCH_NAMESPACE_SELECTOR)
+	if !found || strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	return labels.Parse(selector)
+}
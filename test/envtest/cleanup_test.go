@@ -0,0 +1,152 @@
+package envtest
+
+import (
+	"context"
+	ar "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
+	"github.com/Apicurio/apicurio-registry-operator/controllers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"time"
+)
+
+var _ = Describe("operator cleaning up the managed resources", Ordered, func() {
+
+	const testNamespace = "cleanup-test-namespace"
+
+	// Keeps the ApicurioRegistry resource after the Operator has removed its finalizer
+	const testFinalizer = "test.apicur.io/keep"
+
+	// The garbage collector does not run in envtest, so the resources are removed only by the Operator
+	managedResources := func(registryKey types.NamespacedName) map[string]client.Object {
+		return map[string]client.Object{
+			registryKey.Name + "-deployment":    &apps.Deployment{},
+			registryKey.Name + "-service":       &core.Service{},
+			registryKey.Name + "-ingress":       &networking.Ingress{},
+			registryKey.Name + "-networkpolicy": &networking.NetworkPolicy{},
+		}
+	}
+
+	createRegistry := func(name string, finalizers []string, retainOnDeletion []ar.ApicurioRegistryManagedResourceKind) *ar.ApicurioRegistry {
+		registry := &ar.ApicurioRegistry{
+			ObjectMeta: meta.ObjectMeta{
+				Name:       name,
+				Namespace:  testNamespace,
+				Finalizers: finalizers,
+			},
+			Spec: ar.ApicurioRegistrySpec{
+				Deployment: ar.ApicurioRegistrySpecDeployment{
+					ManagedResources: ar.ApicurioRegistrySpecDeploymentManagedResources{
+						RetainOnDeletion: retainOnDeletion,
+					},
+				},
+			},
+		}
+		Expect(s.k8sClient.Create(s.ctx, registry)).To(Succeed())
+		registryKey := types.NamespacedName{Namespace: testNamespace, Name: name}
+		for resourceName, resource := range managedResources(registryKey) {
+			resourceKey := types.NamespacedName{Namespace: testNamespace, Name: resourceName}
+			Eventually(func() error {
+				return s.k8sClient.Get(s.ctx, resourceKey, resource)
+			}, 10*time.Second*T_SCALE, EVENTUALLY_CHECK_PERIOD).Should(Succeed())
+		}
+		Expect(s.k8sClient.Get(s.ctx, registryKey, registry)).To(Succeed())
+		return registry
+	}
+
+	isDeleted := func(key types.NamespacedName, object client.Object) bool {
+		return errors.IsNotFound(s.k8sClient.Get(s.ctx, key, object))
+	}
+
+	BeforeAll(func() {
+		// Speed the tests up
+		testSupport.SetMockCanMakeHTTPRequestToOperand(testNamespace, true)
+		testSupport.SetMockOperandMetricsReportReady(testNamespace, true)
+		testSupport.SetMockOperatorRestart(testNamespace, false)
+		ns := &core.Namespace{
+			ObjectMeta: meta.ObjectMeta{
+				Name: testNamespace,
+			},
+		}
+		Expect(s.k8sClient.Create(context.TODO(), ns)).To(Succeed())
+	})
+
+	It("should remove the managed resources before the finalizer", func() {
+		registry := createRegistry("finalizer", []string{testFinalizer}, nil)
+		registryKey := types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
+		Eventually(func() bool {
+			Expect(s.k8sClient.Get(s.ctx, registryKey, registry)).To(Succeed())
+			return controllerutil.ContainsFinalizer(registry, controllers.CleanupFinalizer)
+		}, 10*time.Second*T_SCALE, EVENTUALLY_CHECK_PERIOD).Should(BeTrue())
+
+		Expect(s.k8sClient.Delete(s.ctx, registry)).To(Succeed())
+		for resourceName, resource := range managedResources(registryKey) {
+			resourceKey := types.NamespacedName{Namespace: testNamespace, Name: resourceName}
+			Eventually(func() bool {
+				return isDeleted(resourceKey, resource)
+			}, 20*time.Second*T_SCALE, EVENTUALLY_CHECK_PERIOD).Should(BeTrue())
+		}
+		// The cleanup has finished, only the finalizer of the test is left
+		Eventually(func() []string {
+			Expect(s.k8sClient.Get(s.ctx, registryKey, registry)).To(Succeed())
+			return registry.Finalizers
+		}, 10*time.Second*T_SCALE, EVENTUALLY_CHECK_PERIOD).Should(ConsistOf(testFinalizer))
+		Expect(registry.Status.Phase).To(Equal(ar.PhaseDeleting))
+
+		controllerutil.RemoveFinalizer(registry, testFinalizer)
+		Expect(s.k8sClient.Update(s.ctx, registry)).To(Succeed())
+		Eventually(func() bool {
+			return isDeleted(registryKey, &ar.ApicurioRegistry{})
+		}, 10*time.Second*T_SCALE, EVENTUALLY_CHECK_PERIOD).Should(BeTrue())
+	})
+
+	It("should find the managed resources after the Operator restart", func() {
+		registry := createRegistry("restart", nil, nil)
+		registryKey := types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
+
+		// The control loop is created again with an empty resource cache
+		testSupport.SetMockOperatorRestart(testNamespace, true)
+		Expect(s.k8sClient.Delete(s.ctx, registry)).To(Succeed())
+		Eventually(func() bool {
+			return isDeleted(registryKey, &ar.ApicurioRegistry{})
+		}, 20*time.Second*T_SCALE, EVENTUALLY_CHECK_PERIOD).Should(BeTrue())
+		Expect(testSupport.GetMockOperatorRestart(testNamespace)).To(BeFalse())
+		for resourceName, resource := range managedResources(registryKey) {
+			resourceKey := types.NamespacedName{Namespace: testNamespace, Name: resourceName}
+			Expect(isDeleted(resourceKey, resource)).To(BeTrue(), resourceName)
+		}
+	})
+
+	It("should keep the retained resources without the owner reference", func() {
+		registry := createRegistry("retain", nil, []ar.ApicurioRegistryManagedResourceKind{
+			ar.ManagedResourceKindService,
+			ar.ManagedResourceKindNetworkPolicy,
+		})
+		registryKey := types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
+		serviceKey := types.NamespacedName{Namespace: testNamespace, Name: registry.Name + "-service"}
+		npKey := types.NamespacedName{Namespace: testNamespace, Name: registry.Name + "-networkpolicy"}
+		service := &core.Service{}
+		Expect(s.k8sClient.Get(s.ctx, serviceKey, service)).To(Succeed())
+		Expect(service.OwnerReferences).To(ContainElement(HaveField("UID", registry.UID)))
+
+		Expect(s.k8sClient.Delete(s.ctx, registry)).To(Succeed())
+		Eventually(func() bool {
+			return isDeleted(registryKey, &ar.ApicurioRegistry{})
+		}, 20*time.Second*T_SCALE, EVENTUALLY_CHECK_PERIOD).Should(BeTrue())
+
+		Expect(isDeleted(types.NamespacedName{Namespace: testNamespace, Name: registry.Name + "-deployment"}, &apps.Deployment{})).To(BeTrue())
+		Expect(isDeleted(types.NamespacedName{Namespace: testNamespace, Name: registry.Name + "-ingress"}, &networking.Ingress{})).To(BeTrue())
+		Expect(s.k8sClient.Get(s.ctx, serviceKey, service)).To(Succeed())
+		Expect(service.OwnerReferences).NotTo(ContainElement(HaveField("UID", registry.UID)))
+		np := &networking.NetworkPolicy{}
+		Expect(s.k8sClient.Get(s.ctx, npKey, np)).To(Succeed())
+		Expect(np.OwnerReferences).NotTo(ContainElement(HaveField("UID", registry.UID)))
+	})
+})
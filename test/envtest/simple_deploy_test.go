@@ -46,7 +46,12 @@ var _ = Describe("operator processing a simple spec", Ordered, func() {
 				Name:      registryName,
 				Namespace: ns.ObjectMeta.Name,
 			},
-			Spec: ar.ApicurioRegistrySpec{},
+			Spec: ar.ApicurioRegistrySpec{
+				Deployment: ar.ApicurioRegistrySpecDeployment{
+					// The PDB is not created for a single replica
+					Replicas: 2,
+				},
+			},
 		}
 		Expect(s.k8sClient.Create(s.ctx, registry)).To(Succeed())
 		registryKey = types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
@@ -3,6 +3,7 @@ package envtest
 import (
 	"context"
 	ar "github.com/Apicurio/apicurio-registry-operator/api/v1"
+	ar_v1beta2 "github.com/Apicurio/apicurio-registry-operator/api/v1beta2"
 	"github.com/Apicurio/apicurio-registry-operator/controllers"
 	c "github.com/Apicurio/apicurio-registry-operator/controllers/common"
 	"github.com/go-logr/zapr"
@@ -15,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	cr_log "sigs.k8s.io/controller-runtime/pkg/log"
 	kzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"testing"
 	"time"
 )
@@ -52,28 +54,42 @@ var _ = BeforeSuite(func() {
 
 	s.ctx, cancel = context.WithCancel(context.TODO())
 
+	// Both versions must be known before the environment starts,
+	// so the conversion webhook is configured in the CRD
+	Expect(ar.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(ar_v1beta2.AddToScheme(scheme.Scheme)).To(Succeed())
+
 	testEnv = &envtest.Environment{
 		CRDDirectoryPaths:     []string{root + "/config/crd/resources"},
 		ErrorIfCRDPathMissing: true,
 		CRDInstallOptions: envtest.CRDInstallOptions{
 			MaxTime: 60 * time.Second,
+			Scheme:  scheme.Scheme,
+		},
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			LocalServingHost: "127.0.0.1",
 		},
 	}
 	cfg, err := testEnv.Start()
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cfg).NotTo(BeNil())
 
-	Expect(ar.AddToScheme(scheme.Scheme)).To(Succeed())
-
 	s.k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(s.k8sClient).NotTo(BeNil())
 
 	k8sManager, err := cr.NewManager(cfg, cr.Options{
 		Scheme: scheme.Scheme,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    testEnv.WebhookInstallOptions.LocalServingHost,
+			Port:    testEnv.WebhookInstallOptions.LocalServingPort,
+			CertDir: testEnv.WebhookInstallOptions.LocalServingCertDir,
+		}),
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	Expect((&ar_v1beta2.ApicurioRegistry{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
+
 	testSupport = c.NewTestSupport(s.log, true)
 
 	Expect(os.Setenv("REGISTRY_VERSION", "2.x")).To(Succeed())
@@ -82,7 +98,8 @@ var _ = BeforeSuite(func() {
 	Expect(os.Setenv("REGISTRY_IMAGE_KAFKASQL", "quay.io/apicurio/apicurio-registry-kafkasql:latest-snapshot")).To(Succeed())
 	Expect(os.Setenv("REGISTRY_IMAGE_SQL", "quay.io/apicurio/apicurio-registry-sql:latest-snapshot")).To(Succeed())
 
-	reconciler, err := controllers.NewApicurioRegistryReconciler(k8sManager, s.log, testSupport)
+	reconciler, err := controllers.NewApicurioRegistryReconciler(k8sManager, s.log, testSupport,
+		controllers.NewNamespaceScope(s.log, k8sManager.GetClient(), nil), c.NewOperatorConfig(s.log, nil, "", ""))
 	Expect(err).ToNot(HaveOccurred())
 	Expect(reconciler).NotTo(BeNil())
 